@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	enccsv "encoding/csv"
 	encjson "encoding/json"
 	"fmt"
 	"io"
@@ -31,8 +32,10 @@ import (
 
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgo/x"
+	"github.com/dgraph-io/dgraph/chunker/csv"
 	"github.com/dgraph-io/dgraph/chunker/json"
 	"github.com/dgraph-io/dgraph/chunker/rdf"
+	"github.com/dgraph-io/dgraph/ee/enc"
 
 	"github.com/pkg/errors"
 )
@@ -47,10 +50,21 @@ type Chunker interface {
 type rdfChunker struct{}
 type jsonChunker struct{}
 
+// csvChunker reads the records of a CSV file. Each chunk starts with the header of the file, so
+// that it can be parsed on its own. The chunks are always written with commas, so that parsing
+// them doesn't depend on the delimiter of the file.
+type csvChunker struct {
+	mapping *csv.Mapping
+	comma   rune
+	reader  *enccsv.Reader
+	header  []string
+}
+
 const (
 	UnknownFormat int = iota
 	RdfFormat
 	JsonFormat
+	CsvFormat
 )
 
 func NewChunker(inputFormat int) Chunker {
@@ -59,11 +73,20 @@ func NewChunker(inputFormat int) Chunker {
 		return &rdfChunker{}
 	case JsonFormat:
 		return &jsonChunker{}
+	case CsvFormat:
+		panic("CSV needs a mapping, use NewCSVChunker")
 	default:
 		panic("unknown input format")
 	}
 }
 
+// NewCSVChunker returns a Chunker of CSV files, whose records are turned into N-Quads as
+// described by the mapping. The values of the records of the files it reads are separated by
+// comma, see csv.Mapping#Comma.
+func NewCSVChunker(mapping *csv.Mapping, comma rune) Chunker {
+	return &csvChunker{mapping: mapping, comma: comma}
+}
+
 // RDF files don't require any special processing at the beginning of the file.
 func (rdfChunker) Begin(r *bufio.Reader) error {
 	return nil
@@ -237,6 +260,68 @@ func (jsonChunker) End(r *bufio.Reader) error {
 	return errors.New("Not all of JSON file consumed")
 }
 
+// Begin reads the header of the CSV file.
+func (c *csvChunker) Begin(r *bufio.Reader) error {
+	c.reader = enccsv.NewReader(r)
+	c.reader.Comma = c.comma
+	header, err := c.reader.Read()
+	if err != nil {
+		return errors.Wrapf(err, "while reading the CSV header")
+	}
+	if err := c.mapping.CheckHeader(header); err != nil {
+		return err
+	}
+	c.header = header
+	return nil
+}
+
+// Chunk reads up to 1e4 records, and writes them after the header to the chunk.
+func (c *csvChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
+	batch := new(bytes.Buffer)
+	w := enccsv.NewWriter(batch)
+	x.Check(w.Write(c.header))
+	for i := 0; i < 1e4; i++ {
+		record, err := c.reader.Read()
+		if err == io.EOF {
+			w.Flush()
+			return batch, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		x.Check(w.Write(record))
+	}
+	w.Flush()
+	return batch, w.Error()
+}
+
+func (c *csvChunker) Parse(chunkBuf *bytes.Buffer) ([]*api.NQuad, error) {
+	if chunkBuf.Len() == 0 {
+		return nil, io.EOF
+	}
+
+	r := enccsv.NewReader(chunkBuf)
+	records, err := r.ReadAll()
+	chunkBuf.Reset()
+	if err != nil {
+		return nil, err
+	}
+	var nqs []*api.NQuad
+	for _, record := range records[1:] {
+		recordNqs, err := c.mapping.Parse(records[0], record)
+		if err != nil {
+			return nil, err
+		}
+		nqs = append(nqs, recordNqs...)
+	}
+	return nqs, nil
+}
+
+// CSV files don't require any special processing at the end of the file.
+func (c *csvChunker) End(r *bufio.Reader) error {
+	return nil
+}
+
 func slurpSpace(r *bufio.Reader) error {
 	for {
 		ch, _, err := r.ReadRune()
@@ -274,9 +359,10 @@ func slurpQuoted(r *bufio.Reader, out *bytes.Buffer) error {
 }
 
 // FileReader returns an open reader and file on the given file. Gzip-compressed input is detected
-// and decompressed automatically even without the gz extension. The caller is responsible for
-// calling the returned cleanup function when done with the reader.
-func FileReader(file string) (rd *bufio.Reader, cleanup func()) {
+// and decompressed automatically even without the gz extension. If key is not nil, the input is
+// decrypted with it first, as written by encrypted exports, and reads fail if it wasn't. The
+// caller is responsible for calling the returned cleanup function when done with the reader.
+func FileReader(file string, key []byte) (*bufio.Reader, func(), error) {
 	var f *os.File
 	var err error
 	if file == "-" {
@@ -284,30 +370,44 @@ func FileReader(file string) (rd *bufio.Reader, cleanup func()) {
 	} else {
 		f, err = os.Open(file)
 	}
+	if err != nil {
+		return nil, nil, err
+	}
 
-	x.Check(err)
-
-	cleanup = func() { f.Close() }
+	var r io.Reader = f
+	if key != nil {
+		if r, err = enc.NewReader(key, f); err != nil {
+			f.Close()
+			return nil, nil, errors.Wrapf(err, "while decrypting %s", file)
+		}
+	}
 
 	if filepath.Ext(file) == ".gz" {
-		gzr, err := gzip.NewReader(f)
-		x.Check(err)
-		rd = bufio.NewReader(gzr)
-		cleanup = func() { f.Close(); gzr.Close() }
-	} else {
-		rd = bufio.NewReader(f)
-		buf, _ := rd.Peek(512)
-
-		typ := http.DetectContentType(buf)
-		if typ == "application/x-gzip" {
-			gzr, err := gzip.NewReader(rd)
-			x.Check(err)
-			rd = bufio.NewReader(gzr)
-			cleanup = func() { f.Close(); gzr.Close() }
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			f.Close()
+			return nil, nil, err
 		}
+		return bufio.NewReader(gzr), func() { f.Close(); gzr.Close() }, nil
 	}
 
-	return rd, cleanup
+	rd := bufio.NewReader(r)
+	buf, err := rd.Peek(512)
+	if err != nil && err != io.EOF {
+		f.Close()
+		return nil, nil, errors.Wrapf(err, "while reading %s", file)
+	}
+
+	typ := http.DetectContentType(buf)
+	if typ == "application/x-gzip" {
+		gzr, err := gzip.NewReader(rd)
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return bufio.NewReader(gzr), func() { f.Close(); gzr.Close() }, nil
+	}
+	return rd, func() { f.Close() }, nil
 }
 
 // IsJSONData returns true if the reader, which should be at the start of the stream, is reading
@@ -324,7 +424,7 @@ func IsJSONData(r *bufio.Reader) (bool, error) {
 	return err == nil, nil
 }
 
-// DataFormat returns a file's data format (RDF, JSON, or unknown) based on the filename
+// DataFormat returns a file's data format (RDF, JSON, CSV or unknown) based on the filename
 // or the user-provided format option. The file extension has precedence.
 func DataFormat(filename string, format string) int {
 	format = strings.ToLower(format)
@@ -334,6 +434,9 @@ func DataFormat(filename string, format string) int {
 		return RdfFormat
 	case strings.HasSuffix(filename, ".json") || format == "json":
 		return JsonFormat
+	case strings.HasSuffix(filename, ".csv") || strings.HasSuffix(filename, ".tsv") ||
+		format == "csv" || format == "tsv":
+		return CsvFormat
 	default:
 		return UnknownFormat
 	}
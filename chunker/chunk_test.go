@@ -21,10 +21,15 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/chunker/csv"
 )
 
 func bufioReader(str string) *bufio.Reader {
@@ -196,3 +201,83 @@ func TestJSONLoadSuccessAll(t *testing.T) {
 	err = chunker.End(reader)
 	require.NoError(t, err, "end reading JSON document")
 }
+
+func TestCSVChunker(t *testing.T) {
+	mapping, err := csv.ParseMapping([]byte(`{
+		"subject": "id",
+		"columns": {"name": {"predicate": "name"}, "bio": {"predicate": "bio"}}
+	}`))
+	require.NoError(t, err)
+	// One more record than fits in a chunk, with values spanning lines.
+	var testDoc bytes.Buffer
+	testDoc.WriteString("id,name,bio\n")
+	for i := 0; i < 1e4+1; i++ {
+		fmt.Fprintf(&testDoc, "%d,name%d,\"line one\nline \"\"two\"\"\"\n", i, i)
+	}
+
+	chunker := NewCSVChunker(mapping, ',')
+	reader := bufio.NewReader(&testDoc)
+	require.NoError(t, chunker.Begin(reader))
+
+	// Every chunk is parsed on its own, by another chunker.
+	parser := NewCSVChunker(mapping, ',')
+	var numRecords int
+	for {
+		chunk, err := chunker.Chunk(reader)
+		if err != io.EOF {
+			require.NoError(t, err)
+		}
+		nqs, perr := parser.Parse(chunk)
+		require.NoError(t, perr)
+		for _, nq := range nqs {
+			if nq.Predicate == "bio" {
+				require.Equal(t, "line one\nline \"two\"", nq.ObjectValue.GetDefaultVal())
+				numRecords++
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	require.Equal(t, 10001, numRecords)
+	require.NoError(t, chunker.End(reader))
+
+	require.Error(t, NewCSVChunker(mapping, ',').Begin(bufioReader("id,age\n1,2\n")))
+
+	// The chunks of TSV files are parsed like the other ones.
+	chunker = NewCSVChunker(mapping, '\t')
+	reader = bufioReader("id\tname\tbio\n1\tAlice\thi, there\n")
+	require.NoError(t, chunker.Begin(reader))
+	chunk, err := chunker.Chunk(reader)
+	require.Equal(t, io.EOF, err)
+	nqs, err := NewCSVChunker(mapping, ',').Parse(chunk)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(nqs))
+	for _, nq := range nqs {
+		if nq.Predicate == "bio" {
+			require.Equal(t, "hi, there", nq.ObjectValue.GetDefaultVal())
+		}
+	}
+}
+
+func TestFileReaderErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chunker")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, _, err = FileReader(filepath.Join(dir, "missing.rdf"), nil)
+	require.Error(t, err)
+
+	// A key given for a file that isn't encrypted is an error, not a crash.
+	file := filepath.Join(dir, "plain.rdf")
+	require.NoError(t, ioutil.WriteFile(file, []byte("<a> <b> <c> .\n"), 0644))
+	_, _, err = FileReader(file, []byte("0123456789abcdef"))
+	require.Error(t, err)
+
+	r, cleanup, err := FileReader(file, nil)
+	require.NoError(t, err)
+	defer cleanup()
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "<a> <b> <c> .\n", line)
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Column maps a column of the CSV file to a predicate.
+type Column struct {
+	Predicate string `json:"predicate"`
+	// Type is the type of the values, like int or datetime, or uid for the columns holding the
+	// xids of other nodes. The values are untyped by default.
+	Type string `json:"type"`
+	// Prefix is prepended to the values of uid columns to get the xids of the nodes they point to.
+	Prefix string `json:"prefix"`
+
+	tid types.TypeID
+}
+
+// Mapping describes how the records of a CSV file are turned into N-Quads. Each record is a node,
+// whose xid is the value of the Subject column prepended by Prefix.
+type Mapping struct {
+	Subject string `json:"subject"`
+	Prefix  string `json:"prefix"`
+	// Delimiter separates the values of a record. It defaults to a tab for TSV files, and to a
+	// comma for the other ones.
+	Delimiter string `json:"delimiter"`
+	// DgraphType is set as the dgraph.type of every node, if not empty.
+	DgraphType string `json:"dgraph_type"`
+	// Columns maps the names of the columns, from the header of the file, to predicates. The
+	// other columns are ignored.
+	Columns map[string]*Column `json:"columns"`
+
+	comma rune
+}
+
+// ReadMapping reads and validates the JSON encoded Mapping in the file.
+func ReadMapping(file string) (*Mapping, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, x.Wrapf(err, "While reading CSV mapping %q", file)
+	}
+	m, err := ParseMapping(b)
+	if err != nil {
+		return nil, x.Wrapf(err, "In CSV mapping %q", file)
+	}
+	return m, nil
+}
+
+// ParseMapping parses and validates the JSON encoded Mapping.
+func ParseMapping(b []byte) (*Mapping, error) {
+	var m Mapping
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (m *Mapping) validate() error {
+	if m.Subject == "" {
+		return x.Errorf("The subject column is not set")
+	}
+	if m.Delimiter != "" {
+		r, size := utf8.DecodeRuneInString(m.Delimiter)
+		if size != len(m.Delimiter) || r == '"' || r == '\n' || r == '\r' {
+			return x.Errorf("Invalid delimiter %q", m.Delimiter)
+		}
+		m.comma = r
+	}
+	if len(m.Columns) == 0 {
+		return x.Errorf("No columns are mapped")
+	}
+	for name, col := range m.Columns {
+		if col == nil || col.Predicate == "" {
+			return x.Errorf("The predicate of column %q is not set", name)
+		}
+		if col.Type == "" {
+			col.tid = types.DefaultID
+			continue
+		}
+		tid, ok := types.TypeForName(col.Type)
+		if !ok {
+			return x.Errorf("Unknown type %q of column %q", col.Type, name)
+		}
+		col.tid = tid
+	}
+	return nil
+}
+
+// Comma returns the delimiter of the values of the records of the file. Files are TSV if their
+// name ends in .tsv or .tsv.gz, or if format is tsv.
+func (m *Mapping) Comma(filename, format string) rune {
+	switch {
+	case m.comma != 0:
+		return m.comma
+	case strings.HasSuffix(strings.TrimSuffix(strings.ToLower(filename), ".gz"), ".tsv"),
+		strings.ToLower(format) == "tsv":
+		return '\t'
+	default:
+		return ','
+	}
+}
+
+// CheckHeader returns an error if the header of a CSV file lacks a column of the mapping.
+func (m *Mapping) CheckHeader(header []string) error {
+	names := make(map[string]bool, len(header))
+	for _, name := range header {
+		names[name] = true
+	}
+	if !names[m.Subject] {
+		return x.Errorf("Subject column %q is missing from the header %q", m.Subject, header)
+	}
+	for name := range m.Columns {
+		if !names[name] {
+			return x.Errorf("Column %q is missing from the header %q", name, header)
+		}
+	}
+	return nil
+}
+
+// Parse returns the N-Quads of the record, whose columns are named by header. Empty values are
+// skipped.
+func (m *Mapping) Parse(header, record []string) ([]*api.NQuad, error) {
+	if len(record) != len(header) {
+		return nil, x.Errorf("Record %q has %d values, expected %d", record, len(record),
+			len(header))
+	}
+	var subject string
+	for i, name := range header {
+		if name == m.Subject {
+			subject = record[i]
+		}
+	}
+	if subject == "" {
+		return nil, x.Errorf("Record %q has no subject", record)
+	}
+	subject = "_:" + m.Prefix + subject
+
+	var nqs []*api.NQuad
+	if m.DgraphType != "" {
+		nqs = append(nqs, &api.NQuad{
+			Subject:     subject,
+			Predicate:   "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: m.DgraphType}},
+		})
+	}
+	for i, name := range header {
+		col, ok := m.Columns[name]
+		if !ok || record[i] == "" {
+			continue
+		}
+		nq := &api.NQuad{Subject: subject, Predicate: col.Predicate}
+		switch col.tid {
+		case types.UidID:
+			nq.ObjectId = "_:" + col.Prefix + record[i]
+		case types.DefaultID:
+			nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: record[i]}}
+		default:
+			src := types.Val{Tid: types.StringID, Value: []byte(record[i])}
+			val, err := types.Convert(src, col.tid)
+			if err != nil {
+				return nil, x.Wrapf(err, "While converting the value %q of column %q",
+					record[i], name)
+			}
+			if nq.ObjectValue, err = types.ObjectValue(col.tid, val.Value); err != nil {
+				return nil, err
+			}
+		}
+		nqs = append(nqs, nq)
+	}
+	return nqs, nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+const testMapping = `{
+	"subject": "id",
+	"prefix": "person.",
+	"delimiter": "\t",
+	"dgraph_type": "Person",
+	"columns": {
+		"name": {"predicate": "name"},
+		"age": {"predicate": "age", "type": "int"},
+		"company_id": {"predicate": "works_for", "type": "uid", "prefix": "company."}
+	}
+}`
+
+func TestComma(t *testing.T) {
+	m, err := ParseMapping([]byte(`{"subject": "id", "columns": {"name": {"predicate": "name"}}}`))
+	require.NoError(t, err)
+	require.Equal(t, ',', m.Comma("people.csv.gz", ""))
+	require.Equal(t, ',', m.Comma("people", "csv"))
+	require.Equal(t, '\t', m.Comma("people.TSV", ""))
+	require.Equal(t, '\t', m.Comma("people.tsv.gz", "csv"))
+	require.Equal(t, '\t', m.Comma("people", "tsv"))
+
+	m, err = ParseMapping([]byte(`{"subject": "id", "delimiter": ";",
+		"columns": {"name": {"predicate": "name"}}}`))
+	require.NoError(t, err)
+	require.Equal(t, ';', m.Comma("people.tsv", ""))
+}
+
+func TestParse(t *testing.T) {
+	m, err := ParseMapping([]byte(testMapping))
+	require.NoError(t, err)
+	require.Equal(t, '\t', m.Comma("people.csv", ""))
+
+	header := []string{"id", "name", "age", "company_id", "ignored"}
+	require.NoError(t, m.CheckHeader(header))
+	require.Error(t, m.CheckHeader([]string{"id", "name", "age"}))
+
+	nqs, err := m.Parse(header, []string{"1", "Alice", "32", "7", "x"})
+	require.NoError(t, err)
+	require.Equal(t, []*api.NQuad{
+		{
+			Subject:     "_:person.1",
+			Predicate:   "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: "Person"}},
+		},
+		{
+			Subject:     "_:person.1",
+			Predicate:   "name",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Alice"}},
+		},
+		{
+			Subject:     "_:person.1",
+			Predicate:   "age",
+			ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 32}},
+		},
+		{
+			Subject:   "_:person.1",
+			Predicate: "works_for",
+			ObjectId:  "_:company.7",
+		},
+	}, nqs)
+
+	// Empty values are skipped.
+	nqs, err = m.Parse(header, []string{"2", "Bob", "", "", ""})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(nqs))
+
+	_, err = m.Parse(header, []string{"3", "Carol", "old", "", ""})
+	require.Error(t, err)
+	_, err = m.Parse(header, []string{"", "Dave", "40", "", ""})
+	require.Error(t, err)
+}
+
+func TestReadMappingErrors(t *testing.T) {
+	for _, mapping := range []string{
+		`{"columns": {"name": {"predicate": "name"}}}`,
+		`{"subject": "id"}`,
+		`{"subject": "id", "columns": {"name": {}}}`,
+		`{"subject": "id", "columns": {"name": {"predicate": "name", "type": "text"}}}`,
+		`{"subject": "id", "delimiter": ";;", "columns": {"name": {"predicate": "name"}}}`,
+	} {
+		_, err := ParseMapping([]byte(mapping))
+		require.Error(t, err, mapping)
+	}
+}
@@ -224,6 +224,9 @@ func mapToNquads(m map[string]interface{}, idx *int, op int, parentPred string)
 				uid = 0
 			} else if ok := strings.HasPrefix(uidVal, "_:"); ok {
 				mr.uid = uidVal
+			} else if ok := strings.HasPrefix(uidVal, "uid("); ok {
+				// uid(v) refers to a variable of the query in an upsert block.
+				mr.uid = uidVal
 			} else if u, err := strconv.ParseUint(uidVal, 0, 64); err == nil {
 				uid = u
 			} else {
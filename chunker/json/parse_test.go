@@ -241,6 +241,18 @@ func TestNquadsFromJson_BlankNodes(t *testing.T) {
 	require.Contains(t, nq, makeNquadEdge("_:alice", "school", "_:school"))
 }
 
+func TestNquadsFromJson_UidVariables(t *testing.T) {
+	json := `{"uid":"uid(v)","name":"Alice","friend":[{"uid":"uid(w)"}]}`
+
+	nq, err := Parse([]byte(json), SetNquads)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(nq))
+	oval := &api.Value{Val: &api.Value_StrVal{StrVal: "Alice"}}
+	require.Contains(t, nq, makeNquad("uid(v)", "name", oval))
+	require.Contains(t, nq, makeNquadEdge("uid(v)", "friend", "uid(w)"))
+}
+
 func TestNquadsDeleteEdges(t *testing.T) {
 	json := `[{"uid": "0x1","name":null,"mobile":null,"car":null}]`
 	nq, err := Parse([]byte(json), DeleteNquads)
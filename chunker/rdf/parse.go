@@ -78,6 +78,7 @@ L:
 			rnq.Subject = strings.Trim(item.Val, " ")
 
 		case itemVarKeyword:
+			keyword := item.Val
 			it.Next()
 			if item = it.Item(); item.Typ != itemLeftRound {
 				return rnq, x.Errorf("Expected '(', found: %s", item.Val)
@@ -86,9 +87,21 @@ L:
 			if item = it.Item(); item.Typ != itemVarName {
 				return rnq, x.Errorf("Expected variable name, found: %s", item.Val)
 			}
+			ref := keyword + "(" + item.Val + ")"
 
 			it.Next() // parse ')'
 
+			// The reference is kept as is and replaced once the query of the upsert
+			// block defining the variable has run.
+			switch {
+			case keyword == "val":
+				rnq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: ref}}
+			case rnq.Subject == "":
+				rnq.Subject = ref
+			default:
+				rnq.ObjectId = ref
+			}
+
 		case itemPredicate:
 			// Here we split predicate and lang directive (ex: "name@en"), if needed.
 			rnq.Predicate, rnq.Lang = x.PredicateLang(strings.Trim(item.Val, " "))
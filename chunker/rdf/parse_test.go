@@ -895,6 +895,26 @@ var testNQuads = []struct {
 		input:       `<alice> <age> "13"^^<xs:double> (salary=NaN) .`,
 		expectedErr: true,
 	},
+	{
+		input: `uid(v) <friend> uid(w) .`,
+		nq: api.NQuad{
+			Subject:   "uid(v)",
+			Predicate: "friend",
+			ObjectId:  "uid(w)",
+		},
+	},
+	{
+		input: `uid(v) <name> val(n) .`,
+		nq: api.NQuad{
+			Subject:     "uid(v)",
+			Predicate:   "name",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "val(n)"}},
+		},
+	},
+	{
+		input:       `val(v) <name> "Alice" .`,
+		expectedErr: true,
+	},
 }
 
 func TestLex(t *testing.T) {
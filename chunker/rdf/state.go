@@ -146,6 +146,14 @@ func lexText(l *lex.Lexer) lex.StateFn {
 			l.Emit(itemText)
 			return lexVariable
 
+		case r == 'v':
+			if l.Depth != atObject {
+				return l.Errorf("Unexpected char 'v'")
+			}
+			l.Backup()
+			l.Emit(itemText)
+			return lexVariable
+
 		case isSpace(r):
 			continue
 		default:
@@ -420,10 +428,15 @@ func lexComment(l *lex.Lexer) lex.StateFn {
 	return nil // Stop the run loop.
 }
 
+// lexVariable lexes a uid(v) or val(v) reference to a query variable.
 func lexVariable(l *lex.Lexer) lex.StateFn {
 	var r rune
 
-	for _, c := range "uid" {
+	keyword := "uid"
+	if l.Peek() == 'v' {
+		keyword = "val"
+	}
+	for _, c := range keyword {
 		if r = l.Next(); r != c {
 			return l.Errorf("Unexpected char '%c' when parsing var keyword", r)
 		}
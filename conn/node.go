@@ -532,13 +532,16 @@ func (n *Node) proposeConfChange(ctx context.Context, pb raftpb.ConfChange) erro
 	}
 }
 
-func (n *Node) AddToCluster(ctx context.Context, pid uint64) error {
+// AddToCluster proposes adding the peer pid to the Raft group, as a voter or as a learner. A
+// learner receives the log of the group but doesn't count towards quorum.
+func (n *Node) AddToCluster(ctx context.Context, pid uint64, learner bool) error {
 	addr, ok := n.Peer(pid)
 	x.AssertTruef(ok, "Unable to find conn pool for peer: %#x", pid)
 	rc := &pb.RaftContext{
-		Addr:  addr,
-		Group: n.RaftContext.Group,
-		Id:    pid,
+		Addr:      addr,
+		Group:     n.RaftContext.Group,
+		Id:        pid,
+		IsLearner: learner,
 	}
 	rcBytes, err := rc.Marshal()
 	x.Check(err)
@@ -548,6 +551,9 @@ func (n *Node) AddToCluster(ctx context.Context, pid uint64) error {
 		NodeID:  pid,
 		Context: rcBytes,
 	}
+	if learner {
+		cc.Type = raftpb.ConfChangeAddLearnerNode
+	}
 	err = errInternalRetry
 	for err == errInternalRetry {
 		glog.Infof("Trying to add %#x to cluster. Addr: %v\n", pid, addr)
@@ -151,7 +151,7 @@ func newPool(addr string) (*Pool, error) {
 			grpc.MaxCallRecvMsgSize(x.GrpcMaxSize),
 			grpc.MaxCallSendMsgSize(x.GrpcMaxSize)),
 		grpc.WithBackoffMaxDelay(time.Second),
-		grpc.WithInsecure())
+		transportOption())
 	if err != nil {
 		return nil, err
 	}
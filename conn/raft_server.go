@@ -146,6 +146,11 @@ func (w *RaftServer) IsPeer(ctx context.Context, rc *pb.RaftContext) (*pb.PeerRe
 			return &pb.PeerResponse{Status: true}, nil
 		}
 	}
+	for _, raftIdx := range node._confState.Learners {
+		if rc.Id == raftIdx {
+			return &pb.PeerResponse{Status: true}, nil
+		}
+	}
 	return &pb.PeerResponse{}, nil
 }
 
@@ -183,7 +188,7 @@ func (w *RaftServer) JoinCluster(ctx context.Context,
 	}
 	node.Connect(rc.Id, rc.Addr)
 
-	err := node.AddToCluster(context.Background(), rc.Id)
+	err := node.AddToCluster(context.Background(), rc.Id, rc.IsLearner)
 	glog.Infof("[%#x] Done joining cluster with err: %v", rc.Id, err)
 	return &api.Payload{}, err
 }
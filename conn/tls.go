@@ -0,0 +1,194 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// internalTLS holds the mutual TLS configs of the connections between the Zeros and Alphas.
+var internalTLS struct {
+	server, client *tls.Config
+	// isMember returns true if one of the hosts is the host of a member of the cluster.
+	isMember func(hosts []string) bool
+	// clients are the names of the client certificates allowed to call the loaderMethods.
+	clients map[string]bool
+}
+
+// joinMethods can be called by the nodes before they are members of the cluster, with a node
+// certificate. They only join the cluster, and check the connections to their peers. The other
+// methods can only be called by the members of the cluster.
+var joinMethods = map[string]bool{
+	"/pb.Raft/Heartbeat":   true,
+	"/pb.Raft/JoinCluster": true,
+	"/pb.Raft/IsPeer":      true,
+	"/pb.Zero/Connect":     true,
+}
+
+// loaderMethods can also be called by the loaders, with the client certificates allowed by
+// SetInternalTLS.
+var loaderMethods = map[string]bool{
+	"/pb.Zero/AssignUids":        true,
+	"/pb.Zero/Timestamps":        true,
+	"/pb.Zero/StartIngest":       true,
+	"/pb.Zero/FinishIngest":      true,
+	"/pb.Worker/IngestPredicate": true,
+}
+
+// SetInternalTLS enables mutual TLS for the gRPC connections between the Zeros and Alphas. It
+// must be called before the gRPC servers are created and the connections are opened. isMember
+// returns true if one of the hosts of a peer's node certificate is the host of a member of the
+// cluster, see IsMember. The client certificates named by clients can call the loaderMethods.
+func SetInternalTLS(server, client *tls.Config, isMember func(hosts []string) bool,
+	clients []string) {
+	internalTLS.server = server
+	internalTLS.client = client
+	internalTLS.isMember = isMember
+	internalTLS.clients = make(map[string]bool)
+	for _, name := range clients {
+		internalTLS.clients[name] = true
+	}
+}
+
+// ServerOptions returns the options of the gRPC servers of the internal services, which only
+// accept TLS connections from peers with a certificate issued by the cluster CA, if mutual TLS
+// is enabled.
+func ServerOptions() []grpc.ServerOption {
+	if internalTLS.server == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(internalTLS.server)),
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{},
+			info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := authorizePeer(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream,
+			info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := authorizePeer(ss.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// transportOption returns the dial option securing the connections to the other nodes.
+func transportOption() grpc.DialOption {
+	if internalTLS.client == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(internalTLS.client))
+}
+
+// authorizePeer returns an error if the peer can't call the method. The nodes are identified by
+// their node certificate, which can also authenticate servers, and the loaders by the name of
+// their client certificate, which can't.
+func authorizePeer(ctx context.Context, method string) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "No peer found in the context")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return status.Errorf(codes.Unauthenticated, "Peer %v has no verified certificate", p.Addr)
+	}
+	cert := info.State.VerifiedChains[0][0]
+	if !isNodeCert(cert) {
+		if loaderMethods[method] && internalTLS.clients[cert.Subject.CommonName] {
+			return nil
+		}
+		return status.Errorf(codes.PermissionDenied,
+			"Peer %v with client certificate %q can't call %s", p.Addr, cert.Subject.CommonName,
+			method)
+	}
+	if joinMethods[method] {
+		return nil
+	}
+	hosts := certHosts(cert)
+	if internalTLS.isMember != nil && internalTLS.isMember(hosts) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied,
+		"Peer %v with certificate for %v isn't a member of the cluster", p.Addr, hosts)
+}
+
+// IsMember returns true if one of the hosts is the host of a member in the membership state.
+// No host is accepted until the node learns the members.
+func IsMember(state *pb.MembershipState, hosts []string) bool {
+	if state == nil {
+		return false
+	}
+	member := func(m *pb.Member) bool {
+		host, _, err := net.SplitHostPort(m.Addr)
+		if err != nil {
+			host = m.Addr
+		}
+		for _, h := range hosts {
+			if strings.EqualFold(h, host) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, m := range state.Zeros {
+		if member(m) {
+			return true
+		}
+	}
+	for _, group := range state.Groups {
+		for _, m := range group.Members {
+			if member(m) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isNodeCert returns true if the certificate is a node certificate created by 'dgraph cert',
+// which can also authenticate servers, unlike its client certificates.
+func isNodeCert(cert *x509.Certificate) bool {
+	for _, usage := range cert.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageServerAuth {
+			return true
+		}
+	}
+	return false
+}
+
+func certHosts(cert *x509.Certificate) []string {
+	hosts := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		hosts = append(hosts, ip.String())
+	}
+	return hosts
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestIsMember(t *testing.T) {
+	require.False(t, IsMember(nil, []string{"anyhost"}))
+	require.False(t, IsMember(&pb.MembershipState{}, []string{"anyhost"}))
+
+	state := &pb.MembershipState{
+		Zeros: map[uint64]*pb.Member{1: {Addr: "zero1:5080"}},
+		Groups: map[uint32]*pb.Group{
+			1: {Members: map[uint64]*pb.Member{1: {Addr: "10.0.0.2:7080"}}},
+		},
+	}
+	require.True(t, IsMember(state, []string{"Zero1"}))
+	require.True(t, IsMember(state, []string{"alpha", "10.0.0.2"}))
+	require.False(t, IsMember(state, []string{"alpha", "10.0.0.3"}))
+	require.False(t, IsMember(state, nil))
+}
+
+// peerContext returns the context of a call by a peer with the certificate.
+func peerContext(cert *x509.Certificate) context.Context {
+	info := credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+	}
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.9"), Port: 7080}
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: info})
+}
+
+func TestAuthorizePeer(t *testing.T) {
+	SetInternalTLS(&tls.Config{}, &tls.Config{}, func(hosts []string) bool {
+		return len(hosts) > 0 && hosts[0] == "alpha1"
+	}, []string{"loader"})
+	defer SetInternalTLS(nil, nil, nil, nil)
+
+	require.Error(t, authorizePeer(context.Background(), "/pb.Zero/Timestamps"))
+
+	nodeUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	member := peerContext(&x509.Certificate{ExtKeyUsage: nodeUsage, DNSNames: []string{"alpha1"}})
+	require.NoError(t, authorizePeer(member, "/pb.Zero/ShouldServe"))
+	require.NoError(t, authorizePeer(member, "/pb.Zero/Timestamps"))
+
+	// The nodes can only join the cluster until they are members.
+	node := peerContext(&x509.Certificate{ExtKeyUsage: nodeUsage, DNSNames: []string{"alpha2"}})
+	require.NoError(t, authorizePeer(node, "/pb.Raft/JoinCluster"))
+	require.NoError(t, authorizePeer(node, "/pb.Zero/Connect"))
+	require.Error(t, authorizePeer(node, "/pb.Zero/ShouldServe"))
+	require.Error(t, authorizePeer(node, "/pb.Zero/Timestamps"))
+	require.Error(t, authorizePeer(node, "/pb.Raft/RaftMessage"))
+
+	// The loaders can only call the loader methods, with an allowed client certificate.
+	clientUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	loader := peerContext(&x509.Certificate{ExtKeyUsage: clientUsage,
+		Subject: pkix.Name{CommonName: "loader"}})
+	require.NoError(t, authorizePeer(loader, "/pb.Zero/Timestamps"))
+	require.NoError(t, authorizePeer(loader, "/pb.Worker/IngestPredicate"))
+	require.Error(t, authorizePeer(loader, "/pb.Raft/JoinCluster"))
+	require.Error(t, authorizePeer(loader, "/pb.Zero/ShouldServe"))
+	other := peerContext(&x509.Certificate{ExtKeyUsage: clientUsage,
+		Subject: pkix.Name{CommonName: "other"}, DNSNames: []string{"alpha1"}})
+	require.Error(t, authorizePeer(other, "/pb.Zero/Timestamps"))
+	require.Error(t, authorizePeer(other, "/pb.Zero/ShouldServe"))
+}
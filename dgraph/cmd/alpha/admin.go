@@ -19,6 +19,7 @@ package alpha
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 )
@@ -40,9 +42,11 @@ func handlerInit(w http.ResponseWriter, r *http.Request, method string) bool {
 
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil || (!ipInIPWhitelistRanges(ip) && !net.ParseIP(ip).IsLoopback()) {
+		edgraph.AuditAdmin(r.URL.Path, ip, r.Header.Get("X-Dgraph-AccessToken"), false)
 		x.SetStatus(w, x.ErrorUnauthorized, fmt.Sprintf("Request from IP: %v", ip))
 		return false
 	}
+	edgraph.AuditAdmin(r.URL.Path, ip, r.Header.Get("X-Dgraph-AccessToken"), true)
 	return true
 }
 
@@ -57,11 +61,37 @@ func shutDownHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func exportHandler(w http.ResponseWriter, r *http.Request) {
-	if !handlerInit(w, r, http.MethodGet) {
+	// POST is accepted too, to keep the destination credentials out of URLs.
+	method := http.MethodGet
+	if r.Method == http.MethodPost {
+		method = http.MethodPost
+	}
+	if !handlerInit(w, r, method) {
+		return
+	}
+	// The secret key is only read from the body of POST requests, URLs end up in logs.
+	if _, ok := r.URL.Query()["secret_key"]; ok {
+		x.SetStatus(w, x.ErrorInvalidRequest,
+			"secret_key must be passed in the body of a POST request, not in the URL")
 		return
 	}
 	// Export logic can be moved to dgraphzero.
-	if err := worker.ExportOverNetwork(context.Background()); err != nil {
+	req := &pb.ExportRequest{
+		Format:       r.FormValue("format"),
+		Destination:  r.FormValue("destination"),
+		AccessKey:    r.FormValue("access_key"),
+		SecretKey:    r.PostFormValue("secret_key"),
+		SessionToken: r.FormValue("session_token"),
+		Anonymous:    r.FormValue("anonymous") == "true",
+	}
+	if req.Destination != "" && !Alpha.Conf.GetBool("enterprise_features") {
+		x.SetStatus(w,
+			"You must enable Dgraph enterprise features first. "+
+				"Restart Dgraph Alpha with --enterprise_features",
+			"Export failed.")
+		return
+	}
+	if err := worker.ExportOverNetwork(context.Background(), req); err != nil {
 		x.SetStatus(w, err.Error(), "Export failed.")
 		return
 	}
@@ -69,6 +99,65 @@ func exportHandler(w http.ResponseWriter, r *http.Request) {
 	x.Check2(w.Write([]byte(`{"code": "Success", "message": "Export completed."}`)))
 }
 
+// cdcHandler streams the change data capture events committed after since_ts, and then follows
+// the events as they are committed, until the client disconnects.
+func cdcHandler(w http.ResponseWriter, r *http.Request) {
+	if !handlerInit(w, r, http.MethodGet) {
+		return
+	}
+	var sinceTs uint64
+	if since := r.URL.Query().Get("since_ts"); since != "" {
+		var err error
+		if sinceTs, err = strconv.ParseUint(since, 0, 64); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Invalid since_ts: %q", since))
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		x.SetStatus(w, x.Error, "Streaming is not supported.")
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	err := worker.StreamCDC(r.Context(), sinceTs, w, flusher.Flush)
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+	}
+}
+
+// queriesHandler lists the queries running on this server, with their id, start time and text.
+func queriesHandler(w http.ResponseWriter, r *http.Request) {
+	if !handlerInit(w, r, http.MethodGet) {
+		return
+	}
+	js, err := json.Marshal(edgraph.RunningQueries())
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	x.Check2(w.Write(js))
+}
+
+// cancelQueryHandler cancels the running query with the given id.
+func cancelQueryHandler(w http.ResponseWriter, r *http.Request) {
+	if !handlerInit(w, r, http.MethodPost) {
+		return
+	}
+	id, err := strconv.ParseUint(r.FormValue("id"), 0, 64)
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Invalid id: %q", r.FormValue("id")))
+		return
+	}
+	if err := edgraph.CancelQuery(id); err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	x.Check2(w.Write([]byte(`{"code": "Success", "message": "Query cancelled."}`)))
+}
+
 func memoryLimitHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
@@ -81,6 +170,12 @@ func memoryLimitHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func memoryLimitPutHandler(w http.ResponseWriter, r *http.Request) {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	edgraph.AuditAdmin(r.URL.Path, ip, r.Header.Get("X-Dgraph-AccessToken"), true)
+
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
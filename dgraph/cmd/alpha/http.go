@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
+	"net"
 	"net/http"
 	"sort"
 	"strconv"
@@ -33,6 +35,7 @@ import (
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/query"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
@@ -41,6 +44,7 @@ import (
 	"github.com/golang/protobuf/jsonpb"
 
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 func allowed(method string) bool {
@@ -81,9 +85,42 @@ func commonHandler(w http.ResponseWriter, r *http.Request) bool {
 		return true
 	}
 
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	release, err := edgraph.LimitRequest(r.Header.Get("X-Dgraph-AccessToken"), ip)
+	if err != nil {
+		if rerr, ok := err.(*edgraph.RateLimitError); ok {
+			writeRateLimited(w, rerr)
+		} else {
+			x.SetStatus(w, x.Error, err.Error())
+		}
+		return true
+	}
+	// The context of the request is cancelled once its handler returns.
+	go func() {
+		<-r.Context().Done()
+		release()
+	}()
+
 	return false
 }
 
+// writeRateLimited writes the response to a request over a rate limit. The headers must all be
+// set before the status, which sends them.
+func writeRateLimited(w http.ResponseWriter, rerr *edgraph.RateLimitError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", retryAfterSeconds(rerr.RetryAfter))
+	w.WriteHeader(http.StatusTooManyRequests)
+	x.SetStatus(w, x.ErrorResourceExhausted, rerr.Error())
+}
+
+// retryAfterSeconds returns the Retry-After header for d, in whole seconds.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
 // Read request body, transparently decompressing if necessary. Return nil on error.
 func readRequest(w http.ResponseWriter, r *http.Request) []byte {
 	var in io.Reader = r.Body
@@ -159,19 +196,33 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	d := r.URL.Query().Get("debug")
 	ctx := context.WithValue(context.Background(), query.DebugKey, d)
 	ctx = attachAccessJwt(ctx, r)
+	ctx = attachRemoteAddr(ctx, r)
 
-	// Timeout is expected to be in millisecond
+	// The timeout is a duration like "500ms", capped by the one set on the server.
 	paramTimeout := r.URL.Query().Get("timeout")
 	if paramTimeout != "" {
-		timeout, err := time.ParseDuration(paramTimeout)
-		if err != nil {
+		if _, err := time.ParseDuration(paramTimeout); err != nil {
 			x.SetStatusWithData(w, x.Error, err.Error())
 			return
 		}
+	}
 
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, timeout)
-		defer cancel()
+	// read_ts and read_at run this as a read-only query of the data as it was at that timestamp
+	// or time.
+	if readTs := r.URL.Query().Get("read_ts"); readTs != "" {
+		if req.StartTs != 0 {
+			x.SetStatus(w, x.ErrorInvalidRequest, "read_ts can't be used with a start ts")
+			return
+		}
+		if req.StartTs, err = strconv.ParseUint(readTs, 0, 64); err != nil || req.StartTs == 0 {
+			x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Invalid read_ts: %q", readTs))
+			return
+		}
+		req.ReadOnly = true
+	}
+	readAt := r.URL.Query().Get("read_at")
+	if readAt != "" {
+		req.ReadOnly = true
 	}
 
 	if req.StartTs == 0 {
@@ -188,12 +239,19 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// explain returns the plan of the query without running it, and profile runs it and returns
+	// the plan with the time spent at every node.
+	preq := &pb.Request{Request: &req, Timeout: paramTimeout, ReadAt: readAt}
+	preq.Explain, _ = strconv.ParseBool(r.URL.Query().Get("explain"))
+	preq.Profile, _ = strconv.ParseBool(r.URL.Query().Get("profile"))
+
 	// Core processing happens here.
-	resp, err := (&edgraph.Server{}).Query(ctx, &req)
+	presp, err := (&edgraph.ExtServer{}).Query(ctx, preq)
 	if err != nil {
 		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
 		return
 	}
+	resp := presp.Response
 
 	var out bytes.Buffer
 	writeEntry := func(key string, js []byte) {
@@ -207,6 +265,7 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	e := query.Extensions{
 		Txn:     resp.Txn,
 		Latency: resp.Latency,
+		Plan:    presp.Plan,
 	}
 	js, err := json.Marshal(e)
 	if err != nil {
@@ -234,7 +293,7 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 
 	parseStart := time.Now()
 
-	var mu *api.Mutation
+	var pmu *pb.Mutation
 	var err error
 	if mType := r.Header.Get("X-Dgraph-MutationType"); mType == "json" {
 		// Parse JSON.
@@ -245,21 +304,35 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		mu = &api.Mutation{}
+		pmu = &pb.Mutation{Mutation: &api.Mutation{}}
+		mu := pmu.Mutation
 		if setJSON, ok := ms["set"]; ok && setJSON != nil {
 			mu.SetJson = setJSON.bs
 		}
 		if delJSON, ok := ms["delete"]; ok && delJSON != nil {
 			mu.DeleteJson = delJSON.bs
 		}
+		if queryText, ok := ms["query"]; ok && queryText != nil {
+			if err := json.Unmarshal(queryText.bs, &pmu.Query); err != nil {
+				x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+				return
+			}
+		}
+		if condText, ok := ms["cond"]; ok && condText != nil {
+			if err := json.Unmarshal(condText.bs, &pmu.Cond); err != nil {
+				x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+				return
+			}
+		}
 	} else {
 		// Parse N-Quads.
-		mu, err = gql.ParseMutation(string(m))
+		pmu, err = gql.ParseMutation(string(m))
 		if err != nil {
 			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
 			return
 		}
 	}
+	mu := pmu.Mutation
 
 	parseEnd := time.Now()
 
@@ -275,6 +348,7 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 		mu.CommitNow = c
 	}
 	ctx := attachAccessJwt(context.Background(), r)
+	ctx = attachRemoteAddr(ctx, r)
 
 	ts, err := extractStartTs(r.URL.Path)
 	if err != nil {
@@ -283,7 +357,7 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	mu.StartTs = ts
 
-	resp, err := (&edgraph.Server{}).Mutate(ctx, mu)
+	resp, err := (&edgraph.ExtServer{}).Mutate(ctx, pmu)
 	if err != nil {
 		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
 		return
@@ -366,7 +440,9 @@ func commitHandler(w http.ResponseWriter, r *http.Request) {
 		tc.Preds = reqMap["preds"]
 	}
 
-	cts, err := worker.CommitOverNetwork(context.Background(), tc)
+	ctx := attachRemoteAddr(attachAccessJwt(context.Background(), r), r)
+	cts, err := worker.CommitOverNetwork(ctx, tc)
+	edgraph.AuditCommit(ctx, tc, cts, err)
 	if err != nil {
 		x.SetStatus(w, x.Error, err.Error())
 		return
@@ -416,7 +492,9 @@ func abortHandler(w http.ResponseWriter, r *http.Request) {
 	tc.StartTs = ts
 	tc.Aborted = true
 
-	_, aerr := worker.CommitOverNetwork(context.Background(), tc)
+	ctx := attachRemoteAddr(attachAccessJwt(context.Background(), r), r)
+	_, aerr := worker.CommitOverNetwork(ctx, tc)
+	edgraph.AuditCommit(ctx, tc, 0, aerr)
 	if aerr != nil {
 		x.SetStatus(w, x.Error, aerr.Error())
 		return
@@ -448,6 +526,25 @@ func attachAccessJwt(ctx context.Context, r *http.Request) context.Context {
 	return ctx
 }
 
+// attachRemoteAddr adds the remote address of the request as peer info, so that the client IP
+// can be logged by the server.
+func attachRemoteAddr(ctx context.Context, r *http.Request) context.Context {
+	ip, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return ctx
+	}
+	intPort, err := strconv.Atoi(port)
+	if err != nil {
+		return ctx
+	}
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP(ip),
+			Port: intPort,
+		},
+	})
+}
+
 func alterHandler(w http.ResponseWriter, r *http.Request) {
 	if commonHandler(w, r) {
 		return
@@ -476,6 +573,7 @@ func alterHandler(w http.ResponseWriter, r *http.Request) {
 	md.Append("auth-token", r.Header.Get("X-Dgraph-AuthToken"))
 	ctx := metadata.NewIncomingContext(context.Background(), md)
 	ctx = attachAccessJwt(ctx, r)
+	ctx = attachRemoteAddr(ctx, r)
 	if _, err = (&edgraph.Server{}).Alter(ctx, op); err != nil {
 		x.SetStatus(w, x.Error, err.Error())
 		return
@@ -509,3 +509,56 @@ func TestHttpCompressionSupport(t *testing.T) {
 	require.Equal(t, r1, data)
 	require.Empty(t, resp.Header.Get("Content-Encoding"))
 }
+
+func TestQueryExplainAndProfile(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`
+		name: string @index(exact) .
+		friend: uid .`))
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		_:a <name> "Alice" .
+		_:a <friend> _:b .
+		_:a <friend> _:c .
+		_:b <name> "Bob" .
+		_:c <name> "Charlie" .
+	  }
+	}
+	`))
+
+	q := `{ q(func: eq(name, "Alice")) { friend { name } } }`
+	plan := func(param string) (json.RawMessage, []query.PlanNode) {
+		_, body, err := runWithRetries("POST", addr+"/query?"+param, q, nil)
+		require.NoError(t, err)
+		var r res
+		require.NoError(t, json.Unmarshal(body, &r))
+		var nodes []query.PlanNode
+		require.NoError(t, json.Unmarshal(r.Extensions.Plan, &nodes))
+		require.Equal(t, 1, len(nodes))
+		return r.Data, nodes
+	}
+
+	// Explain doesn't run the query.
+	data, nodes := plan("explain=true")
+	require.JSONEq(t, `{}`, string(data))
+	root := nodes[0]
+	require.Equal(t, `eq(name, "Alice")`, root.Func)
+	require.True(t, root.Index)
+	require.Equal(t, []string{"exact"}, root.Tokenizers)
+	require.NotZero(t, root.Group)
+	require.Equal(t, uint64(1), root.Estimate.Uids)
+	friend := root.Children[0]
+	require.Equal(t, "friend", friend.Attr)
+	require.Equal(t, 2.0, friend.Estimate.FanOut)
+	require.Equal(t, uint64(2), friend.Estimate.Uids)
+
+	// Profile runs it.
+	data, nodes = plan("profile=true")
+	require.Contains(t, string(data), `{"name":"Bob"}`)
+	require.Contains(t, string(data), `{"name":"Charlie"}`)
+	root = nodes[0]
+	require.Equal(t, 1, root.Profile.Uids)
+	require.NotZero(t, root.Profile.LatencyNs)
+	require.Equal(t, 2, root.Children[0].Profile.Uids)
+}
@@ -36,8 +36,12 @@ import (
 
 	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/ee/auth"
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/worker"
@@ -51,10 +55,14 @@ import (
 	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	_ "google.golang.org/grpc/encoding/gzip" // grpc compression
 	"google.golang.org/grpc/health"
 	hapi "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -110,6 +118,9 @@ they form a Raft group and provide synchronous replication.
 		"A comma separated list of IP ranges you wish to whitelist for performing admin "+
 			"actions (i.e., --whitelist 127.0.0.1:127.0.0.3,0.0.0.7:0.0.0.9)")
 	flag.String("export", "export", "Folder in which to store exports.")
+	flag.String("cdc_file", "",
+		"File to append the transactions committed in the group of this Alpha to, as a "+
+			"change data capture stream served at /admin/cdc. Disabled if empty.")
 	flag.Int("pending_proposals", 256,
 		"Number of pending mutation proposals. Useful for rate limiting.")
 	flag.String("my", "",
@@ -118,9 +129,13 @@ they form a Raft group and provide synchronous replication.
 		"IP_ADDRESS:PORT of a Dgraph Zero.")
 	flag.Uint64("idx", 0,
 		"Optional Raft ID that this Dgraph Alpha will use to join RAFT groups.")
+	flag.Bool("learner", false,
+		"Join an existing group as a learner replica. Learners receive all the updates of the "+
+			"group, but don't vote, don't become the leader and serve read-only queries.")
 	flag.Bool("expand_edge", true,
-		"Enables the expand() feature. This is very expensive for large data loads because it"+
-			" doubles the number of mutations going on in the system.")
+		"Enables the _predicate_ edge listing the predicates of each node. expand(_all_) uses"+
+			" the declared types instead and doesn't need it. This is very expensive for large"+
+			" data loads because it doubles the number of mutations going on in the system.")
 	flag.Int("max_retries", -1,
 		"Commits to disk will give up after these number of retries to prevent locking the worker"+
 			" in a failed state. Use -1 to retry infinitely.")
@@ -138,6 +153,11 @@ they form a Raft group and provide synchronous replication.
 		"Enterprise feature.")
 	flag.Duration("acl_cache_ttl", 30*time.Second, "The interval to refresh the acl cache. "+
 		"Enterprise feature.")
+	flag.String("acl_auth_config", "", "The JSON file configuring the external authenticators "+
+		"of the users: the identity provider issuing their JWTs, and the LDAP server checking "+
+		"their passwords. Enterprise feature.")
+	flag.String("encryption_key_file", "", "The file that stores the AES key used to encrypt "+
+		"backups and exports. The key must be 16, 24 or 32 bytes long. Enterprise feature.")
 	flag.Float64P("lru_mb", "l", -1,
 		"Estimated memory the LRU cache can take. "+
 			"Actual usage by the process would be more than specified here.")
@@ -153,11 +173,30 @@ they form a Raft group and provide synchronous replication.
 	flag.Uint64("query_edge_limit", 1e6,
 		"Limit for the maximum number of edges that can be returned in a query."+
 			" This applies to shortest path and recursive queries.")
+	flag.Duration("query_timeout", 0,
+		"Cancel queries running for longer than this duration. The requests can only set a "+
+			"shorter timeout. Running queries can be listed and cancelled at /admin/queries. "+
+			"Disabled if zero.")
+	flag.String("slow_query_log", "",
+		"File to log the queries and mutations slower than --slow_query_threshold to, as one "+
+			"JSON object per line. Disabled if empty.")
+	flag.Duration("slow_query_threshold", time.Second,
+		"Latency above which queries and mutations are written to --slow_query_log.")
+	flag.String("audit_log", "",
+		"File to write the audit log of the logins, alters, mutations, queries, commits and "+
+			"admin requests to, as one JSON object per line. Disabled if empty. "+
+			"Enterprise feature.")
+	flag.Int("audit_log_size_mb", 100,
+		"Size in MB beyond which the audit log is rotated. The rotated logs are kept.")
+	flag.String("rate_limits", "",
+		"JSON file with the rate limits and the concurrent requests allowed per user and group "+
+			"with ACL, or per IP address otherwise. Disabled if empty.")
 
 	// TLS configurations
 	flag.String("tls_dir", "", "Path to directory that has TLS certificates and keys.")
 	flag.Bool("tls_use_system_ca", true, "Include System CA into CA Certs.")
 	flag.String("tls_client_auth", "VERIFYIFGIVEN", "Enable TLS client authentication")
+	x.RegisterInternalTLSFlags(flag)
 
 	//Custom plugins.
 	flag.String("custom_tokenizers", "",
@@ -305,6 +344,7 @@ func serveGRPC(l net.Listener, tlsCfg *tls.Config, wg *sync.WaitGroup) {
 		grpc.MaxSendMsgSize(x.GrpcMaxSize),
 		grpc.MaxConcurrentStreams(1000),
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
+		grpc.UnaryInterceptor(rateLimitInterceptor),
 	}
 	if tlsCfg != nil {
 		opt = append(opt, grpc.Creds(credentials.NewTLS(tlsCfg)))
@@ -312,12 +352,51 @@ func serveGRPC(l net.Listener, tlsCfg *tls.Config, wg *sync.WaitGroup) {
 
 	s := grpc.NewServer(opt...)
 	api.RegisterDgraphServer(s, &edgraph.Server{})
+	pb.RegisterDgraphServer(s, &edgraph.ExtServer{})
 	hapi.RegisterHealthServer(s, health.NewServer())
 	err := s.Serve(l)
 	glog.Errorf("GRPC listener canceled: %v\n", err)
 	s.Stop()
 }
 
+// rateLimitInterceptor rejects the requests over the rate limits of their user or IP address with
+// ResourceExhausted, setting the retry-after header to the seconds to wait before retrying.
+func rateLimitInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	// Both the dgo API and the options Dgraph has beyond it are limited.
+	limited := strings.HasPrefix(info.FullMethod, "/api.Dgraph/") ||
+		strings.HasPrefix(info.FullMethod, "/pb.Dgraph/")
+	if !limited || info.FullMethod == "/api.Dgraph/CheckVersion" {
+		return handler(ctx, req)
+	}
+	var accessJwt, ip string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if jwts := md.Get("accessJwt"); len(jwts) > 0 {
+			accessJwt = jwts[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	release, err := edgraph.LimitRequest(accessJwt, ip)
+	if err != nil {
+		if rerr, ok := err.(*edgraph.RateLimitError); ok {
+			md := metadata.Pairs("retry-after", retryAfterSeconds(rerr.RetryAfter))
+			if err := grpc.SetHeader(ctx, md); err != nil {
+				glog.Warningf("While setting retry-after header: %v", err)
+			}
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
 func serveHTTP(l net.Listener, tlsCfg *tls.Config, wg *sync.WaitGroup) {
 	defer wg.Done()
 	srv := &http.Server{
@@ -379,6 +458,9 @@ func setupServer() {
 
 	http.HandleFunc("/admin/shutdown", shutDownHandler)
 	http.HandleFunc("/admin/export", exportHandler)
+	http.HandleFunc("/admin/cdc", cdcHandler)
+	http.HandleFunc("/admin/queries", queriesHandler)
+	http.HandleFunc("/admin/queries/cancel", cancelQueryHandler)
 	http.HandleFunc("/admin/config/lru_mb", memoryLimitHandler)
 
 	// Add OpenCensus z-pages.
@@ -421,6 +503,23 @@ func run() {
 		MutationsMode:  edgraph.AllowMutations,
 		AuthToken:      Alpha.Conf.GetString("auth_token"),
 		AllottedMemory: Alpha.Conf.GetFloat64("lru_mb"),
+		QueryTimeout:   Alpha.Conf.GetDuration("query_timeout"),
+
+		SlowQueryLog:       Alpha.Conf.GetString("slow_query_log"),
+		SlowQueryThreshold: Alpha.Conf.GetDuration("slow_query_threshold"),
+	}
+	if path := Alpha.Conf.GetString("audit_log"); path != "" {
+		if !Alpha.Conf.GetBool("enterprise_features") {
+			glog.Fatalf("You must enable Dgraph enterprise features with the " +
+				"--enterprise_features option in order to use the audit log.")
+		}
+		opts.AuditLog = path
+		opts.AuditLogSize = int64(Alpha.Conf.GetInt("audit_log_size_mb")) << 20
+	}
+	if path := Alpha.Conf.GetString("rate_limits"); path != "" {
+		rl, err := edgraph.ReadRateLimits(path)
+		x.Check(err)
+		opts.RateLimits = rl
 	}
 
 	secretFile := Alpha.Conf.GetString("acl_secret_file")
@@ -445,6 +544,28 @@ func run() {
 
 		glog.Info("HMAC secret loaded successfully.")
 	}
+	if path := Alpha.Conf.GetString("acl_auth_config"); path != "" {
+		if secretFile == "" {
+			glog.Fatalf("You must enable ACL with the --acl_secret_file option in order to " +
+				"use external authenticators.")
+		}
+		authenticator, err := auth.ReadConfig(path)
+		x.Check(err)
+		opts.Authenticator = authenticator
+		glog.Info("External authenticators loaded successfully.")
+	}
+
+	var encryptionKey []byte
+	if keyFile := Alpha.Conf.GetString("encryption_key_file"); keyFile != "" {
+		if !Alpha.Conf.GetBool("enterprise_features") {
+			glog.Fatalf("You must enable Dgraph enterprise features with the " +
+				"--enterprise_features option in order to use encryption.")
+		}
+		key, err := enc.ReadKeyFile(keyFile)
+		x.Check(err)
+		encryptionKey = key
+		glog.Info("Encryption key loaded successfully.")
+	}
 
 	switch strings.ToLower(Alpha.Conf.GetString("mutations")) {
 	case "allow":
@@ -474,6 +595,17 @@ func run() {
 		MaxRetries:          Alpha.Conf.GetInt("max_retries"),
 		StrictMutations:     opts.MutationsMode == edgraph.StrictMutations,
 		AclEnabled:          secretFile != "",
+		EncryptionKey:       encryptionKey,
+		CDCFile:             Alpha.Conf.GetString("cdc_file"),
+		Learner:             Alpha.Conf.GetBool("learner"),
+	}
+
+	internalServerTLS, internalClientTLS, err := x.LoadInternalTLSConfig(Alpha.Conf)
+	x.Check(err)
+	if internalServerTLS != nil {
+		conn.SetInternalTLS(internalServerTLS, internalClientTLS, worker.IsMember,
+			x.InternalTLSClients(Alpha.Conf))
+		glog.Info("Mutual TLS enabled for the internal traffic.")
 	}
 
 	setupCustomTokenizers()
@@ -31,6 +31,7 @@ import (
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/query"
@@ -40,7 +41,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var q0 = `
@@ -1106,13 +1110,20 @@ func TestExpandPredError(t *testing.T) {
 			<0x1> <name> "Alice" .
 			<0x1> <age> "13" .
 			<0x1> <friend> <0x4> .
+			<0x1> <dgraph.type> "Person" .
 			<0x4> <name> "bob" .
 			<0x4> <age> "12" .
+			<0x4> <dgraph.type> "Person" .
 		}
 	}
 	`
 	var s = `
 			name:string @index(term) .
+			type Person {
+				name: string
+				age: string
+				friend: [uid]
+			}
 	`
 
 	// reset Schema
@@ -1145,13 +1156,20 @@ func TestExpandPred(t *testing.T) {
 			<0x11> <name> "Alice" .
 			<0x11> <age> "13" .
 			<0x11> <friend> <0x4> .
+			<0x11> <dgraph.type> "Person" .
 			<0x4> <name> "bob" .
 			<0x4> <age> "12" .
+			<0x4> <dgraph.type> "Person" .
 		}
 	}
 	`
 	var s = `
 			name:string @index(term) .
+			type Person {
+				name: string
+				age: string
+				friend: [uid]
+			}
 	`
 
 	// reset Schema
@@ -1585,13 +1603,22 @@ func TestRecurseExpandAll(t *testing.T) {
 			<0x1> <name> "Alica" .
 			<0x1> <age> "13" .
 			<0x1> <friend> <0x4> .
+			<0x1> <dgraph.type> "Person" .
 			<0x4> <name> "bob" .
 			<0x4> <age> "12" .
+			<0x4> <dgraph.type> "Person" .
 		}
 	}
 	`
 
-	var s = `name:string @index(term) .`
+	var s = `
+		name:string @index(term) .
+		type Person {
+			name: string
+			age: string
+			friend: [uid]
+		}
+	`
 	// reset Schema
 	schema.ParseBytes([]byte(""), 1)
 	err := runMutation(m)
@@ -1745,6 +1772,41 @@ var addr = "http://localhost:8180"
 var grootAccessJwt string
 var grootRefreshJwt string
 
+type stubDgraph struct{}
+
+func (stubDgraph) Query(ctx context.Context, req *pb.Request) (*pb.Response, error) {
+	return &pb.Response{}, nil
+}
+
+func (stubDgraph) Mutate(ctx context.Context, m *pb.Mutation) (*api.Assigned, error) {
+	return &api.Assigned{}, nil
+}
+
+func TestRateLimitPbDgraph(t *testing.T) {
+	edgraph.Config.RateLimits = &edgraph.RateLimits{Default: edgraph.Limit{QPS: 1}}
+	defer func() { edgraph.Config.RateLimits = nil }()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	s := grpc.NewServer(grpc.UnaryInterceptor(rateLimitInterceptor))
+	pb.RegisterDgraphServer(s, stubDgraph{})
+	go s.Serve(l)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	dc := pb.NewDgraphClient(conn)
+
+	ctx := context.Background()
+	_, err = dc.Query(ctx, &pb.Request{})
+	require.NoError(t, err)
+	var md metadata.MD
+	_, err = dc.Mutate(ctx, &pb.Mutation{}, grpc.Header(&md))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, []string{"1"}, md.Get("retry-after"))
+}
+
 func TestMain(m *testing.M) {
 	// Increment lease, so that mutations work.
 	conn, err := grpc.Dial(z.SockAddrZero, grpc.WithInsecure())
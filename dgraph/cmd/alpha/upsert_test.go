@@ -0,0 +1,163 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertMutation(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`email: string @index(exact) @upsert .`))
+
+	m := `
+upsert {
+  query {
+    me(func: eq(email, "email@company.io")) {
+      v as uid
+    }
+  }
+
+  mutation {
+    set {
+      uid(v) <name> "Wrong" .
+      uid(v) <email> "email@company.io" .
+    }
+  }
+}`
+	// The first upsert creates a new node, the second one updates the same node.
+	require.NoError(t, runMutation(m))
+	require.NoError(t, runMutation(
+		`upsert {
+		  query { me(func: eq(email, "email@company.io")) { v as uid } }
+		  mutation { set { uid(v) <name> "Ashish" . } }
+		}`))
+
+	res, err := runQuery(`{ q(func: has(email)) { name email } }`)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"data":{"q":[{"name":"Ashish","email":"email@company.io"}]}}`, res)
+}
+
+func TestUpsertValVar(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`
+		name: string @index(exact) .
+		nickname: string .`))
+	require.NoError(t, runMutation(`{ set {
+		_:a <name> "Alice" .
+		_:b <name> "Bob" .
+	}}`))
+
+	require.NoError(t, runMutation(`
+upsert {
+  query {
+    me(func: has(name)) {
+      v as uid
+      n as name
+    }
+  }
+
+  mutation {
+    set {
+      uid(v) <nickname> val(n) .
+    }
+  }
+}`))
+
+	res, err := runQuery(`{ q(func: has(nickname), orderasc: nickname) { nickname } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data":{"q":[{"nickname":"Alice"},{"nickname":"Bob"}]}}`, res)
+}
+
+func TestUpsertDeleteWithoutMatch(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`email: string @index(exact) .`))
+
+	// Nothing matches the query, so the delete has nothing to remove.
+	require.NoError(t, runMutation(`
+upsert {
+  query { me(func: eq(email, "nobody@company.io")) { v as uid } }
+  mutation { delete { uid(v) <email> * . } }
+}`))
+}
+
+func TestUpsertUndefinedVar(t *testing.T) {
+	err := runMutation(`
+upsert {
+  query { me(func: eq(email, "email@company.io")) { uid } }
+  mutation { set { uid(w) <name> "Alice" . } }
+}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Some variables are used but not defined")
+}
+
+func TestConditionalUpsert(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`email: string @index(exact) .`))
+
+	m := `
+upsert {
+  query { me(func: eq(email, "email@company.io")) { v as uid } }
+  mutation @if(eq(len(v), 0)) {
+    set {
+      _:user <email> "email@company.io" .
+    }
+  }
+}`
+	// Only the first run finds no node and creates one.
+	require.NoError(t, runMutation(m))
+	require.NoError(t, runMutation(m))
+
+	res, err := runQuery(`{ q(func: has(email)) { email } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data":{"q":[{"email":"email@company.io"}]}}`, res)
+
+	require.NoError(t, runMutation(`
+upsert {
+  query { me(func: eq(email, "email@company.io")) { v as uid } }
+  mutation @if(eq(len(v), 1) AND NOT gt(len(v), 1)) {
+    delete {
+      uid(v) <email> * .
+    }
+  }
+}`))
+
+	res, err = runQuery(`{ q(func: has(email)) { email } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data":{"q":[]}}`, res)
+}
+
+func TestConditionalUpsertErrors(t *testing.T) {
+	err := runMutation(`
+upsert {
+  query { me(func: eq(email, "email@company.io")) { uid } }
+  mutation @if(eq(len(w), 0)) { set { _:a <email> "a" . } }
+}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Some variables are used but not defined")
+
+	err = runMutation(`
+upsert {
+  query { me(func: eq(email, "email@company.io")) { v as uid } }
+  mutation @if(eq(email, "a")) { set { uid(v) <email> "a" . } }
+}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only eq, le, lt, ge and gt on len()")
+}
@@ -0,0 +1,370 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bulk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgraph/chunker/csv"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const (
+	manifestFile = "manifest"
+
+	// checkpointInterval is how often the mappers write out their buffers and the progress of the
+	// map phase is recorded.
+	checkpointInterval = 5 * time.Minute
+)
+
+// manifest records the progress of the bulk loader in the tmp directory, so that --resume can pick
+// up where an interrupted run stopped.
+type manifest struct {
+	// The checksums of the schema file and of the options changing the output. The run can only
+	// be resumed if they are the same.
+	SchemaSum  string `json:"schema_sum"`
+	OptionsSum string `json:"options_sum"`
+	WriteTs    uint64 `json:"write_ts"`
+
+	Files []*inputFile `json:"files"`
+	// MapFiles are the map output files, relative to the shards directory, holding the map
+	// entries of the chunks done. The other map files are removed when resuming.
+	MapFiles  []string                    `json:"map_files"`
+	MapFileId uint32                      `json:"map_file_id"`
+	Shards    map[string]int              `json:"shards"`
+	NextShard int                         `json:"next_shard"`
+	Schema    map[string]*pb.SchemaUpdate `json:"schema"`
+	// MapDone is set once the map shards have been merged into the reduce shards.
+	MapDone bool `json:"map_done"`
+	// ReduceDone is set for the reduce shards whose output directory is complete.
+	ReduceDone []bool `json:"reduce_done"`
+}
+
+// inputFile is the state of an input file in the manifest.
+type inputFile struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"`
+	// Chunks is the number of leading chunks of the file that are done, and Later holds the
+	// indexes of the following chunks that are done, since the mappers finish them out of order.
+	Chunks int   `json:"chunks"`
+	Later  []int `json:"later,omitempty"`
+	Done   bool  `json:"done"`
+}
+
+// isDone returns true if the map entries of the chunk with the given index are durable.
+func (f *inputFile) isDone(chunk int) bool {
+	if chunk < f.Chunks {
+		return true
+	}
+	i := sort.SearchInts(f.Later, chunk)
+	return i < len(f.Later) && f.Later[i] == chunk
+}
+
+// add marks the chunk with the given index as done.
+func (f *inputFile) add(chunk int) {
+	if f.isDone(chunk) {
+		return
+	}
+	i := sort.SearchInts(f.Later, chunk)
+	f.Later = append(f.Later, 0)
+	copy(f.Later[i+1:], f.Later[i:])
+	f.Later[i] = chunk
+	for len(f.Later) > 0 && f.Later[0] == f.Chunks {
+		f.Later = f.Later[1:]
+		f.Chunks++
+	}
+}
+
+// newManifest returns the manifest of a run loading the given files.
+func newManifest(opt options, loadType int, files []string) (*manifest, error) {
+	schema, err := ioutil.ReadFile(opt.SchemaFile)
+	if err != nil {
+		return nil, x.Wrapf(err, "While reading schema file %q", opt.SchemaFile)
+	}
+	schemaSum := sha256.Sum256(schema)
+
+	// Only the options that change the output of the loader.
+	outputOpts, err := json.Marshal(struct {
+		Format           int
+		MapShards        int
+		ReduceShards     int
+		ExpandEdges      bool
+		StoreXids        bool
+		NewUids          bool
+		CustomTokenizers string
+		CSVMapping       *csv.Mapping
+	}{loadType, opt.MapShards, opt.ReduceShards, opt.ExpandEdges, opt.StoreXids, opt.NewUids,
+		opt.CustomTokenizers, opt.CSVMapping})
+	if err != nil {
+		return nil, err
+	}
+	optionsSum := sha256.Sum256(outputOpts)
+
+	m := &manifest{
+		SchemaSum:  hex.EncodeToString(schemaSum[:]),
+		OptionsSum: hex.EncodeToString(optionsSum[:]),
+		ReduceDone: make([]bool, opt.ReduceShards),
+	}
+	for _, file := range files {
+		f := &inputFile{Path: file, Size: -1}
+		if file != "-" {
+			fi, err := os.Stat(file)
+			if err != nil {
+				return nil, err
+			}
+			f.Size, f.ModTime = fi.Size(), fi.ModTime().UnixNano()
+		}
+		m.Files = append(m.Files, f)
+	}
+	return m, nil
+}
+
+// readManifest reads the manifest written by a previous run in the tmp directory.
+func readManifest(tmpDir string) (*manifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(tmpDir, manifestFile))
+	if err != nil {
+		return nil, x.Wrapf(err, "While reading the manifest of the run to resume")
+	}
+	var m manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, x.Wrapf(err, "While parsing the manifest of the run to resume")
+	}
+	return &m, nil
+}
+
+// check returns an error if the run described by cur can't resume the one recorded by m.
+func (m *manifest) check(cur *manifest) error {
+	if m.SchemaSum != cur.SchemaSum {
+		return x.Errorf("The schema file has changed since the run to resume")
+	}
+	if m.OptionsSum != cur.OptionsSum {
+		return x.Errorf("The options changing the output differ from the run to resume")
+	}
+	if len(m.Files) != len(cur.Files) {
+		return x.Errorf("Found %d data files, the run to resume has %d", len(cur.Files),
+			len(m.Files))
+	}
+	for i, f := range cur.Files {
+		prev := m.Files[i]
+		switch {
+		case f.Path == "-":
+			return x.Errorf("Can't resume loading data from stdin")
+		case f.Path != prev.Path:
+			return x.Errorf("Found data file %q, the run to resume has %q", f.Path, prev.Path)
+		case f.Size != prev.Size || f.ModTime != prev.ModTime:
+			return x.Errorf("Data file %q has changed since the run to resume", f.Path)
+		}
+	}
+	return nil
+}
+
+// write atomically replaces the manifest in the tmp directory.
+func (m *manifest) write(tmpDir string) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(tmpDir, manifestFile)
+	if err := x.WriteFileSync(path+".tmp", b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// removeStrayMapFiles removes the map files that aren't in the manifest. They were written after
+// the last checkpoint, so their chunks are mapped again.
+func (m *manifest) removeStrayMapFiles(tmpDir string) error {
+	keep := make(map[string]bool, len(m.MapFiles))
+	for _, f := range m.MapFiles {
+		keep[f] = true
+	}
+	dir := filepath.Join(tmpDir, "shards")
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		switch {
+		case os.IsNotExist(err):
+			return nil
+		case err != nil:
+			return err
+		case fi.IsDir() && strings.HasPrefix(fi.Name(), "shard_"):
+			// Already merged into a reduce shard, once all the chunks were done.
+			return filepath.SkipDir
+		case fi.IsDir() || !strings.HasSuffix(path, ".map"):
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if keep[rel] {
+			return nil
+		}
+		return os.Remove(path)
+	})
+}
+
+// checkpointer tracks the progress of the loader and saves it in the manifest.
+type checkpointer struct {
+	sync.Mutex
+	*state
+	man   *manifest
+	total []int // The number of chunks of each file, once it has been read.
+
+	saveMu sync.Mutex // Serializes the writes of the manifest.
+}
+
+func newCheckpointer(st *state, man *manifest) *checkpointer {
+	c := &checkpointer{state: st, man: man, total: make([]int, len(man.Files))}
+	for i := range c.total {
+		c.total[i] = -1
+	}
+	return c
+}
+
+// skip returns the input file state of the given file, to skip the chunks that are done.
+func (c *checkpointer) skip(file int) inputFile {
+	c.Lock()
+	defer c.Unlock()
+	f := *c.man.Files[file]
+	f.Later = append([]int{}, f.Later...)
+	return f
+}
+
+// fileRead records the number of chunks of the file, once it has been read.
+func (c *checkpointer) fileRead(file, chunks int) {
+	c.Lock()
+	defer c.Unlock()
+	c.total[file] = chunks
+	c.updateDone(file)
+}
+
+func (c *checkpointer) updateDone(file int) {
+	f := c.man.Files[file]
+	f.Done = c.total[file] >= 0 && f.Chunks >= c.total[file]
+}
+
+// mapped records the chunks processed by a mapper, along with the map files holding their
+// entries. The files must have been written.
+func (c *checkpointer) mapped(chunks []chunkRef, mapFiles []string) {
+	c.Lock()
+	defer c.Unlock()
+	for _, ref := range chunks {
+		c.man.Files[ref.file].add(ref.seq)
+		c.updateDone(ref.file)
+	}
+	c.man.MapFiles = append(c.man.MapFiles, mapFiles...)
+}
+
+// mapDone records that the map phase is complete.
+func (c *checkpointer) mapDone() {
+	c.Lock()
+	c.man.MapDone = true
+	c.Unlock()
+	c.save()
+}
+
+// isReduced returns true if the given reduce shard was completed by a previous run.
+func (c *checkpointer) isReduced(shard int) bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.man.ReduceDone[shard]
+}
+
+// reduced records that the output directory of the given reduce shard is complete.
+func (c *checkpointer) reduced(shard int) {
+	c.Lock()
+	c.man.ReduceDone[shard] = true
+	c.Unlock()
+	c.save()
+}
+
+// save writes the manifest. The progress is copied before the xid mappings are persisted, so
+// that the mappings of all the chunks recorded as done are in the manifest's checkpoint.
+func (c *checkpointer) save() {
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	c.Lock()
+	snap := *c.man
+	snap.Files = make([]*inputFile, len(c.man.Files))
+	for i, f := range c.man.Files {
+		cp := *f
+		cp.Later = append([]int{}, f.Later...)
+		snap.Files[i] = &cp
+	}
+	snap.MapFiles = append([]string{}, c.man.MapFiles...)
+	snap.ReduceDone = append([]bool{}, c.man.ReduceDone...)
+	c.Unlock()
+
+	if c.xids != nil {
+		x.Check(c.xids.Checkpoint())
+	}
+	snap.MapFileId = atomic.LoadUint32(&c.mapFileId)
+
+	c.shards.RLock()
+	snap.Shards = make(map[string]int, len(c.shards.predToShard))
+	for pred, shard := range c.shards.predToShard {
+		snap.Shards[pred] = shard
+	}
+	snap.NextShard = c.shards.nextShard
+	c.shards.RUnlock()
+
+	c.schema.RLock()
+	snap.Schema = make(map[string]*pb.SchemaUpdate, len(c.schema.m))
+	for pred, sch := range c.schema.m {
+		snap.Schema[pred] = sch
+	}
+	c.schema.RUnlock()
+
+	x.Check(snap.write(c.opt.TmpDir))
+}
+
+// restore sets the state of the loader to the one recorded in the manifest.
+func (c *checkpointer) restore() {
+	c.mapFileId = c.man.MapFileId
+	for pred, shard := range c.man.Shards {
+		c.shards.predToShard[pred] = shard
+	}
+	c.shards.nextShard = c.man.NextShard
+	for pred, sch := range c.man.Schema {
+		if _, ok := c.schema.m[pred]; !ok {
+			c.schema.m[pred] = sch
+		}
+	}
+
+	var done int
+	for i, f := range c.man.Files {
+		if f.Done {
+			done++
+			c.total[i] = f.Chunks
+		}
+	}
+	fmt.Printf("Resuming run: %d out of %d files mapped, map phase done: %v\n", done,
+		len(c.man.Files), c.man.MapDone)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bulk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputFileChunks(t *testing.T) {
+	var f inputFile
+	for _, chunk := range []int{2, 0, 4, 2} {
+		f.add(chunk)
+	}
+	require.Equal(t, 1, f.Chunks)
+	require.Equal(t, []int{2, 4}, f.Later)
+	require.True(t, f.isDone(0))
+	require.False(t, f.isDone(1))
+	require.True(t, f.isDone(2))
+	require.False(t, f.isDone(3))
+
+	f.add(1)
+	f.add(3)
+	require.Equal(t, 5, f.Chunks)
+	require.Empty(t, f.Later)
+}
+
+func TestManifestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(data), 0644))
+		return path
+	}
+	opt := options{
+		SchemaFile:   write("schema", "name: string @index(exact) ."),
+		MapShards:    2,
+		ReduceShards: 1,
+	}
+	files := []string{write("a.rdf", "<a> <name> \"a\" ."), write("b.rdf", "<b> <name> \"b\" .")}
+
+	newMan := func(opt options, files []string) *manifest {
+		m, err := newManifest(opt, 0, files)
+		require.NoError(t, err)
+		return m
+	}
+	prev := newMan(opt, files)
+	prev.Files[0].add(0)
+	require.NoError(t, prev.write(dir))
+	prev, err = readManifest(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, prev.Files[0].Chunks)
+	require.NoError(t, prev.check(newMan(opt, files)))
+
+	changed := opt
+	changed.ReduceShards = 2
+	require.Error(t, prev.check(newMan(changed, files)))
+	require.Error(t, prev.check(newMan(opt, files[:1])))
+
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(files[1], later, later))
+	require.Error(t, prev.check(newMan(opt, files)))
+
+	write("schema", "name: string @index(hash) .")
+	require.Error(t, prev.check(newMan(opt, files)))
+}
+
+func TestRemoveStrayMapFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	paths := []string{"000/000001.map", "000/000003.map", "001/000002.map",
+		"shard_0/002/000004.map"}
+	for _, path := range paths {
+		path = filepath.Join(dir, "shards", path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+	}
+
+	m := &manifest{MapFiles: []string{"000/000001.map", "001/000002.map"}}
+	require.NoError(t, m.removeStrayMapFiles(dir))
+	var left []string
+	for _, path := range filenamesInTree(filepath.Join(dir, "shards")) {
+		rel, err := filepath.Rel(filepath.Join(dir, "shards"), path)
+		require.NoError(t, err)
+		left = append(left, rel)
+	}
+	require.Equal(t, []string{"000/000001.map", "001/000002.map", "shard_0/002/000004.map"}, left)
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	bpb "github.com/dgraph-io/badger/pb"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	humanize "github.com/dustin/go-humanize"
+	"google.golang.org/grpc"
+)
+
+// ingestBatchSize is the size of the batches of keys streamed to the Alphas.
+const ingestBatchSize = 4 << 20
+
+// ingest streams the output of the loader to the groups serving its predicates in the cluster of
+// Zero. The data of the predicates in the cluster is replaced at once, when all of them have been
+// streamed.
+func (ld *loader) ingest() error {
+	// Only the predicates with data are ingested. The ones only in the schema file are left as they
+	// are in the cluster.
+	var preds []string
+	for _, db := range ld.dbs {
+		txn := db.NewTransactionAt(math.MaxUint64, false)
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		itr := txn.NewIterator(opts)
+		prefix := []byte{x.DefaultPrefix}
+		for itr.Seek(prefix); itr.ValidForPrefix(prefix); {
+			pk := x.Parse(itr.Item().Key())
+			preds = append(preds, pk.Attr)
+			itr.Seek(pk.SkipPredicate())
+		}
+		itr.Close()
+		txn.Discard()
+	}
+	for _, pred := range preds {
+		if x.IsReservedPredicate(pred) {
+			return x.Errorf("Can't ingest reserved predicate %s", pred)
+		}
+	}
+	if len(preds) == 0 {
+		fmt.Println("No data to ingest.")
+		return nil
+	}
+	sort.Strings(preds)
+
+	zc := pb.NewZeroClient(ld.zero)
+	state, err := zc.StartIngest(context.Background(), &pb.IngestRequest{Predicates: preds})
+	if err != nil {
+		return x.Wrapf(err, "While starting ingestion")
+	}
+	fmt.Printf("Ingesting %d predicates at timestamp %d\n", len(preds), state.Ts)
+
+	groups := make(map[uint32][]string)
+	for _, pred := range preds {
+		gid := state.Tablets[pred]
+		groups[gid] = append(groups[gid], pred)
+	}
+	errCh := make(chan error, len(groups))
+	for gid, preds := range groups {
+		go func(gid uint32, preds []string) {
+			errCh <- ld.ingestGroup(state.Leaders[gid], preds, state.Ts)
+		}(gid, preds)
+	}
+	for range groups {
+		if gerr := <-errCh; gerr != nil && err == nil {
+			err = gerr
+		}
+	}
+
+	req := &pb.IngestRequest{Predicates: preds, Ts: state.Ts, Abort: err != nil}
+	if _, ferr := zc.FinishIngest(context.Background(), req); ferr != nil && err == nil {
+		err = x.Wrapf(ferr, "While finishing ingestion")
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Ingested %d predicates at timestamp %d\n", len(preds), state.Ts)
+	return nil
+}
+
+// ingestGroup streams the predicates to the leader of their group.
+func (ld *loader) ingestGroup(addr string, preds []string, ts uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithBlock(),
+		ld.opt.transportOption(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(x.GrpcMaxSize)))
+	if err != nil {
+		return x.Wrapf(err, "While connecting to Alpha at %s", addr)
+	}
+	defer conn.Close()
+
+	wc := pb.NewWorkerClient(conn)
+	for _, pred := range preds {
+		if err := ld.ingestPredicate(wc, pred, ts); err != nil {
+			return x.Wrapf(err, "While ingesting predicate %s into %s", pred, addr)
+		}
+	}
+	return nil
+}
+
+func (ld *loader) ingestPredicate(wc pb.WorkerClient, pred string, ts uint64) error {
+	start := time.Now()
+	stream, err := wc.IngestPredicate(context.Background())
+	if err != nil {
+		return err
+	}
+
+	batch := &pb.KVS{Ingest: &pb.IngestPredicate{Predicate: pred, Ts: ts}}
+	var size, total uint64
+	send := func() error {
+		if err := stream.Send(batch); err != nil {
+			return err
+		}
+		batch = &pb.KVS{}
+		total += size
+		size = 0
+		return nil
+	}
+	add := func(item *badger.Item) error {
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		kv := &bpb.KV{Key: item.KeyCopy(nil), Value: val, UserMeta: []byte{item.UserMeta()}}
+		batch.Kv = append(batch.Kv, kv)
+		size += uint64(len(kv.Key) + len(kv.Value))
+		if size >= ingestBatchSize {
+			return send()
+		}
+		return nil
+	}
+
+	// The predicate is in a single output DB, along with its schema.
+	prefix := x.PredicatePrefix(pred)
+	for _, db := range ld.dbs {
+		if err := ld.readPredicate(db, pred, prefix, add); err != nil {
+			return err
+		}
+	}
+	if err := send(); err != nil {
+		return err
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return err
+	}
+	fmt.Printf("Ingested predicate %s: %s in %s\n", pred, humanize.Bytes(total),
+		time.Since(start).Round(time.Second))
+	return nil
+}
+
+// readPredicate calls add with the schema and the keys of the predicate in the DB.
+func (ld *loader) readPredicate(db *badger.DB, pred string, prefix []byte,
+	add func(item *badger.Item) error) error {
+	txn := db.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+
+	item, err := txn.Get(x.SchemaKey(pred))
+	switch {
+	case err == badger.ErrKeyNotFound:
+	case err != nil:
+		return err
+	default:
+		if err := add(item); err != nil {
+			return err
+		}
+	}
+
+	itr := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		if err := add(itr.Item()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
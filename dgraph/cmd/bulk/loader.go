@@ -18,8 +18,8 @@ package bulk
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"hash/adler32"
 	"io"
@@ -31,14 +31,17 @@ import (
 	"time"
 
 	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/badger/y"
 
 	"github.com/dgraph-io/dgraph/chunker"
+	"github.com/dgraph-io/dgraph/chunker/csv"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/dgraph/xidmap"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type options struct {
@@ -61,11 +64,45 @@ type options struct {
 	IgnoreErrors     bool
 	CustomTokenizers string
 	NewUids          bool
+	Resume           bool
+	Ingest           bool
+	EncryptionKey    []byte
+	// CSVMapping describes how the records of CSV files are turned into N-Quads.
+	CSVMapping *csv.Mapping
 
 	MapShards    int
 	ReduceShards int
 
 	shardOutputDirs []string
+	// tlsConfig secures the connections to Zero and the Alphas, if set.
+	tlsConfig *tls.Config
+}
+
+// transportOption returns the dial option of the connections to Zero and the Alphas.
+func (opt *options) transportOption() grpc.DialOption {
+	if opt.tlsConfig == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(opt.tlsConfig))
+}
+
+// newChunker returns the Chunker of the input format for the file. The file only matters to read
+// CSV files, not to parse their chunks.
+func (opt *options) newChunker(inputFormat int, file string) chunker.Chunker {
+	if inputFormat == chunker.CsvFormat {
+		return chunker.NewCSVChunker(opt.CSVMapping, opt.CSVMapping.Comma(file, opt.DataFormat))
+	}
+	return chunker.NewChunker(inputFormat)
+}
+
+// chunkRef identifies a chunk by the index of its input file and its index in the file.
+type chunkRef struct {
+	file, seq int
+}
+
+type mapChunk struct {
+	buf *bytes.Buffer
+	ref chunkRef
 }
 
 type state struct {
@@ -74,10 +111,11 @@ type state struct {
 	xids          *xidmap.XidMap
 	schema        *schemaStore
 	shards        *shardMap
-	readerChunkCh chan *bytes.Buffer
+	readerChunkCh chan *mapChunk
 	mapFileId     uint32 // Used atomically to name the output files of the mappers.
 	dbs           []*badger.DB
 	writeTs       uint64 // All badger writes use this timestamp
+	ckpt          *checkpointer
 }
 
 type loader struct {
@@ -86,7 +124,7 @@ type loader struct {
 	zero    *grpc.ClientConn
 }
 
-func newLoader(opt options) *loader {
+func newLoader(opt options, man *manifest) *loader {
 	fmt.Printf("Connecting to zero at %s\n", opt.ZeroAddr)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
@@ -94,17 +132,25 @@ func newLoader(opt options) *loader {
 
 	zero, err := grpc.DialContext(ctx, opt.ZeroAddr,
 		grpc.WithBlock(),
-		grpc.WithInsecure())
+		opt.transportOption())
 	x.Checkf(err, "Unable to connect to zero, Is it running at %s?", opt.ZeroAddr)
+	// A resumed run writes at the timestamp of the run it resumes.
+	if man.WriteTs == 0 {
+		man.WriteTs = getWriteTimestamp(zero)
+	}
 	st := &state{
 		opt:    opt,
 		prog:   newProgress(),
 		shards: newShardMap(opt.MapShards),
 		// Lots of gz readers, so not much channel buffer needed.
-		readerChunkCh: make(chan *bytes.Buffer, opt.NumGoroutines),
-		writeTs:       getWriteTimestamp(zero),
+		readerChunkCh: make(chan *mapChunk, opt.NumGoroutines),
+		writeTs:       man.WriteTs,
+	}
+	st.schema = newSchemaStore(readSchema(opt.SchemaFile, opt.EncryptionKey), opt, st)
+	st.ckpt = newCheckpointer(st, man)
+	if opt.Resume {
+		st.ckpt.restore()
 	}
-	st.schema = newSchemaStore(readSchema(opt.SchemaFile), opt, st)
 	ld := &loader{
 		state:   st,
 		mappers: make([]*mapper, opt.NumGoroutines),
@@ -131,15 +177,10 @@ func getWriteTimestamp(zero *grpc.ClientConn) uint64 {
 	}
 }
 
-func readSchema(filename string) []*pb.SchemaUpdate {
-	f, err := os.Open(filename)
+func readSchema(filename string, key []byte) []*pb.SchemaUpdate {
+	r, cleanup, err := chunker.FileReader(filename, key)
 	x.Check(err)
-	defer f.Close()
-	var r io.Reader = f
-	if filepath.Ext(filename) == ".gz" {
-		r, err = gzip.NewReader(f)
-		x.Check(err)
-	}
+	defer cleanup()
 
 	buf, err := ioutil.ReadAll(r)
 	x.Check(err)
@@ -149,25 +190,65 @@ func readSchema(filename string) []*pb.SchemaUpdate {
 	return result.Schemas
 }
 
-func (ld *loader) mapStage() {
-	ld.prog.setPhase(mapPhase)
-	ld.xids = xidmap.New(ld.zero, nil)
-
-	files := x.FindDataFiles(ld.opt.DataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz"})
+// findDataFiles returns the data files to load and their format.
+func findDataFiles(opt options) ([]string, int) {
+	files := x.FindDataFiles(opt.DataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz",
+		".csv", ".csv.gz", ".tsv", ".tsv.gz"})
 	if len(files) == 0 {
-		fmt.Printf("No data files found in %s.\n", ld.opt.DataFiles)
+		fmt.Printf("No data files found in %s.\n", opt.DataFiles)
 		os.Exit(1)
 	}
 
 	// Because mappers must handle chunks that may be from different input files, they must all
-	// assume the same data format, either RDF or JSON. Use the one specified by the user or by
-	// the first load file.
-	loadType := chunker.DataFormat(files[0], ld.opt.DataFormat)
+	// assume the same data format, either RDF, JSON or CSV. Use the one specified by the user or
+	// by the first load file.
+	loadType := chunker.DataFormat(files[0], opt.DataFormat)
 	if loadType == chunker.UnknownFormat {
 		// Dont't try to detect JSON input in bulk loader.
-		fmt.Printf("Need --format=rdf or --format=json to load %s", files[0])
+		fmt.Printf("Need --format=rdf, --format=json or --format=csv to load %s", files[0])
 		os.Exit(1)
 	}
+	if loadType == chunker.CsvFormat && opt.CSVMapping == nil {
+		fmt.Printf("Need --csv_mapping to load %s", files[0])
+		os.Exit(1)
+	}
+	return files, loadType
+}
+
+// openXidDB opens the badger DB in the tmp directory that persists the xid mappings between
+// checkpoints.
+func (ld *loader) openXidDB() *badger.DB {
+	opt := badger.DefaultOptions
+	opt.SyncWrites = false
+	opt.Dir = filepath.Join(ld.opt.TmpDir, "xids")
+	opt.ValueDir = opt.Dir
+	x.Check(os.MkdirAll(opt.Dir, 0700))
+	db, err := badger.Open(opt)
+	x.Check(err)
+	return db
+}
+
+func (ld *loader) mapStage(loadType int) {
+	ld.prog.setPhase(mapPhase)
+	xidDB := ld.openXidDB()
+	ld.xids = xidmap.New(ld.zero, xidDB)
+
+	// Record the progress periodically, so that an interrupted run can be resumed.
+	ld.ckpt.save()
+	closer := y.NewCloser(1)
+	go func() {
+		defer closer.Done()
+		ticker := time.NewTicker(checkpointInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ld.ckpt.save()
+			case <-closer.HasBeenClosed():
+				return
+			}
+		}
+	}()
 
 	var mapperWg sync.WaitGroup
 	mapperWg.Add(len(ld.mappers))
@@ -180,22 +261,35 @@ func (ld *loader) mapStage() {
 
 	// This is the main map loop.
 	thr := x.NewThrottle(ld.opt.NumGoroutines)
+	files := ld.ckpt.man.Files
 	for i, file := range files {
+		skip := ld.ckpt.skip(i)
+		if skip.Done {
+			fmt.Printf("Skipping file (%d out of %d), done by a previous run: %s\n",
+				i+1, len(files), file.Path)
+			continue
+		}
 		thr.Start()
-		fmt.Printf("Processing file (%d out of %d): %s\n", i+1, len(files), file)
+		fmt.Printf("Processing file (%d out of %d): %s\n", i+1, len(files), file.Path)
 
-		go func(file string) {
+		go func(i int, file string) {
 			defer thr.Done()
 
-			r, cleanup := chunker.FileReader(file)
+			r, cleanup, err := chunker.FileReader(file, ld.opt.EncryptionKey)
+			x.Check(err)
 			defer cleanup()
 
-			chunker := chunker.NewChunker(loadType)
+			chunker := ld.opt.newChunker(loadType, file)
 			x.Check(chunker.Begin(r))
+			var seq int
 			for {
 				chunkBuf, err := chunker.Chunk(r)
 				if chunkBuf != nil && chunkBuf.Len() > 0 {
-					ld.readerChunkCh <- chunkBuf
+					// Skip the chunks mapped by the run being resumed.
+					if !skip.isDone(seq) {
+						ld.readerChunkCh <- &mapChunk{buf: chunkBuf, ref: chunkRef{i, seq}}
+					}
+					seq++
 				}
 				if err == io.EOF {
 					break
@@ -204,12 +298,15 @@ func (ld *loader) mapStage() {
 				}
 			}
 			x.Check(chunker.End(r))
-		}(file)
+			ld.ckpt.fileRead(i, seq)
+		}(i, file.Path)
 	}
 	thr.Wait()
 
 	close(ld.readerChunkCh)
 	mapperWg.Wait()
+	closer.SignalAndWait()
+	ld.ckpt.save()
 
 	// Allow memory to GC before the reduce phase.
 	for i := range ld.mappers {
@@ -217,12 +314,15 @@ func (ld *loader) mapStage() {
 	}
 	x.Check(ld.xids.Flush())
 	ld.xids = nil
+	x.Check(xidDB.Close())
 	runtime.GC()
 }
 
 type shuffleOutput struct {
 	db         *badger.DB
 	mapEntries []*pb.MapEntry
+	// Counts the reduce jobs of the shard whose writes are pending.
+	pending *sync.WaitGroup
 }
 
 func (ld *loader) reduceStage() {
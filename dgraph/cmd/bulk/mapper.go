@@ -30,9 +30,9 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
-	"github.com/dgraph-io/dgraph/chunker"
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -47,6 +47,11 @@ import (
 type mapper struct {
 	*state
 	shards []shardState // shard is based on predicate
+
+	// The chunks processed and the map files written since the last checkpoint.
+	chunks   []chunkRef
+	mapFiles []string
+	filesMu  sync.Mutex // Guards mapFiles, appended by the file writes.
 }
 
 type shardState struct {
@@ -108,19 +113,24 @@ func (m *mapper) writeMapEntriesToFile(entriesBuf []byte, shardIdx int) {
 	x.AssertTrue(len(buf) == 0)
 
 	fileNum := atomic.AddUint32(&m.mapFileId, 1)
-	filename := filepath.Join(
-		m.opt.TmpDir,
-		"shards",
+	relname := filepath.Join(
 		fmt.Sprintf("%03d", shardIdx),
 		fmt.Sprintf("%06d.map", fileNum),
 	)
+	filename := filepath.Join(m.opt.TmpDir, "shards", relname)
 	x.Check(os.MkdirAll(filepath.Dir(filename), 0755))
 	x.Check(x.WriteFileSync(filename, entriesBuf, 0644))
+
+	m.filesMu.Lock()
+	m.mapFiles = append(m.mapFiles, relname)
+	m.filesMu.Unlock()
 }
 
 func (m *mapper) run(inputFormat int) {
-	chunker := chunker.NewChunker(inputFormat)
-	for chunkBuf := range m.readerChunkCh {
+	chunker := m.opt.newChunker(inputFormat, "")
+	lastCheckpoint := time.Now()
+	for chunk := range m.readerChunkCh {
+		chunkBuf := chunk.buf
 		done := false
 		for !done {
 			nqs, err := chunker.Parse(chunkBuf)
@@ -154,16 +164,36 @@ func (m *mapper) run(inputFormat int) {
 				}
 			}
 		}
+
+		m.chunks = append(m.chunks, chunk.ref)
+		if time.Since(lastCheckpoint) >= checkpointInterval {
+			m.checkpoint()
+			lastCheckpoint = time.Now()
+		}
 	}
+	m.checkpoint()
+}
 
+// checkpoint writes out the buffered map entries, and records the chunks processed since the last
+// checkpoint as done.
+func (m *mapper) checkpoint() {
 	for i := range m.shards {
 		sh := &m.shards[i]
+		sh.mu.Lock() // Ensure that the last file write finishes.
 		if len(sh.entriesBuf) > 0 {
-			sh.mu.Lock() // One write at a time.
 			m.writeMapEntriesToFile(sh.entriesBuf, i)
+			sh.entriesBuf = sh.entriesBuf[:0]
+		} else {
+			sh.mu.Unlock()
 		}
-		m.shards[i].mu.Lock() // Ensure that the last file write finishes.
 	}
+
+	m.filesMu.Lock()
+	mapFiles := m.mapFiles
+	m.mapFiles = nil
+	m.filesMu.Unlock()
+	m.ckpt.mapped(m.chunks, mapFiles)
+	m.chunks = nil
 }
 
 func (m *mapper) addMapEntry(key []byte, p *pb.Posting, shard int) {
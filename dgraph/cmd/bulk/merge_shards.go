@@ -27,7 +27,13 @@ import (
 )
 
 func mergeMapShardsIntoReduceShards(opt options) {
-	mapShards := shardDirs(opt.TmpDir)
+	var mapShards []string
+	for _, shard := range shardDirs(opt.TmpDir) {
+		// The reduce shards exist if a resumed run was interrupted while merging.
+		if !strings.HasPrefix(filepath.Base(shard), "shard_") {
+			mapShards = append(mapShards, shard)
+		}
+	}
 
 	var reduceShards []string
 	for i := 0; i < opt.ReduceShards; i++ {
@@ -57,7 +63,9 @@ func shardDirs(tmpDir string) []string {
 		shards[i] = filepath.Join(tmpDir, "shards", shard)
 	}
 
-	// Allow largest shards to be shuffled first.
+	// Allow largest shards to be shuffled first. Sort by name before, so that the shards are in
+	// the same order in a resumed run.
+	sort.Strings(shards)
 	sortBySize(shards)
 	return shards
 }
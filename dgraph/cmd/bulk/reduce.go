@@ -100,5 +100,6 @@ func (r *reducer) reduce(job shuffleOutput) {
 		x.Check(err)
 		NumBadgerWrites.Add(-1)
 		r.writesThr.Done()
+		job.pending.Done()
 	}))
 }
@@ -28,6 +28,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dgraph-io/dgraph/chunker/csv"
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/spf13/cobra"
@@ -50,11 +52,13 @@ func init() {
 
 	flag := Bulk.Cmd.Flags()
 	flag.StringP("files", "f", "",
-		"Location of *.rdf(.gz) or *.json(.gz) file(s) to load.")
+		"Location of *.rdf(.gz), *.json(.gz), *.csv(.gz) or *.tsv(.gz) file(s) to load.")
 	flag.StringP("schema", "s", "",
 		"Location of schema file.")
 	flag.String("format", "",
-		"Specify file format (rdf or json) instead of getting it from filename.")
+		"Specify file format (rdf, json, csv or tsv) instead of getting it from filename.")
+	flag.String("csv_mapping", "",
+		"Location of the JSON file mapping the columns of CSV files to predicates.")
 	flag.String("out", defaultOutDir,
 		"Location to write the final dgraph data directories.")
 	flag.Bool("replace_out", false,
@@ -67,10 +71,16 @@ func init() {
 	flag.Int64("mapoutput_mb", 64,
 		"The estimated size of each map file output. Increasing this increases memory usage.")
 	flag.Bool("expand_edges", true,
-		"Generate edges that allow nodes to be expanded using _predicate_ or expand(...). "+
+		"Generate the _predicate_ edges listing the predicates of each node. "+
 			"Disable to increase loading speed.")
 	flag.Bool("skip_map_phase", false,
 		"Skip the map phase (assumes that map output files already exist).")
+	flag.Bool("resume", false,
+		"Resume the interrupted run whose progress is recorded in the tmp directory. The data "+
+			"files, the schema and the options changing the output must be the same.")
+	flag.Bool("replace_predicates", false,
+		"Ingest the output into the groups of the running cluster of --zero, replacing the data "+
+			"of the predicates loaded instead of merging it. Requires --expand_edges=false.")
 	flag.Bool("cleanup_tmp", true,
 		"Clean up the tmp directory after the loader finishes. Setting this to false allows the"+
 			" bulk loader can be re-run while skipping the map phase.")
@@ -80,6 +90,7 @@ func init() {
 	flag.Bool("version", false, "Prints the version of Dgraph Bulk Loader.")
 	flag.BoolP("store_xids", "x", false, "Generate an xid edge for each node.")
 	flag.StringP("zero", "z", "localhost:5080", "gRPC address for Dgraph zero")
+	x.RegisterClientTLSFlags(flag)
 	// TODO: Potentially move http server to main.
 	flag.String("http", "localhost:8080",
 		"Address to serve http (pprof).")
@@ -96,6 +107,9 @@ func init() {
 		"Comma separated list of tokenizer plugins")
 	flag.Bool("new_uids", false,
 		"Ignore UIDs in load files and assign new ones.")
+	flag.String("encryption_key_file", "",
+		"The file that stores the key used to encrypt the data and schema files, if they are "+
+			"encrypted exports.")
 }
 
 func run() {
@@ -121,12 +135,36 @@ func run() {
 		ReduceShards:     Bulk.Conf.GetInt("reduce_shards"),
 		CustomTokenizers: Bulk.Conf.GetString("custom_tokenizers"),
 		NewUids:          Bulk.Conf.GetBool("new_uids"),
+		Resume:           Bulk.Conf.GetBool("resume"),
+		Ingest:           Bulk.Conf.GetBool("replace_predicates"),
 	}
 
 	x.PrintVersion()
 	if opt.Version {
 		os.Exit(0)
 	}
+	if keyFile := Bulk.Conf.GetString("encryption_key_file"); keyFile != "" {
+		key, err := enc.ReadKeyFile(keyFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		opt.EncryptionKey = key
+	}
+	tlsConfig, err := x.LoadClientTLSConfig(Bulk.Conf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	opt.tlsConfig = tlsConfig
+	if mappingFile := Bulk.Conf.GetString("csv_mapping"); mappingFile != "" {
+		mapping, err := csv.ReadMapping(mappingFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		opt.CSVMapping = mapping
+	}
 	if opt.SchemaFile == "" {
 		fmt.Fprint(os.Stderr, "Schema file must be specified.\n")
 		os.Exit(1)
@@ -135,7 +173,7 @@ func run() {
 		os.Exit(1)
 	}
 	if opt.DataFiles == "" {
-		fmt.Fprint(os.Stderr, "RDF or JSON file(s) location must be specified.\n")
+		fmt.Fprint(os.Stderr, "RDF, JSON or CSV file(s) location must be specified.\n")
 		os.Exit(1)
 	} else if _, err := os.Stat(opt.DataFiles); err != nil && os.IsNotExist(err) {
 		fmt.Fprintf(os.Stderr, "Data path(%v) does not exist.\n", opt.DataFiles)
@@ -151,6 +189,15 @@ func run() {
 			opt.NumShufflers, opt.ReduceShards)
 		os.Exit(1)
 	}
+	if opt.Resume && opt.SkipMapPhase {
+		fmt.Fprint(os.Stderr, "Invalid flags: resume and skip_map_phase can't both be set.\n")
+		os.Exit(1)
+	}
+	if opt.Ingest && opt.ExpandEdges {
+		fmt.Fprint(os.Stderr,
+			"Invalid flags: replace_predicates requires expand_edges to be false.\n")
+		os.Exit(1)
+	}
 	if opt.CustomTokenizers != "" {
 		for _, soFile := range strings.Split(opt.CustomTokenizers, ",") {
 			tok.LoadCustomTokenizer(soFile)
@@ -169,9 +216,31 @@ func run() {
 		log.Fatal(http.ListenAndServe(opt.HttpAddr, nil))
 	}()
 
+	var files []string
+	var loadType int
+	if !opt.SkipMapPhase {
+		files, loadType = findDataFiles(opt)
+	}
+	man, err := newManifest(opt, loadType, files)
+	x.Check(err)
+	if opt.Resume {
+		prev, err := readManifest(opt.TmpDir)
+		if err == nil {
+			err = prev.check(man)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Can't resume: %v\n", err)
+			os.Exit(1)
+		}
+		man = prev
+	}
+	if opt.SkipMapPhase {
+		man.MapDone = true
+	}
+
 	// Make sure it's OK to create or replace the directory specified with the --out option.
 	// It is always OK to create or replace the default output directory.
-	if opt.OutDir != defaultOutDir && !opt.ReplaceOutDir {
+	if opt.OutDir != defaultOutDir && !opt.ReplaceOutDir && !opt.Resume {
 		missingOrEmpty, err := x.IsMissingOrEmptyDir(opt.OutDir)
 		x.CheckfNoTrace(err)
 		if !missingOrEmpty {
@@ -181,30 +250,47 @@ func run() {
 		}
 	}
 
-	// Delete and recreate the output dirs to ensure they are empty.
-	x.Check(os.RemoveAll(opt.OutDir))
+	// Delete and recreate the output dirs to ensure they are empty. A resumed run keeps the ones
+	// completed by the run it resumes.
+	if !opt.Resume {
+		x.Check(os.RemoveAll(opt.OutDir))
+	}
 	for i := 0; i < opt.ReduceShards; i++ {
 		dir := filepath.Join(opt.OutDir, strconv.Itoa(i), "p")
+		if opt.Resume && !man.ReduceDone[i] {
+			x.Check(os.RemoveAll(dir))
+		}
 		x.Check(os.MkdirAll(dir, 0700))
 		opt.shardOutputDirs = append(opt.shardOutputDirs, dir)
 	}
 
 	// Create a directory just for bulk loader's usage.
-	if !opt.SkipMapPhase {
+	if !opt.SkipMapPhase && !opt.Resume {
 		x.Check(os.RemoveAll(opt.TmpDir))
 		x.Check(os.MkdirAll(opt.TmpDir, 0700))
 	}
+	if opt.Resume && !man.MapDone {
+		x.Check(man.removeStrayMapFiles(opt.TmpDir))
+	}
 	if opt.CleanupTmp {
 		defer os.RemoveAll(opt.TmpDir)
 	}
 
-	loader := newLoader(opt)
-	if !opt.SkipMapPhase {
-		loader.mapStage()
+	loader := newLoader(opt, man)
+	if !man.MapDone {
+		loader.mapStage(loadType)
 		mergeMapShardsIntoReduceShards(opt)
+		loader.ckpt.mapDone()
 	}
 	loader.reduceStage()
 	loader.writeSchema()
+	if opt.Ingest {
+		if err := loader.ingest(); err != nil {
+			fmt.Fprintf(os.Stderr, "Ingestion failed: %v\n", err)
+			loader.cleanup()
+			os.Exit(1)
+		}
+	}
 	loader.cleanup()
 }
 
@@ -21,9 +21,11 @@ import (
 	"bytes"
 	"container/heap"
 	"encoding/binary"
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"sync"
 
 	"github.com/dgraph-io/badger"
 	bo "github.com/dgraph-io/badger/options"
@@ -44,6 +46,10 @@ func (s *shuffler) run() {
 
 	thr := x.NewThrottle(s.opt.NumShufflers)
 	for i := 0; i < s.opt.ReduceShards; i++ {
+		if s.ckpt.isReduced(i) {
+			fmt.Printf("Skipping reduce shard %d, done by a previous run\n", i)
+			continue
+		}
 		thr.Start()
 		go func(shardId int, db *badger.DB) {
 			mapFiles := filenamesInTree(shardDirs[shardId])
@@ -54,16 +60,26 @@ func (s *shuffler) run() {
 			}
 
 			ci := &countIndexer{state: s.state, db: db}
-			s.shufflePostings(shuffleInputChs, ci)
+			var pending sync.WaitGroup
+			s.shufflePostings(shuffleInputChs, ci, &pending)
 			ci.wait()
+			pending.Wait()
+			// The writes aren't synced, so the shard is only complete once its DB is closed.
+			x.Check(db.Close())
+			s.ckpt.reduced(shardId)
 			thr.Done()
-		}(i, s.createBadger(i))
+		}(i, s.openBadger(i))
 	}
 	thr.Wait()
+
+	// Reopen the DBs of all the shards, to write the schema and ingest them.
+	for i := 0; i < s.opt.ReduceShards; i++ {
+		s.dbs = append(s.dbs, s.openBadger(i))
+	}
 	close(s.output)
 }
 
-func (s *shuffler) createBadger(i int) *badger.DB {
+func (s *shuffler) openBadger(i int) *badger.DB {
 	opt := badger.DefaultOptions
 	opt.SyncWrites = false
 	opt.TableLoadingMode = bo.MemoryMap
@@ -71,7 +87,6 @@ func (s *shuffler) createBadger(i int) *badger.DB {
 	opt.ValueDir = opt.Dir
 	db, err := badger.OpenManaged(opt)
 	x.Check(err)
-	s.dbs = append(s.dbs, db)
 	return db
 }
 
@@ -107,7 +122,9 @@ func readMapOutput(filename string, mapEntryCh chan<- *pb.MapEntry) {
 	close(mapEntryCh)
 }
 
-func (s *shuffler) shufflePostings(mapEntryChs []chan *pb.MapEntry, ci *countIndexer) {
+func (s *shuffler) shufflePostings(mapEntryChs []chan *pb.MapEntry, ci *countIndexer,
+	pending *sync.WaitGroup) {
+
 	var ph postingHeap
 	for _, ch := range mapEntryChs {
 		heap.Push(&ph, heapNode{mapEntry: <-ch, ch: ch})
@@ -135,7 +152,8 @@ func (s *shuffler) shufflePostings(mapEntryChs []chan *pb.MapEntry, ci *countInd
 		}
 
 		if len(batch) >= batchSize && bytes.Compare(prevKey, me.Key) != 0 {
-			s.output <- shuffleOutput{mapEntries: batch, db: ci.db}
+			pending.Add(1)
+			s.output <- shuffleOutput{mapEntries: batch, db: ci.db, pending: pending}
 			NumQueuedReduceJobs.Add(1)
 			batch = make([]*pb.MapEntry, 0, batchAlloc)
 		}
@@ -144,7 +162,8 @@ func (s *shuffler) shufflePostings(mapEntryChs []chan *pb.MapEntry, ci *countInd
 		plistLen++
 	}
 	if len(batch) > 0 {
-		s.output <- shuffleOutput{mapEntries: batch, db: ci.db}
+		pending.Add(1)
+		s.output <- shuffleOutput{mapEntries: batch, db: ci.db, pending: pending}
 		NumQueuedReduceJobs.Add(1)
 	}
 	if plistLen > 0 {
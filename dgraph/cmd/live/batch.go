@@ -32,7 +32,6 @@ import (
 
 	"github.com/dgraph-io/badger"
 	"github.com/dgraph-io/dgo"
-	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgo/y"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
@@ -61,7 +60,11 @@ type batchMutationOptions struct {
 type loader struct {
 	opts batchMutationOptions
 
-	dc         *dgo.Dgraph
+	dc *dgo.Dgraph
+	// The clients of the Alphas sending the upsert blocks with --upsert_predicate. They go
+	// through the Dgraph service of pb, which takes the query of an upsert along with the
+	// mutation.
+	mcs        []pb.DgraphClient
 	alloc      *xidmap.XidMap
 	ticker     *time.Ticker
 	db         *badger.DB
@@ -80,8 +83,10 @@ type loader struct {
 	start time.Time
 
 	reqNum   uint64
-	reqs     chan api.Mutation
+	reqs     chan pb.Mutation
 	zeroconn *grpc.ClientConn
+	// Resolves the xids against the cluster with --upsert_predicate. Nil otherwise.
+	xids *xidResolver
 }
 
 type uidProvider struct {
@@ -147,17 +152,27 @@ func handleError(err error, reqNum uint64, isRetry bool) {
 	}
 }
 
-func (l *loader) infinitelyRetry(req api.Mutation, reqNum uint64) {
+// mutate sends the mutation in a transaction of its own, which is committed right away.
+func (l *loader) mutate(req *pb.Mutation) error {
+	req.Mutation.CommitNow = true
+	if l.xids == nil {
+		_, err := l.dc.NewTxn().Mutate(l.opts.Ctx, req.Mutation)
+		return err
+	}
+	mc := l.mcs[rand.Intn(len(l.mcs))]
+	_, err := mc.Mutate(l.opts.Ctx, req)
+	return err
+}
+
+func (l *loader) infinitelyRetry(req pb.Mutation, reqNum uint64) {
 	defer l.retryRequestsWg.Done()
 	nretries := 1
 	for i := time.Millisecond; ; i *= 2 {
-		txn := l.dc.NewTxn()
-		req.CommitNow = true
-		_, err := txn.Mutate(l.opts.Ctx, &req)
+		err := l.mutate(&req)
 		if err == nil {
 			fmt.Printf("Transaction #%d succeeded after %s.\n",
 				reqNum, english.Plural(nretries, "retry", "retries"))
-			atomic.AddUint64(&l.nquads, uint64(len(req.Set)))
+			atomic.AddUint64(&l.nquads, uint64(len(req.Mutation.Set)))
 			atomic.AddUint64(&l.txns, 1)
 			return
 		}
@@ -171,13 +186,11 @@ func (l *loader) infinitelyRetry(req api.Mutation, reqNum uint64) {
 	}
 }
 
-func (l *loader) request(req api.Mutation, reqNum uint64) {
-	txn := l.dc.NewTxn()
-	req.CommitNow = true
-	_, err := txn.Mutate(l.opts.Ctx, &req)
+func (l *loader) request(req pb.Mutation, reqNum uint64) {
+	err := l.mutate(&req)
 
 	if err == nil {
-		atomic.AddUint64(&l.nquads, uint64(len(req.Set)))
+		atomic.AddUint64(&l.nquads, uint64(len(req.Mutation.Set)))
 		atomic.AddUint64(&l.txns, 1)
 		return
 	}
@@ -170,6 +170,47 @@ func TestLiveLoadRdfUidDiscard(t *testing.T) {
 	checkLoadedData(t, true)
 }
 
+func TestLiveLoadRdfUpsertXid(t *testing.T) {
+	z.DropAll(t, dg)
+
+	// Loading the same file twice must not create the nodes again.
+	for i := 0; i < 2; i++ {
+		pipeline := [][]string{
+			{os.ExpandEnv("$GOPATH/bin/dgraph"), "live", "--new_uids", "--upsert_predicate", "xid",
+				"--schema", testDataDir + "/family_xid.schema", "--files", testDataDir + "/family.rdf",
+				"--alpha", alphaService},
+		}
+		err := z.Pipeline(pipeline)
+		require.NoError(t, err, "live loading RDF file exited with error")
+	}
+
+	resp, err := dg.NewTxn().Query(context.Background(), `
+		{
+			q(func: anyofterms(name, "Homer")) {
+				xid
+				name
+				parent_to { xid }
+			}
+			nodes(func: has(xid)) {
+				count(uid)
+			}
+		}
+	`)
+	require.NoError(t, err)
+	z.CompareJSON(t, `
+		{
+			"q": [
+				{
+					"xid": "0x2001",
+					"name": "Homer",
+					"parent_to": [{"xid": "0x3001"}, {"xid": "0x3002"}, {"xid": "0x3003"}]
+				}
+			],
+			"nodes": [{"count": 6}]
+		}
+	`, string(resp.GetJson()))
+}
+
 func TestMain(m *testing.M) {
 	_, thisFile, _, _ := runtime.Caller(0)
 	testDataDir = path.Dir(thisFile)
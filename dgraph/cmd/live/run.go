@@ -19,7 +19,6 @@ package live
 import (
 	"bufio"
 	"bytes"
-	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"errors"
@@ -43,6 +42,9 @@ import (
 	"github.com/dgraph-io/dgo/protos/api"
 
 	"github.com/dgraph-io/dgraph/chunker"
+	"github.com/dgraph-io/dgraph/chunker/csv"
+	"github.com/dgraph-io/dgraph/ee/enc"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/dgraph/xidmap"
 
@@ -62,6 +64,9 @@ type options struct {
 	authToken           string
 	useCompression      bool
 	newUids             bool
+	upsertPredicate     string
+	encryptionKey       []byte
+	csvMapping          *csv.Mapping
 }
 
 var (
@@ -84,9 +89,13 @@ func init() {
 	Live.EnvPrefix = "DGRAPH_LIVE"
 
 	flag := Live.Cmd.Flags()
-	flag.StringP("files", "f", "", "Location of *.rdf(.gz) or *.json(.gz) file(s) to load")
+	flag.StringP("files", "f", "",
+		"Location of *.rdf(.gz), *.json(.gz), *.csv(.gz) or *.tsv(.gz) file(s) to load")
 	flag.StringP("schema", "s", "", "Location of schema file")
-	flag.String("format", "", "Specify file format (rdf or json) instead of getting it from filename")
+	flag.String("format", "",
+		"Specify file format (rdf, json, csv or tsv) instead of getting it from filename")
+	flag.String("csv_mapping", "",
+		"Location of the JSON file mapping the columns of CSV files to predicates")
 	flag.StringP("alpha", "a", "127.0.0.1:9080",
 		"Comma-separated list of Dgraph alpha gRPC server addresses")
 	flag.StringP("zero", "z", "127.0.0.1:5080", "Dgraph zero gRPC server address")
@@ -103,6 +112,13 @@ func init() {
 		"Enable compression on connection to alpha server")
 	flag.Bool("new_uids", false,
 		"Ignore UIDs in load files and assign new ones.")
+	flag.StringP("upsert_predicate", "U", "",
+		"Resolve the blank nodes of the load files to the nodes in the cluster with their name "+
+			"as the value of this predicate, which must have an exact or hash index, and create "+
+			"only the missing ones. Loading the same files again updates the same nodes.")
+	flag.String("encryption_key_file", "",
+		"The file that stores the key used to encrypt the data and schema files, if they are "+
+			"encrypted exports.")
 
 	// TLS configuration
 	x.RegisterClientTLSFlags(flag)
@@ -137,21 +153,15 @@ func processSchemaFile(ctx context.Context, file string, dgraphClient *dgo.Dgrap
 		ctx = metadata.NewOutgoingContext(ctx, md)
 	}
 
-	f, err := os.Open(file)
-	x.CheckfNoTrace(err)
-	defer f.Close()
-
-	var reader io.Reader
-	if strings.HasSuffix(strings.ToLower(file), ".gz") {
-		reader, err = gzip.NewReader(f)
-		x.Check(err)
-	} else {
-		reader = f
+	reader, cleanup, err := chunker.FileReader(file, opt.encryptionKey)
+	if err != nil {
+		return err
 	}
+	defer cleanup()
 
 	b, err := ioutil.ReadAll(reader)
 	if err != nil {
-		x.Checkf(err, "Error while reading file")
+		return x.Wrapf(err, "Error while reading file")
 	}
 
 	op := &api.Operation{}
@@ -180,7 +190,10 @@ func (l *loader) uid(val string) string {
 func (l *loader) processFile(ctx context.Context, filename string) error {
 	fmt.Printf("Processing data file %q\n", filename)
 
-	rd, cleanup := chunker.FileReader(filename)
+	rd, cleanup, err := chunker.FileReader(filename, opt.encryptionKey)
+	if err != nil {
+		return err
+	}
 	defer cleanup()
 
 	loadType := chunker.DataFormat(filename, opt.dataFormat)
@@ -194,6 +207,13 @@ func (l *loader) processFile(ctx context.Context, filename string) error {
 		}
 	}
 
+	if loadType == chunker.CsvFormat {
+		if opt.csvMapping == nil {
+			return fmt.Errorf("need --csv_mapping to load %s", filename)
+		}
+		return l.processLoadFile(ctx, rd, chunker.NewCSVChunker(opt.csvMapping,
+			opt.csvMapping.Comma(filename, opt.dataFormat)))
+	}
 	return l.processLoadFile(ctx, rd, chunker.NewChunker(loadType))
 }
 
@@ -233,16 +253,18 @@ func (l *loader) processChunk(chunkBuf *bytes.Buffer, ck chunker.Chunker) {
 
 	batch := make([]*api.NQuad, 0, opt.batchSize)
 	for _, nq := range nqs {
-		nq.Subject = l.uid(nq.Subject)
-		if len(nq.ObjectId) > 0 {
-			nq.ObjectId = l.uid(nq.ObjectId)
+		// The xids are resolved by batch in the upsert mode.
+		if l.xids == nil {
+			nq.Subject = l.uid(nq.Subject)
+			if len(nq.ObjectId) > 0 {
+				nq.ObjectId = l.uid(nq.ObjectId)
+			}
 		}
 
 		batch = append(batch, nq)
 
 		if len(batch) >= opt.batchSize {
-			mu := api.Mutation{Set: batch}
-			l.reqs <- mu
+			l.send(batch)
 
 			// The following would create a new batch slice. We should not use batch =
 			// batch[:0], because it would end up modifying the batch array passed
@@ -253,11 +275,20 @@ func (l *loader) processChunk(chunkBuf *bytes.Buffer, ck chunker.Chunker) {
 
 	// sends the left over nqs
 	if len(batch) > 0 {
-		l.reqs <- api.Mutation{Set: batch}
+		l.send(batch)
 	}
 }
 
-func setup(opts batchMutationOptions, dc *dgo.Dgraph) *loader {
+// send queues the batch of N-Quads to be sent as a mutation.
+func (l *loader) send(batch []*api.NQuad) {
+	if l.xids != nil {
+		l.reqs <- l.xids.resolve(batch)
+		return
+	}
+	l.reqs <- pb.Mutation{Mutation: &api.Mutation{Set: batch}}
+}
+
+func setup(opts batchMutationOptions, dc *dgo.Dgraph, mcs []pb.DgraphClient) *loader {
 	var db *badger.DB
 	if len(opt.clientDir) > 0 {
 		x.Check(os.MkdirAll(opt.clientDir, 0700))
@@ -280,12 +311,16 @@ func setup(opts batchMutationOptions, dc *dgo.Dgraph) *loader {
 	l := &loader{
 		opts:     opts,
 		dc:       dc,
+		mcs:      mcs,
 		start:    time.Now(),
-		reqs:     make(chan api.Mutation, opts.Pending*2),
+		reqs:     make(chan pb.Mutation, opts.Pending*2),
 		alloc:    alloc,
 		db:       db,
 		zeroconn: connzero,
 	}
+	if opt.upsertPredicate != "" {
+		l.xids = newXidResolver(l, opt.upsertPredicate)
+	}
 
 	l.requestsWg.Add(opts.Pending)
 	for i := 0; i < opts.Pending; i++ {
@@ -311,11 +346,24 @@ func run() error {
 		authToken:           Live.Conf.GetString("auth_token"),
 		useCompression:      Live.Conf.GetBool("use_compression"),
 		newUids:             Live.Conf.GetBool("new_uids"),
+		upsertPredicate:     Live.Conf.GetString("upsert_predicate"),
 	}
 	tlsCfg, err := x.LoadClientTLSConfig(Live.Conf)
 	if err != nil {
 		return err
 	}
+	if keyFile := Live.Conf.GetString("encryption_key_file"); keyFile != "" {
+		if opt.encryptionKey, err = enc.ReadKeyFile(keyFile); err != nil {
+			fmt.Printf("%v\n", err)
+			return err
+		}
+	}
+	if mappingFile := Live.Conf.GetString("csv_mapping"); mappingFile != "" {
+		if opt.csvMapping, err = csv.ReadMapping(mappingFile); err != nil {
+			fmt.Printf("%v\n", err)
+			return err
+		}
+	}
 
 	go http.ListenAndServe("localhost:6060", nil)
 	ctx := context.Background()
@@ -329,6 +377,7 @@ func run() error {
 
 	ds := strings.Split(opt.alpha, ",")
 	var clients []api.DgraphClient
+	var mcs []pb.DgraphClient
 	for _, d := range ds {
 		conn, err := x.SetupConnection(d, tlsCfg, opt.useCompression)
 		x.Checkf(err, "While trying to setup connection to Dgraph alpha %v", ds)
@@ -336,10 +385,13 @@ func run() error {
 
 		dc := api.NewDgraphClient(conn)
 		clients = append(clients, dc)
+		if opt.upsertPredicate != "" {
+			mcs = append(mcs, pb.NewDgraphClient(conn))
+		}
 	}
 	dgraphClient := dgo.NewDgraphClient(clients...)
 
-	l := setup(bmOpts, dgraphClient)
+	l := setup(bmOpts, dgraphClient, mcs)
 	defer l.zeroconn.Close()
 
 	if len(opt.schemaFile) > 0 {
@@ -355,10 +407,11 @@ func run() error {
 	}
 
 	if opt.dataFiles == "" {
-		return errors.New("RDF or JSON file(s) location must be specified")
+		return errors.New("RDF, JSON or CSV file(s) location must be specified")
 	}
 
-	filesList := x.FindDataFiles(opt.dataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz"})
+	filesList := x.FindDataFiles(opt.dataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz",
+		".csv", ".csv.gz", ".tsv", ".tsv.gz"})
 	totalFiles := len(filesList)
 	if totalFiles == 0 {
 		return fmt.Errorf("No data files found in %s", opt.dataFiles)
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// xidResolver turns the batches into upsert blocks, which resolve the xids of their blank nodes
+// to the nodes that have them as the value of an indexed predicate in the cluster, so that
+// loading the same data again updates the same nodes. Nodes are only created for the xids not
+// found in the cluster, along with the edge setting their xid. The xids are looked up in the
+// transaction of the mutation, so the transactions creating the same xid concurrently conflict on
+// the @upsert index of the predicate, and the aborted ones are retried like any other.
+type xidResolver struct {
+	l    *loader
+	pred string
+}
+
+func newXidResolver(l *loader, pred string) *xidResolver {
+	return &xidResolver{l: l, pred: pred}
+}
+
+// isXid returns true if val is the xid of a blank node, as opposed to a uid.
+func isXid(val string) bool {
+	if opt.newUids {
+		return true
+	}
+	_, err := strconv.ParseUint(val, 0, 64)
+	return err != nil
+}
+
+// resolve returns the upsert block of the batch. Its query looks up the xids of the batch, and
+// its mutation refers to their nodes by uid variable, which creates the missing ones.
+func (r *xidResolver) resolve(batch []*api.NQuad) pb.Mutation {
+	var buf bytes.Buffer
+	var xids []string
+	vars := make(map[string]string)
+	buf.WriteString("{\n")
+	uid := func(val string) string {
+		if !isXid(val) {
+			// Not an xid. Treat it like l.uid does.
+			return r.l.uid(val)
+		}
+		// The xid of the blank node _:alice is alice.
+		xid := strings.TrimPrefix(val, "_:")
+		if v, ok := vars[xid]; ok {
+			return v
+		}
+		v := fmt.Sprintf("uid(x%d)", len(xids))
+		fmt.Fprintf(&buf, "  x%d as var(func: eq(%s, %s))\n", len(xids), r.pred,
+			strconv.Quote(xid))
+		vars[xid] = v
+		xids = append(xids, xid)
+		return v
+	}
+	for _, nq := range batch {
+		nq.Subject = uid(nq.Subject)
+		if len(nq.ObjectId) > 0 {
+			nq.ObjectId = uid(nq.ObjectId)
+		}
+	}
+	buf.WriteString("}")
+
+	// Setting the xid of the nodes found in the cluster again is a no-op, but it creates the
+	// nodes that are missing with it.
+	for _, xid := range xids {
+		batch = append(batch, &api.NQuad{
+			Subject:     vars[xid],
+			Predicate:   r.pred,
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: xid}},
+		})
+	}
+	mu := pb.Mutation{Mutation: &api.Mutation{Set: batch}}
+	if len(xids) > 0 {
+		mu.Query = buf.String()
+	}
+	return mu
+}
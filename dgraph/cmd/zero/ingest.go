@@ -0,0 +1,219 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// ingestTimeout is how long an ingestion can take before it's aborted. Commits on the ingested
+// predicates are blocked meanwhile.
+const ingestTimeout = 6 * time.Hour
+
+// ingestion is the ingestion of bulk loader output into the groups serving its predicates. See
+// worker/ingest.go for the steps on the Alpha side.
+type ingestion struct {
+	ts      uint64
+	groups  map[uint32][]string // The predicates ingested by each group.
+	unblock []func()
+	timer   *time.Timer
+}
+
+// StartIngest blocks the commits on the predicates to ingest, assigns the new ones to groups and
+// leases the timestamps of the ingestion.
+func (s *Server) StartIngest(ctx context.Context, req *pb.IngestRequest) (*pb.IngestState,
+	error) {
+	ctx, span := otrace.StartSpan(ctx, "Zero.StartIngest")
+	defer span.End()
+
+	if len(req.Predicates) == 0 {
+		return nil, x.Errorf("No predicates to ingest")
+	}
+	for _, pred := range req.Predicates {
+		if x.IsReservedPredicate(pred) {
+			return nil, x.Errorf("Unable to ingest reserved predicate %s", pred)
+		}
+	}
+	if _, err := s.latestMembershipState(ctx); err != nil {
+		return nil, x.Errorf("Unable to reach quorum: %v", err)
+	}
+	if !s.Node.AmLeader() {
+		return nil, x.Errorf("I am not the Zero leader")
+	}
+
+	select {
+	case s.moveOngoing <- struct{}{}:
+	default:
+		return nil, x.Errorf("A predicate move or ingestion is in progress")
+	}
+	ing := &ingestion{groups: make(map[uint32][]string)}
+	var err error
+	defer func() {
+		if err != nil {
+			ing.release(s)
+		}
+	}()
+
+	state := &pb.IngestState{
+		Tablets: make(map[string]uint32),
+		Leaders: make(map[uint32]string),
+	}
+	for _, pred := range req.Predicates {
+		var tab *pb.Tablet
+		if tab, err = s.ingestTablet(ctx, pred); err != nil {
+			return nil, err
+		}
+		state.Tablets[pred] = tab.GroupId
+		ing.groups[tab.GroupId] = append(ing.groups[tab.GroupId], pred)
+		ing.unblock = append(ing.unblock, s.blockTablet(pred))
+	}
+	for gid := range ing.groups {
+		pl := s.Leader(gid)
+		if pl == nil {
+			err = x.Errorf("No healthy connection found to leader of group %d", gid)
+			return nil, err
+		}
+		state.Leaders[gid] = pl.Addr
+	}
+
+	// No commits on the predicates would happen beyond ts. The existing data is deleted at ts,
+	// the ingested data is written at ts+1, and the existing data written back at ts+2 if the
+	// ingestion is aborted.
+	var ids *pb.AssignedIds
+	if ids, err = s.Timestamps(ctx, &pb.Num{Val: 3}); err != nil || ids.StartId == 0 {
+		err = x.Errorf("While leasing txn timestamps. Id: %+v Error: %v", ids, err)
+		return nil, err
+	}
+	ing.ts, state.Ts = ids.StartId, ids.StartId
+
+	s.ingestMu.Lock()
+	s.ingest = ing
+	ing.timer = time.AfterFunc(ingestTimeout, func() {
+		glog.Warningf("Ingestion at %d wasn't finished in %s", ing.ts, ingestTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := s.FinishIngest(ctx, &pb.IngestRequest{Ts: ing.ts, Abort: true}); err != nil {
+			glog.Errorf("While aborting ingestion at %d: %v", ing.ts, err)
+		}
+	})
+	s.ingestMu.Unlock()
+
+	glog.Infof("Starting ingestion at %d of predicates %v", ing.ts, req.Predicates)
+	span.Annotatef(nil, "Starting ingestion: %+v", state)
+	return state, nil
+}
+
+// ingestTablet returns the tablet of the predicate, assigning it to the group with the least data
+// if it isn't served yet.
+func (s *Server) ingestTablet(ctx context.Context, pred string) (*pb.Tablet, error) {
+	if tab := s.ServingTablet(pred); tab != nil {
+		return tab, nil
+	}
+
+	s.RLock()
+	var gids []uint32
+	space := make(map[uint32]int64)
+	for gid, group := range s.state.Groups {
+		gids = append(gids, gid)
+		for _, tab := range group.Tablets {
+			space[gid] += tab.Space
+		}
+	}
+	s.RUnlock()
+	if len(gids) == 0 {
+		return nil, x.Errorf("No groups to ingest predicate %s into", pred)
+	}
+	sort.Slice(gids, func(i, j int) bool {
+		if space[gids[i]] == space[gids[j]] {
+			return gids[i] < gids[j]
+		}
+		return space[gids[i]] < space[gids[j]]
+	})
+	return s.ShouldServe(ctx, &pb.Tablet{GroupId: gids[0], Predicate: pred})
+}
+
+// release unblocks the commits on the predicates, and allows the next move or ingestion.
+func (ing *ingestion) release(s *Server) {
+	for _, unblock := range ing.unblock {
+		unblock()
+	}
+	<-s.moveOngoing
+}
+
+// FinishIngest commits the ingestion once all the groups have written the ingested data, or aborts
+// it. Zero decides it like for a transaction starting at the ingestion timestamp, and the groups
+// get the decision through the Oracle delta stream: either the ingested data shows up in all of
+// them at the commit timestamp, or they all restore the existing data.
+func (s *Server) FinishIngest(ctx context.Context, req *pb.IngestRequest) (*api.Payload,
+	error) {
+	ctx, span := otrace.StartSpan(ctx, "Zero.FinishIngest")
+	defer span.End()
+
+	s.ingestMu.Lock()
+	defer s.ingestMu.Unlock()
+	ing := s.ingest
+	if ing == nil || ing.ts != req.Ts {
+		return nil, x.Errorf("No ingestion at %d is in progress", req.Ts)
+	}
+	s.ingest = nil
+	ing.timer.Stop()
+	defer ing.release(s)
+
+	gids := make([]uint32, 0, len(ing.groups))
+	for gid := range ing.groups {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+
+	var finishErr error
+	for _, gid := range gids {
+		if req.Abort {
+			break
+		}
+		in := &pb.IngestRequest{Predicates: ing.groups[gid], Ts: ing.ts}
+		err := x.Errorf("No healthy connection found to leader of group %d", gid)
+		if pl := s.Leader(gid); pl != nil {
+			_, err = pb.NewWorkerClient(pl.Get()).PrepareIngest(ctx, in)
+		}
+		if err != nil {
+			finishErr = x.Errorf("While finishing ingestion in group %d: %v", gid, err)
+			glog.Errorf("%v. Aborting it.", finishErr)
+			break
+		}
+	}
+
+	txn := &api.TxnContext{StartTs: ing.ts, Aborted: req.Abort || finishErr != nil}
+	if err := s.commit(ctx, txn); err != nil {
+		// The groups ask to abort the ingestion once it times out.
+		return nil, x.Errorf("While finishing ingestion at %d: %v", ing.ts, err)
+	}
+	if txn.Aborted && finishErr == nil && !req.Abort {
+		finishErr = x.Errorf("Ingestion at %d was aborted", ing.ts)
+	}
+	glog.Infof("Ingestion at %d finished. Commit ts: %d. Aborted: %v",
+		ing.ts, txn.CommitTs, txn.Aborted)
+	span.Annotatef(nil, "Ingestion at %d finished. Aborted: %v", ing.ts, txn.Aborted)
+	return &api.Payload{}, finishErr
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -518,3 +519,73 @@ func (s *Server) Timestamps(ctx context.Context, num *pb.Num) (*pb.AssignedIds,
 	}
 	return reply, err
 }
+
+// maxTsLog is the max number of entries in the timestamp log, about a week with one entry per
+// second.
+const maxTsLog = 7 * 24 * 3600
+
+// appendTsLog appends the entry to the timestamp log, unless it doesn't move the log forward.
+func appendTsLog(log []*pb.TimestampAt, e *pb.TimestampAt) []*pb.TimestampAt {
+	if n := len(log); n > 0 && (e.Ts <= log[n-1].Ts || e.At <= log[n-1].At) {
+		return log
+	}
+	if len(log) >= maxTsLog {
+		// Drop the oldest tenth, so this doesn't happen every time.
+		log = append(log[:0], log[maxTsLog/10:]...)
+	}
+	return append(log, e)
+}
+
+// trimTsLog drops the entries of the timestamps below minTs, which can't be read at anymore. The
+// last of them is kept, as it maps the times after it to minTs.
+func trimTsLog(log []*pb.TimestampAt, minTs uint64) []*pb.TimestampAt {
+	i := sort.Search(len(log), func(i int) bool { return log[i].Ts >= minTs })
+	if i > 1 {
+		log = append(log[:0], log[i-1:]...)
+	}
+	return log
+}
+
+// timestampAt returns the max assigned timestamp at the given time, found in the timestamp log.
+func timestampAt(log []*pb.TimestampAt, at time.Time) (uint64, error) {
+	i := sort.Search(len(log), func(i int) bool { return log[i].At > at.UnixNano() })
+	if i == 0 {
+		if len(log) == 0 {
+			return 0, x.Errorf("No timestamp is known for time %s yet.", at.Format(time.RFC3339))
+		}
+		return 0, x.Errorf("No timestamp is known for time %s. The earliest known time is %s.",
+			at.Format(time.RFC3339), time.Unix(0, log[0].At).Format(time.RFC3339))
+	}
+	return log[i-1].Ts, nil
+}
+
+// logTimestamp records the max assigned timestamp at the current time in the timestamp log, if
+// it moved since the last entry. It's called by the leader every second.
+func (s *Server) logTimestamp() {
+	ts := s.orc.MaxPending()
+	s.RLock()
+	log := s.state.TsLog
+	moved := ts > 0 && (len(log) == 0 || ts > log[len(log)-1].Ts)
+	s.RUnlock()
+	if !moved {
+		return
+	}
+	p := &pb.ZeroProposal{TsAt: &pb.TimestampAt{At: time.Now().UnixNano(), Ts: ts}}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Node.proposeAndWait(ctx, p); err != nil {
+		glog.Warningf("While logging timestamp %d: %v", ts, err)
+	}
+}
+
+// TimestampAt returns the timestamp to read the data as it was at the given time, i.e., the max
+// assigned timestamp at that time. The timestamps below the snapshots of the groups are dropped.
+func (s *Server) TimestampAt(ctx context.Context, req *pb.TimestampAt) (*pb.TimestampAt, error) {
+	s.RLock()
+	defer s.RUnlock()
+	ts, err := timestampAt(s.state.TsLog, time.Unix(0, req.At))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TimestampAt{At: req.At, Ts: ts}, nil
+}
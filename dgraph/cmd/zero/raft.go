@@ -208,7 +208,11 @@ func (n *node) handleMemberProposal(member *pb.Member) error {
 		}
 		return nil
 	}
-	if !has && len(group.Members) >= n.server.NumReplicas {
+	switch {
+	case has:
+	case member.Learner && numReplicas(group) == 0:
+		return x.Errorf("Group has no voters for the learner to replicate: %+v", member)
+	case !member.Learner && numReplicas(group) >= n.server.NumReplicas:
 		// We shouldn't allow more members than the number of replicas.
 		return x.Errorf("Group reached replication level. Can't add another member: %+v", member)
 	}
@@ -219,7 +223,7 @@ func (n *node) handleMemberProposal(member *pb.Member) error {
 	group.Members[member.Id] = member
 	// Increment nextGroup when we have enough replicas
 	if member.GroupId == n.server.nextGroup &&
-		len(group.Members) >= n.server.NumReplicas {
+		numReplicas(group) >= n.server.NumReplicas {
 		n.server.nextGroup++
 	}
 	if member.Leader {
@@ -342,6 +346,7 @@ func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 		}
 		if purgeTs < math.MaxUint64 {
 			n.server.orc.purgeBelow(purgeTs)
+			state.TsLog = trimTsLog(state.TsLog, purgeTs)
 		}
 	}
 	if p.Member != nil {
@@ -372,6 +377,9 @@ func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 	if p.Txn != nil {
 		n.server.orc.updateCommitStatus(e.Index, p.Txn)
 	}
+	if p.TsAt != nil {
+		state.TsLog = appendTsLog(state.TsLog, p.TsAt)
+	}
 
 	return p.Key, nil
 }
@@ -459,9 +467,14 @@ func (n *node) initAndStartNode() error {
 		for {
 			ctx, cancel := context.WithTimeout(n.ctx, timeout)
 			defer cancel()
-			// JoinCluster can block indefinitely, raft ignores conf change proposal
-			// if it has pending configuration.
-			_, err := c.JoinCluster(ctx, n.RaftContext)
+			// The members of the cluster are needed to accept their messages, see isMember.
+			cs, err := pb.NewZeroClient(gconn).Connect(ctx, &pb.Member{ClusterInfoOnly: true})
+			if err == nil {
+				n.server.setJoinState(cs.GetState())
+				// JoinCluster can block indefinitely, raft ignores conf change proposal
+				// if it has pending configuration.
+				_, err = c.JoinCluster(ctx, n.RaftContext)
+			}
 			if err == nil {
 				break
 			}
@@ -563,6 +576,24 @@ func (n *node) checkQuorum(closer *y.Closer) {
 	}
 }
 
+func (n *node) logTimestampsPeriodically(closer *y.Closer) {
+	defer closer.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if n.AmLeader() {
+				n.server.logTimestamp()
+			}
+
+		case <-closer.HasBeenClosed():
+			return
+		}
+	}
+}
+
 func (n *node) snapshotPeriodically(closer *y.Closer) {
 	defer closer.Done()
 	ticker := time.NewTicker(10 * time.Second)
@@ -604,7 +635,7 @@ func (n *node) Run() {
 	// snapshot can cause select loop to block while deleting entries, so run
 	// it in goroutine
 	readStateCh := make(chan raft.ReadState, 100)
-	closer := y.NewCloser(4)
+	closer := y.NewCloser(5)
 	defer func() {
 		closer.SignalAndWait()
 		n.closer.Done()
@@ -614,6 +645,7 @@ func (n *node) Run() {
 	go n.snapshotPeriodically(closer)
 	go n.updateZeroMembershipPeriodically(closer)
 	go n.checkQuorum(closer)
+	go n.logTimestampsPeriodically(closer)
 	go n.RunReadIndexLoop(closer, readStateCh)
 	// We only stop runReadIndexLoop after the for loop below has finished interacting with it.
 	// That way we know sending to readStateCh will not deadlock.
@@ -87,6 +87,7 @@ instances to achieve high-availability.
 	flag.StringP("wal", "w", "zw", "Directory storing WAL.")
 	flag.Duration("rebalance_interval", 8*time.Minute, "Interval for trying a predicate move.")
 	flag.Bool("telemetry", true, "Send anonymous telemetry data to Dgraph devs.")
+	x.RegisterInternalTLSFlags(flag)
 
 	// OpenCensus flags.
 	flag.Float64("trace", 1.0, "The ratio of queries to trace.")
@@ -125,11 +126,13 @@ func (st *state) serveGRPC(l net.Listener, store *raftwal.DiskStorage) {
 	// 	glog.Fatalf("Unable to register OpenCensus stats: %v", err)
 	// }
 
-	s := grpc.NewServer(
+	grpcOpts := append([]grpc.ServerOption{
 		grpc.MaxRecvMsgSize(x.GrpcMaxSize),
 		grpc.MaxSendMsgSize(x.GrpcMaxSize),
 		grpc.MaxConcurrentStreams(1000),
-		grpc.StatsHandler(&ocgrpc.ServerHandler{}))
+		grpc.StatsHandler(&ocgrpc.ServerHandler{})},
+		conn.ServerOptions()...)
+	s := grpc.NewServer(grpcOpts...)
 
 	rc := pb.RaftContext{Id: opts.nodeId, Addr: opts.myAddr, Group: 0}
 	m := conn.NewNode(&rc, store)
@@ -229,6 +232,14 @@ func run() {
 
 	// Initialize the servers.
 	var st state
+	serverTLS, clientTLS, err := x.LoadInternalTLSConfig(Zero.Conf)
+	x.Checkf(err, "Error while loading internal TLS certificates")
+	if serverTLS != nil {
+		conn.SetInternalTLS(serverTLS, clientTLS, func(hosts []string) bool {
+			return st.zero.isMember(hosts)
+		}, x.InternalTLSClients(Zero.Conf))
+		glog.Info("Mutual TLS enabled for the internal traffic.")
+	}
 	st.serveGRPC(grpcListener, store)
 	st.serveHTTP(httpListener)
 
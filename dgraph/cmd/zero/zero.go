@@ -48,6 +48,9 @@ type Server struct {
 
 	NumReplicas int
 	state       *pb.MembershipState
+	// joinState is the membership state of the cluster this Zero joins, given by its peer. Its
+	// members are trusted until this Zero learns its own membership.
+	joinState *pb.MembershipState
 
 	nextLeaseId uint64
 	nextTxnTs   uint64
@@ -62,6 +65,9 @@ type Server struct {
 
 	moveOngoing    chan struct{}
 	blockCommitsOn *sync.Map
+
+	ingestMu sync.Mutex
+	ingest   *ingestion // The ongoing ingestion of bulk loader output, if any.
 }
 
 func (s *Server) Init() {
@@ -163,6 +169,10 @@ func (s *Server) Leader(gid uint32) *conn.Pool {
 	}
 	var healthyPool *conn.Pool
 	for _, m := range members {
+		if m.Learner {
+			// Learners never become the leader, and don't accept writes.
+			continue
+		}
 		if pl, err := conn.Get().Get(m.Addr); err == nil {
 			healthyPool = pl
 			if m.Leader {
@@ -173,6 +183,42 @@ func (s *Server) Leader(gid uint32) *conn.Pool {
 	return healthyPool
 }
 
+// numReplicas returns the number of voting members of the group. Learners don't count towards the
+// replication level.
+func numReplicas(group *pb.Group) int {
+	var n int
+	for _, m := range group.Members {
+		if !m.Learner {
+			n++
+		}
+	}
+	return n
+}
+
+// learnerGroup returns the group a new learner should join. That's gid if it has voters, or the
+// group with voters and the fewest learners if gid is zero. It returns zero if there's none.
+func (s *Server) learnerGroup(gid uint32) uint32 {
+	s.AssertRLock()
+	if gid > 0 {
+		if group, has := s.state.Groups[gid]; has && numReplicas(group) > 0 {
+			return gid
+		}
+		return 0
+	}
+	var res uint32
+	minLearners := -1
+	for gid, group := range s.state.Groups {
+		voters := numReplicas(group)
+		if voters == 0 {
+			continue
+		}
+		if learners := len(group.Members) - voters; minLearners < 0 || learners < minLearners {
+			res, minLearners = gid, learners
+		}
+	}
+	return res
+}
+
 func (s *Server) KnownGroups() []uint32 {
 	var groups []uint32
 	s.RLock()
@@ -231,7 +277,27 @@ func (s *Server) MarshalMembershipState() ([]byte, error) {
 func (s *Server) membershipState() *pb.MembershipState {
 	s.RLock()
 	defer s.RUnlock()
-	return proto.Clone(s.state).(*pb.MembershipState)
+	// The timestamp log only serves Zero's TimestampAt, it's left out of the copies.
+	state := *s.state
+	state.TsLog = nil
+	return proto.Clone(&state).(*pb.MembershipState)
+}
+
+// isMember returns true if one of the hosts is the host of a member of the cluster.
+func (s *Server) isMember(hosts []string) bool {
+	s.RLock()
+	defer s.RUnlock()
+	if s.Node != nil && s.state.GetZeros()[s.Node.Id] == nil &&
+		conn.IsMember(s.joinState, hosts) {
+		return true
+	}
+	return conn.IsMember(s.state, hosts)
+}
+
+func (s *Server) setJoinState(state *pb.MembershipState) {
+	s.Lock()
+	defer s.Unlock()
+	s.joinState = state
 }
 
 func (s *Server) groupChecksums() map[uint32]uint64 {
@@ -460,7 +526,7 @@ func (s *Server) Connect(ctx context.Context,
 	// Create a connection and check validity of the address by doing an Echo.
 	conn.Get().Connect(m.Addr)
 
-	createProposal := func() *pb.ZeroProposal {
+	createProposal := func() (*pb.ZeroProposal, error) {
 		s.Lock()
 		defer s.Unlock()
 
@@ -468,7 +534,7 @@ func (s *Server) Connect(ctx context.Context,
 		// Check if we already have this member.
 		for _, group := range s.state.Groups {
 			if _, has := group.Members[m.Id]; has {
-				return nil
+				return nil, nil
 			}
 		}
 		if m.Id == 0 {
@@ -476,34 +542,45 @@ func (s *Server) Connect(ctx context.Context,
 			proposal.MaxRaftId = m.Id
 		}
 
+		if m.Learner {
+			// Learners join a group which already has voters, and don't count towards its
+			// replication level.
+			m.GroupId = s.learnerGroup(m.GroupId)
+			if m.GroupId == 0 {
+				return nil, x.Errorf("No group for learner to join: %+v", m)
+			}
+			proposal.Member = m
+			return proposal, nil
+		}
+
 		// We don't have this member. So, let's see if it has preference for a group.
 		if m.GroupId > 0 {
 			group, has := s.state.Groups[m.GroupId]
 			if !has {
 				// We don't have this group. Add the server to this group.
 				proposal.Member = m
-				return proposal
+				return proposal, nil
 			}
 
 			if _, has := group.Members[m.Id]; has {
 				proposal.Member = m // Update in case some fields have changed, like address.
-				return proposal
+				return proposal, nil
 			}
 
 			// We don't have this server in the list.
-			if len(group.Members) < s.NumReplicas {
+			if numReplicas(group) < s.NumReplicas {
 				// We need more servers here, so let's add it.
 				proposal.Member = m
-				return proposal
+				return proposal, nil
 			}
 			// Already have plenty of servers serving this group.
 		}
 		// Let's assign this server to a new group.
 		for gid, group := range s.state.Groups {
-			if len(group.Members) < s.NumReplicas {
+			if numReplicas(group) < s.NumReplicas {
 				m.GroupId = gid
 				proposal.Member = m
-				return proposal
+				return proposal, nil
 			}
 		}
 		// We either don't have any groups, or don't have any groups which need another member.
@@ -511,10 +588,13 @@ func (s *Server) Connect(ctx context.Context,
 		// We shouldn't increase nextGroup here as we don't know whether we have enough
 		// replicas until proposal is committed and can cause issues due to race.
 		proposal.Member = m
-		return proposal
+		return proposal, nil
 	}
 
-	proposal := createProposal()
+	proposal, err := createProposal()
+	if err != nil {
+		return &emptyConnectionState, err
+	}
 	if proposal != nil {
 		if err := s.Node.proposeAndWait(ctx, proposal); err != nil {
 			return &emptyConnectionState, err
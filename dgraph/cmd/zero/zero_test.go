@@ -19,6 +19,7 @@ package zero
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/stretchr/testify/require"
@@ -35,3 +36,74 @@ func TestRemoveNode(t *testing.T) {
 	err = server.removeNode(context.TODO(), 1, 2)
 	require.Error(t, err)
 }
+
+func TestLearnerGroup(t *testing.T) {
+	server := &Server{
+		state: &pb.MembershipState{
+			Groups: map[uint32]*pb.Group{
+				1: {Members: map[uint64]*pb.Member{
+					1: {Id: 1, GroupId: 1},
+					2: {Id: 2, GroupId: 1, Learner: true},
+				}},
+				2: {Members: map[uint64]*pb.Member{
+					3: {Id: 3, GroupId: 2},
+				}},
+				3: {Members: map[uint64]*pb.Member{
+					4: {Id: 4, GroupId: 3, Learner: true},
+				}},
+			},
+		},
+	}
+	server.RLock()
+	defer server.RUnlock()
+
+	require.Equal(t, 1, numReplicas(server.state.Groups[1]))
+	require.Equal(t, 0, numReplicas(server.state.Groups[3]))
+	// The group with voters and the fewest learners is picked.
+	require.Equal(t, uint32(2), server.learnerGroup(0))
+	require.Equal(t, uint32(1), server.learnerGroup(1))
+	// Groups without voters can't be joined by a learner.
+	require.Equal(t, uint32(0), server.learnerGroup(3))
+	require.Equal(t, uint32(0), server.learnerGroup(4))
+}
+
+func TestTimestampAt(t *testing.T) {
+	start := time.Now()
+	at := func(d time.Duration) int64 { return start.Add(d).UnixNano() }
+	_, err := timestampAt(nil, start)
+	require.Error(t, err)
+
+	var log []*pb.TimestampAt
+	log = appendTsLog(log, &pb.TimestampAt{At: at(0), Ts: 10})
+	log = appendTsLog(log, &pb.TimestampAt{At: at(time.Second), Ts: 10}) // Didn't move.
+	log = appendTsLog(log, &pb.TimestampAt{At: at(2 * time.Second), Ts: 20})
+	log = appendTsLog(log, &pb.TimestampAt{At: at(time.Second), Ts: 25}) // Went back in time.
+	log = appendTsLog(log, &pb.TimestampAt{At: at(4 * time.Second), Ts: 30})
+	require.Equal(t, 3, len(log))
+
+	_, err = timestampAt(log, start.Add(-time.Second))
+	require.Error(t, err)
+	for _, tc := range []struct {
+		at time.Duration
+		ts uint64
+	}{
+		{0, 10},
+		{time.Second, 10},
+		{2 * time.Second, 20},
+		{3 * time.Second, 20},
+		{time.Hour, 30},
+	} {
+		ts, err := timestampAt(log, start.Add(tc.at))
+		require.NoError(t, err)
+		require.Equal(t, tc.ts, ts, "at %s", tc.at)
+	}
+
+	// The entry of timestamp 20 is kept, as the times after it map to it until timestamp 30.
+	log = trimTsLog(log, 25)
+	require.Equal(t, 2, len(log))
+	_, err = timestampAt(log, start.Add(time.Second))
+	require.Error(t, err)
+	ts, err := timestampAt(log, start.Add(3*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), ts)
+}
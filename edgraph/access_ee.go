@@ -16,6 +16,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,7 +26,10 @@ import (
 
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgraph/ee/acl"
+	"github.com/dgraph-io/dgraph/ee/auth"
 	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/query"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/x"
 	jwt "github.com/dgrijalva/jwt-go"
@@ -38,10 +42,19 @@ import (
 )
 
 func (s *Server) Login(ctx context.Context,
-	request *api.LoginRequest) (*api.Response, error) {
+	request *api.LoginRequest) (resp *api.Response, rerr error) {
 	ctx, span := otrace.StartSpan(ctx, "server.Login")
 	defer span.End()
 
+	var user *acl.User
+	defer func() {
+		if user != nil {
+			auditLogin(ctx, user.UserID, acl.GetGroupIDs(user.Groups), rerr)
+		} else {
+			auditLogin(ctx, request.GetUserid(), nil, rerr)
+		}
+	}()
+
 	// record the client ip for this login request
 	var addr string
 	if peerInfo, ok := peer.FromContext(ctx); ok {
@@ -59,7 +72,7 @@ func (s *Server) Login(ctx context.Context,
 		return nil, fmt.Errorf(errMsg)
 	}
 
-	resp := &api.Response{}
+	resp = &api.Response{}
 	accessJwt, err := getAccessJwt(user.UserID, user.Groups)
 	if err != nil {
 		errMsg := fmt.Sprintf("unable to get access jwt (userid=%s,addr=%s):%v",
@@ -67,12 +80,17 @@ func (s *Server) Login(ctx context.Context,
 		glog.Errorf(errMsg)
 		return nil, fmt.Errorf(errMsg)
 	}
-	refreshJwt, err := getRefreshJwt(user.UserID)
-	if err != nil {
-		errMsg := fmt.Sprintf("unable to get refresh jwt (userid=%s,addr=%s):%v",
-			user.UserID, addr, err)
-		glog.Errorf(errMsg)
-		return nil, fmt.Errorf(errMsg)
+	// The refresh jwt is exchanged for the groups stored in Dgraph, so the users of the LDAP
+	// server log in again instead.
+	var refreshJwt string
+	if !isLDAPLogin(request) {
+		refreshJwt, err = getRefreshJwt(user.UserID)
+		if err != nil {
+			errMsg := fmt.Sprintf("unable to get refresh jwt (userid=%s,addr=%s):%v",
+				user.UserID, addr, err)
+			glog.Errorf(errMsg)
+			return nil, fmt.Errorf(errMsg)
+		}
 	}
 
 	loginJwt := api.Jwt{
@@ -123,6 +141,19 @@ func (s *Server) authenticateLogin(ctx context.Context, request *api.LoginReques
 		return user, nil
 	}
 
+	if isLDAPLogin(request) {
+		id, err := Config.Authenticator.Bind(request.Userid, request.Password)
+		if err != nil {
+			return nil, fmt.Errorf("unable to authenticate through LDAP: %v", err)
+		}
+		user = &acl.User{UserID: id.UserID}
+		for _, groupId := range id.Groups {
+			user.Groups = append(user.Groups, acl.Group{GroupID: groupId})
+		}
+		glog.Infof("Authenticated user %s through LDAP", id.UserID)
+		return user, nil
+	}
+
 	// authorize the user using password
 	var err error
 	user, err = authorizeUser(ctx, request.Userid, request.Password)
@@ -141,6 +172,26 @@ func (s *Server) authenticateLogin(ctx context.Context, request *api.LoginReques
 	return user, nil
 }
 
+// isLDAPLogin returns whether the password of the login request is checked by the LDAP server.
+// The password of groot is always checked by Dgraph, so that it can't be locked out.
+func isLDAPLogin(request *api.LoginRequest) bool {
+	return len(request.RefreshToken) == 0 && Config.Authenticator.HasLDAP() &&
+		request.Userid != x.GrootId
+}
+
+// validateAccessToken validates the access jwt like validateToken, unless it's signed like the
+// jwts of the identity provider, in which case it's verified by the Authenticator.
+func validateAccessToken(jwtStr string) ([]string, error) {
+	if !Config.Authenticator.IsProviderJWT(jwtStr) {
+		return validateToken(jwtStr)
+	}
+	id, err := Config.Authenticator.VerifyJWT(jwtStr)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{id.UserID}, id.Groups...), nil
+}
+
 // validateToken verifies the signature and expiration of the jwt, and if validation passes,
 // returns a slice of strings, where the first element is the extracted userId
 // and the rest are groupIds encoded in the jwt.
@@ -269,6 +320,9 @@ func authorizeUser(ctx context.Context, userid string, password string) (*acl.Us
 		Vars:  queryVars,
 	}
 
+	// The query is run by the server, not with the credentials of the request, which are the
+	// expired ones when logging in with the refresh token.
+	ctx = metadata.NewIncomingContext(ctx, metadata.MD{})
 	queryResp, err := (&Server{}).doQuery(ctx, &queryRequest)
 	if err != nil {
 		glog.Errorf("Error while query user with id %s: %v", userid, err)
@@ -377,7 +431,8 @@ func ResetAcl() {
 			Set:       createUserNQuads,
 		}
 
-		if _, err := (&Server{}).doMutate(context.Background(), mu); err != nil {
+		_, err = (&Server{}).doMutate(context.Background(), &pb.Mutation{Mutation: mu})
+		if err != nil {
 			return err
 		}
 		glog.Infof("Successfully upserted the groot account")
@@ -410,7 +465,36 @@ func extractUserAndGroups(ctx context.Context) ([]string, error) {
 		return nil, errNoJwt
 	}
 
-	return validateToken(accessJwt[0])
+	return validateAccessToken(accessJwt[0])
+}
+
+// userOf returns the user of the ACL JWT of the request, or anonymous if it has none. It returns
+// an empty string if ACLs are not enabled or the JWT is invalid.
+func userOf(ctx context.Context) string {
+	if len(Config.HmacSecret) == 0 {
+		return ""
+	}
+	userData, err := extractUserAndGroups(ctx)
+	switch {
+	case err == nil:
+		return userData[0]
+	case err == errNoJwt:
+		return "anonymous"
+	}
+	return ""
+}
+
+// requestUser returns the user and groups of the access JWT. It returns false if ACLs are not
+// enabled or the JWT is missing or invalid.
+func requestUser(accessJwt string) (string, []string, bool) {
+	if len(Config.HmacSecret) == 0 || accessJwt == "" {
+		return "", nil, false
+	}
+	userData, err := validateAccessToken(accessJwt)
+	if err != nil {
+		return "", nil, false
+	}
+	return userData[0], userData[1:], true
 }
 
 //authorizeAlter parses the Schema in the operation and authorizes the operation using the aclCache
@@ -420,21 +504,9 @@ func authorizeAlter(ctx context.Context, op *api.Operation) error {
 		return nil
 	}
 
-	// extract the list of predicates from the operation object
-	var preds []string
-	if len(op.DropAttr) > 0 {
-		preds = []string{op.DropAttr}
-	} else if op.DropOp == api.Operation_ATTR && len(op.DropValue) > 0 {
-		preds = []string{op.DropValue}
-	} else {
-		update, err := schema.Parse(op.Schema)
-		if err != nil {
-			return err
-		}
-
-		for _, u := range update.Schemas {
-			preds = append(preds, u.Predicate)
-		}
+	preds, err := alterPreds(op)
+	if err != nil {
+		return err
 	}
 
 	var userId string
@@ -484,7 +556,7 @@ func authorizeAlter(ctx context.Context, op *api.Operation) error {
 		return nil
 	}
 
-	err := doAuthorizeAlter()
+	err = doAuthorizeAlter()
 	span := otrace.FromContext(ctx)
 	if span != nil {
 		span.Annotatef(nil, (&AccessEntry{
@@ -499,6 +571,25 @@ func authorizeAlter(ctx context.Context, op *api.Operation) error {
 	return err
 }
 
+// alterPreds returns the list of predicates altered by the operation.
+func alterPreds(op *api.Operation) ([]string, error) {
+	if len(op.DropAttr) > 0 {
+		return []string{op.DropAttr}, nil
+	}
+	if op.DropOp == api.Operation_ATTR && len(op.DropValue) > 0 {
+		return []string{op.DropValue}, nil
+	}
+	update, err := schema.Parse(op.Schema)
+	if err != nil {
+		return nil, err
+	}
+	var preds []string
+	for _, u := range update.Schemas {
+		preds = append(preds, u.Predicate)
+	}
+	return preds, nil
+}
+
 // parsePredsFromMutation returns a union set of all the predicate names in the input nquads
 func parsePredsFromMutation(nquads []*api.NQuad) []string {
 	// use a map to dedup predicates
@@ -541,19 +632,34 @@ func isAclPredMutation(nquads []*api.NQuad) bool {
 }
 
 // authorizeMutation authorizes the mutation using the aclCache
-func authorizeMutation(ctx context.Context, mu *api.Mutation) error {
+func authorizeMutation(ctx context.Context, mu *pb.Mutation) error {
 	if len(Config.HmacSecret) == 0 {
 		// the user has not turned on the acl feature
 		return nil
 	}
 
 	// parse predicates from the mutation object
-	gmu, err := parseMutationObject(mu)
+	gmu, err := parseMutationObject(mu.Mutation)
 	if err != nil {
 		return err
 	}
 	preds := parsePredsFromMutation(gmu.Set)
 
+	// the query of an upsert block reads predicates, which needs read access to them
+	if len(mu.Query) > 0 {
+		needVars, _, err := upsertNeedVars(mu, gmu)
+		if err != nil {
+			return err
+		}
+		parsedReq, err := gql.ParseWithNeedVars(gql.Request{Str: mu.Query}, needVars)
+		if err != nil {
+			return err
+		}
+		if err := authorizeQueryPreds(ctx, parsePredsFromQuery(parsedReq.Query)); err != nil {
+			return err
+		}
+	}
+
 	var userId string
 	var groupIds []string
 	// doAuthorizeMutation checks if modification of all the predicates are allowed
@@ -667,8 +773,11 @@ func authorizeQuery(ctx context.Context, req *api.Request) error {
 	if err != nil {
 		return err
 	}
-	preds := parsePredsFromQuery(parsedReq.Query)
+	return authorizeQueryPreds(ctx, parsePredsFromQuery(parsedReq.Query))
+}
 
+// authorizeQueryPreds checks that the user is allowed to read all the given predicates
+func authorizeQueryPreds(ctx context.Context, preds []string) error {
 	var userId string
 	var groupIds []string
 	doAuthorizeQuery := func() error {
@@ -706,7 +815,7 @@ func authorizeQuery(ctx context.Context, req *api.Request) error {
 		return nil
 	}
 
-	err = doAuthorizeQuery()
+	err := doAuthorizeQuery()
 	if span := otrace.FromContext(ctx); span != nil {
 		span.Annotatef(nil, (&AccessEntry{
 			userId:    userId,
@@ -719,3 +828,156 @@ func authorizeQuery(ctx context.Context, req *api.Request) error {
 
 	return err
 }
+
+// nodeFilter returns the filter of the nodes the user of the request can access, along with the
+// value of its $user variable. The filter is empty if the user can access all of them.
+func nodeFilter(ctx context.Context) (string, map[string]string, error) {
+	if len(Config.HmacSecret) == 0 {
+		return "", nil, nil
+	}
+	userData, err := extractUserAndGroups(ctx)
+	switch {
+	case err == errNoJwt:
+		// the anonymous user hasn't joined any group, and internal requests have no JWT
+		return "", nil, nil
+	case err != nil:
+		return "", nil, status.Error(codes.Unauthenticated, err.Error())
+	case userData[0] == x.GrootId:
+		return "", nil, nil
+	}
+
+	filter := aclCache.nodeFilter(userData[1:])
+	if len(filter) == 0 || !strings.Contains(filter, "$user") {
+		return filter, nil, nil
+	}
+	userId := userData[0]
+	if auth.IsExternalUser(userId) {
+		// The users of the external authenticators have no node in Dgraph.
+		return "", nil, x.Errorf("The ACL filter of user %q can't refer to $user", userId)
+	}
+	uid, found := aclCache.userUid(userId)
+	if !found {
+		user, err := authorizeUser(context.Background(), userId, "")
+		if err != nil {
+			return "", nil, err
+		}
+		if user == nil {
+			return "", nil, x.Errorf("Unable to find user %q of the ACL filter", userId)
+		}
+		uid = user.Uid
+		aclCache.setUserUid(userId, uid)
+	}
+	return filter, map[string]string{"$user": uid}, nil
+}
+
+// filterQueryNodes restricts the nodes reached by the query to those the user of the request
+// can access.
+func filterQueryNodes(ctx context.Context, gqs []*gql.GraphQuery) error {
+	filter, vars, err := nodeFilter(ctx)
+	if err != nil || len(filter) == 0 {
+		return err
+	}
+	ft, err := acl.ParseFilter(filter, vars)
+	if err != nil {
+		return err
+	}
+	query.AddNodeFilter(gqs, ft)
+	return nil
+}
+
+// authorizeMutationNodes refuses the mutation if it changes existing nodes the user of the
+// request can't access, or links to them. It returns the check to run once the mutation is
+// applied, which refuses it if the nodes it sets predicates of, including the new ones, are no
+// longer accessible.
+func authorizeMutationNodes(ctx context.Context, startTs uint64, edges []*pb.DirectedEdge,
+	newUids map[string]uint64) (func() error, error) {
+	noCheck := func() error { return nil }
+	filter, vars, err := nodeFilter(ctx)
+	if err != nil || len(filter) == 0 {
+		return noCheck, err
+	}
+
+	isNew := make(map[uint64]struct{}, len(newUids))
+	for _, uid := range newUids {
+		isNew[uid] = struct{}{}
+	}
+	var existing, set []uint64
+	for _, edge := range edges {
+		if _, ok := isNew[edge.Entity]; !ok {
+			existing = append(existing, edge.Entity)
+		}
+		if _, ok := isNew[edge.ValueId]; !ok && edge.ValueId != 0 {
+			existing = append(existing, edge.ValueId)
+		}
+		if edge.Op == pb.DirectedEdge_SET {
+			set = append(set, edge.Entity)
+		}
+	}
+
+	checkNodes := func(uids []uint64) error {
+		inaccessible, err := inaccessibleNodes(startTs, uids, filter, vars)
+		if err != nil {
+			return err
+		}
+		if len(inaccessible) > 0 {
+			return status.Errorf(codes.PermissionDenied,
+				"unauthorized to mutate node %#x, which doesn't match the ACL filter",
+				inaccessible[0])
+		}
+		return nil
+	}
+	if err := checkNodes(existing); err != nil {
+		return noCheck, err
+	}
+	return func() error { return checkNodes(set) }, nil
+}
+
+// inaccessibleNodes returns the nodes which don't match the filter at the start ts, seeing the
+// mutations of the transaction.
+func inaccessibleNodes(startTs uint64, uids []uint64, filter string,
+	vars map[string]string) ([]uint64, error) {
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	seen := make(map[uint64]struct{}, len(uids))
+	var hexUids []string
+	for _, uid := range uids {
+		if _, ok := seen[uid]; !ok {
+			seen[uid] = struct{}{}
+			hexUids = append(hexUids, fmt.Sprintf("%#x", uid))
+		}
+	}
+	req := &api.Request{
+		Query: fmt.Sprintf("query q($user: string) { q(func: uid(%s)) @filter(%s) { uid } }",
+			strings.Join(hexUids, ", "), filter),
+		Vars:    vars,
+		StartTs: startTs,
+	}
+	resp, err := (&Server{}).doQuery(context.Background(), req)
+	if err != nil {
+		return nil, x.Wrapf(err, "While checking the nodes against the ACL filter")
+	}
+	var res struct {
+		Nodes []struct {
+			Uid string `json:"uid"`
+		} `json:"q"`
+	}
+	if err := json.Unmarshal(resp.Json, &res); err != nil {
+		return nil, err
+	}
+	accessible := make(map[uint64]struct{}, len(res.Nodes))
+	for _, node := range res.Nodes {
+		uid, err := strconv.ParseUint(node.Uid, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		accessible[uid] = struct{}{}
+	}
+	var inaccessible []uint64
+	for uid := range seen {
+		if _, ok := accessible[uid]; !ok {
+			inaccessible = append(inaccessible, uid)
+		}
+	}
+	return inaccessible, nil
+}
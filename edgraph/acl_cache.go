@@ -16,6 +16,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/dgraph-io/dgraph/ee/acl"
@@ -33,6 +35,10 @@ type AclCache struct {
 	sync.RWMutex
 	predPerms      map[string]map[string]int32
 	predRegexRules []*PredRegexRule
+	// groupFilters maps a group to the filter of the nodes its members can access
+	groupFilters map[string]string
+	// userUids caches the uids of the users, the values of $user in the filters
+	userUids map[string]string
 }
 
 var aclCache *AclCache = &AclCache{
@@ -63,6 +69,7 @@ func (cache *AclCache) update(groups []acl.Group) {
 	// predRegexPerms is a map from a regex string to a PredRegexRule, and a PredRegexRule
 	// contains a map from a group to a permission
 	predRegexPerms := make(map[string]*PredRegexRule)
+	groupFilters := make(map[string]string)
 	for _, group := range groups {
 		aclBytes := []byte(group.Acls)
 		var acls []acl.Acl
@@ -98,6 +105,8 @@ func (cache *AclCache) update(groups []acl.Group) {
 						groupPerms: groupPermsMap,
 					}
 				}
+			} else if len(acl.Filter) > 0 {
+				groupFilters[group.GroupID] = acl.Filter
 			}
 		}
 	}
@@ -112,6 +121,49 @@ func (cache *AclCache) update(groups []acl.Group) {
 	defer aclCache.Unlock()
 	aclCache.predPerms = predPerms
 	aclCache.predRegexRules = predRegexRules
+	aclCache.groupFilters = groupFilters
+	aclCache.userUids = make(map[string]string)
+}
+
+// nodeFilter returns the filter of the nodes the members of the groups can access, or an empty
+// string if they can access all of them. As soon as one of the groups has a filter, only the
+// nodes matching the filter of one of the groups with a filter are accessible, so that adding a
+// user to a group granting predicate permissions doesn't lift the restriction.
+func (cache *AclCache) nodeFilter(groups []string) string {
+	aclCache.RLock()
+	groupFilters := aclCache.groupFilters
+	aclCache.RUnlock()
+
+	var filters []string
+	for _, group := range groups {
+		if filter, found := groupFilters[group]; found {
+			filters = append(filters, filter)
+		}
+	}
+	switch len(filters) {
+	case 0:
+		return ""
+	case 1:
+		return filters[0]
+	}
+	sort.Strings(filters)
+	return "(" + strings.Join(filters, ") or (") + ")"
+}
+
+func (cache *AclCache) userUid(userId string) (string, bool) {
+	aclCache.RLock()
+	defer aclCache.RUnlock()
+	uid, found := aclCache.userUids[userId]
+	return uid, found
+}
+
+func (cache *AclCache) setUserUid(userId, uid string) {
+	aclCache.Lock()
+	defer aclCache.Unlock()
+	if aclCache.userUids == nil {
+		aclCache.userUids = make(map[string]string)
+	}
+	aclCache.userUids[userId] = uid
 }
 
 func (cache *AclCache) authorizePredicate(groups []string, predicate string,
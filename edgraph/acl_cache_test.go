@@ -78,3 +78,39 @@ func TestAclCache(t *testing.T) {
 	require.NoError(t, aclCache.authorizePredicate([]string{group}, predicate, acl.Read),
 		"the user with group authorized should have access")
 }
+
+func TestAclCacheNodeFilter(t *testing.T) {
+	aclCache = &AclCache{
+		predPerms:      make(map[string]map[string]int32),
+		predRegexRules: make([]*PredRegexRule, 0),
+	}
+	require.Empty(t, aclCache.nodeFilter([]string{"acme"}),
+		"the user should access all the nodes when the acl cache is empty")
+
+	newGroup := func(groupId string, acls []acl.Acl) acl.Group {
+		aclBytes, _ := json.Marshal(acls)
+		return acl.Group{GroupID: groupId, Acls: string(aclBytes)}
+	}
+	aclCache.update([]acl.Group{
+		newGroup("acme", []acl.Acl{{Predicate: "name", Perm: 4}, {Filter: `eq(tenant, "acme")`}}),
+		newGroup("owners", []acl.Acl{{Filter: `uid_in(owner, $user)`}}),
+		newGroup("dev", []acl.Acl{{Predicate: "name", Perm: 7}}),
+	})
+	require.Empty(t, aclCache.nodeFilter([]string{"dev"}),
+		"the user of groups without filter should access all the nodes")
+	require.Equal(t, `eq(tenant, "acme")`, aclCache.nodeFilter([]string{"acme", "dev"}),
+		"the group without filter should not lift the filter of the other group")
+	require.Equal(t, `(eq(tenant, "acme")) or (uid_in(owner, $user))`,
+		aclCache.nodeFilter([]string{"owners", "acme"}),
+		"the user should access the nodes matching the filter of any of the groups")
+
+	aclCache.setUserUid("alice", "0x2")
+	uid, found := aclCache.userUid("alice")
+	require.True(t, found)
+	require.Equal(t, "0x2", uid)
+	// the uids of the users are refreshed along with the acls
+	aclCache.update([]acl.Group{})
+	_, found = aclCache.userUid("alice")
+	require.False(t, found)
+	require.Empty(t, aclCache.nodeFilter([]string{"acme"}))
+}
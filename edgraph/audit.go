@@ -0,0 +1,60 @@
+// +build oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// openAuditLog is not supported in the oss version.
+func openAuditLog(path string, maxSize int64) error {
+	return x.ErrNotSupported
+}
+
+func closeAuditLog() error {
+	return nil
+}
+
+func auditAlter(ctx context.Context, op *api.Operation, decision string, err error) {
+	// do nothing
+}
+
+func auditMutation(ctx context.Context, mu *pb.Mutation, resp *api.Assigned, decision string,
+	err error) {
+	// do nothing
+}
+
+func auditQuery(ctx context.Context, req *api.Request, resp *api.Response, decision string,
+	err error) {
+	// do nothing
+}
+
+// AuditCommit does nothing in the oss version.
+func AuditCommit(ctx context.Context, tc *api.TxnContext, commitTs uint64, err error) {
+	// do nothing
+}
+
+// AuditAdmin does nothing in the oss version.
+func AuditAdmin(endpoint, ip, accessJwt string, allowed bool) {
+	// do nothing
+}
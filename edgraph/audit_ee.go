@@ -0,0 +1,194 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/peer"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// openAuditLog starts writing the audit log to path, rotating it once it would grow over
+// maxSize bytes.
+func openAuditLog(path string, maxSize int64) error {
+	w, err := newAuditWriter(path, maxSize)
+	if err != nil {
+		return err
+	}
+	glog.Infof("Writing the audit log to %q", path)
+	auditLog = w
+	return nil
+}
+
+func closeAuditLog() error {
+	return auditLog.close()
+}
+
+// newAuditEntry returns the entry of a request, with the user and groups of its ACL JWT and its
+// client IP.
+func newAuditEntry(ctx context.Context, operation, decision string, err error) *AuditEntry {
+	e := &AuditEntry{Operation: operation, Decision: decision}
+	if len(Config.HmacSecret) > 0 {
+		userData, err := extractUserAndGroups(ctx)
+		switch {
+		case err == nil:
+			e.User, e.Groups = userData[0], userData[1:]
+		case err == errNoJwt:
+			e.User = "anonymous"
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		e.ClientIP = hostOf(p.Addr.String())
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
+
+func hostOf(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+func sortedPreds(preds []string) []string {
+	set := make(map[string]struct{}, len(preds))
+	var sorted []string
+	for _, pred := range preds {
+		if _, ok := set[pred]; !ok {
+			set[pred] = struct{}{}
+			sorted = append(sorted, pred)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func auditLogin(ctx context.Context, userId string, groups []string, err error) {
+	if auditLog == nil {
+		return
+	}
+	decision := auditAllowed
+	if err != nil {
+		decision = auditDenied
+	}
+	e := newAuditEntry(ctx, "login", decision, err)
+	e.User, e.Groups = userId, groups
+	auditLog.log(e)
+}
+
+func auditAlter(ctx context.Context, op *api.Operation, decision string, err error) {
+	if auditLog == nil {
+		return
+	}
+	e := newAuditEntry(ctx, "alter", decision, err)
+	if preds, perr := alterPreds(op); perr == nil {
+		e.Predicates = sortedPreds(preds)
+	}
+	auditLog.log(e)
+}
+
+func auditMutation(ctx context.Context, mu *pb.Mutation, resp *api.Assigned, decision string,
+	err error) {
+	if auditLog == nil {
+		return
+	}
+	e := newAuditEntry(ctx, "mutate", decision, err)
+	e.StartTs = mu.Mutation.StartTs
+	if resp != nil && resp.Context != nil {
+		e.CommitTs = resp.Context.CommitTs
+	}
+
+	// The predicates of the deletions and of the upsert query are touched too.
+	if gmu, perr := parseMutationObject(mu.Mutation); perr == nil {
+		preds := parsePredsFromMutation(gmu.Set)
+		preds = append(preds, parsePredsFromMutation(gmu.Del)...)
+		if len(mu.Query) > 0 {
+			if needVars, _, perr := upsertNeedVars(mu, gmu); perr == nil {
+				req, perr := gql.ParseWithNeedVars(gql.Request{Str: mu.Query}, needVars)
+				if perr == nil {
+					preds = append(preds, parsePredsFromQuery(req.Query)...)
+				}
+			}
+		}
+		e.Predicates = sortedPreds(preds)
+	}
+	auditLog.log(e)
+}
+
+func auditQuery(ctx context.Context, req *api.Request, resp *api.Response, decision string,
+	err error) {
+	if auditLog == nil {
+		return
+	}
+	e := newAuditEntry(ctx, "query", decision, err)
+	e.StartTs = req.StartTs
+	if resp != nil && resp.Txn != nil {
+		e.StartTs = resp.Txn.StartTs
+	}
+	parsed, perr := gql.Parse(gql.Request{Str: req.Query, Variables: req.Vars})
+	if perr == nil {
+		e.Predicates = sortedPreds(parsePredsFromQuery(parsed.Query))
+	}
+	auditLog.log(e)
+}
+
+// AuditCommit writes the commit or abort of the transaction to the audit log. Its mutations are
+// audited with the same start ts.
+func AuditCommit(ctx context.Context, tc *api.TxnContext, commitTs uint64, err error) {
+	if auditLog == nil {
+		return
+	}
+	operation := "commit"
+	if tc.Aborted {
+		operation = "abort"
+	}
+	e := newAuditEntry(ctx, operation, "", err)
+	e.StartTs, e.CommitTs = tc.StartTs, commitTs
+	// The predicates of the txn context are prefixed with the group serving them.
+	var preds []string
+	for _, pred := range tc.Preds {
+		if parts := strings.SplitN(pred, "-", 2); len(parts) == 2 {
+			pred = parts[1]
+		}
+		preds = append(preds, pred)
+	}
+	e.Predicates = sortedPreds(preds)
+	auditLog.log(e)
+}
+
+// AuditAdmin writes the request to the admin endpoint to the audit log. The user and groups are
+// those of the access JWT, if any.
+func AuditAdmin(endpoint, ip, accessJwt string, allowed bool) {
+	if auditLog == nil {
+		return
+	}
+	e := &AuditEntry{Operation: "admin", Endpoint: endpoint, ClientIP: ip, Decision: auditDenied}
+	if allowed {
+		e.Decision = auditAllowed
+	}
+	if user, groups, ok := requestUser(accessJwt); ok {
+		e.User, e.Groups = user, groups
+	}
+	auditLog.log(e)
+}
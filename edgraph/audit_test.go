@@ -0,0 +1,141 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+func readAuditLog(t *testing.T, path string) []AuditEntry {
+	fd, err := os.Open(path)
+	require.NoError(t, err)
+	defer fd.Close()
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		var e AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestAuditLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.json")
+
+	require.NoError(t, openAuditLog(path, 1<<20))
+	defer func() { auditLog = nil }()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+	})
+	req := &api.Request{Query: `{ q(func: eq(name, "alice")) { age friend { name } } }`}
+	auditQuery(ctx, req, &api.Response{Txn: &api.TxnContext{StartTs: 10}}, auditAllowed, nil)
+	mu := &api.Mutation{
+		StartTs:   11,
+		SetNquads: []byte(`<0x1> <name> "bob" .`),
+		DelNquads: []byte(`<0x1> <age> * .`),
+	}
+	auditMutation(ctx, &pb.Mutation{Mutation: mu}, nil, auditDenied, errors.New("unauthorized"))
+	// The variable n of the upsert query is only used by the condition.
+	upsert := &pb.Mutation{
+		Mutation: &api.Mutation{
+			StartTs:   13,
+			SetNquads: []byte(`uid(u) <name> "bob" .`),
+		},
+		Cond:  `@if(eq(len(n), 0))`,
+		Query: `{ u as var(func: eq(email, "a")) n as var(func: eq(nick, "b")) }`,
+	}
+	auditMutation(ctx, upsert, nil, auditAllowed, nil)
+	AuditCommit(ctx, &api.TxnContext{StartTs: 11, Preds: []string{"1-name", "2-age"}}, 12, nil)
+	auditAlter(ctx, &api.Operation{DropAttr: "age"}, "", errors.New("no auth token"))
+	AuditAdmin("/admin/export", "10.0.0.2", "", true)
+	// The entries are written in the background, closing the log waits for them.
+	require.NoError(t, closeAuditLog())
+
+	entries := readAuditLog(t, path)
+	require.Equal(t, 6, len(entries))
+
+	require.Equal(t, "query", entries[0].Operation)
+	require.Equal(t, "10.0.0.1", entries[0].ClientIP)
+	require.Equal(t, []string{"age", "friend", "name"}, entries[0].Predicates)
+	require.Equal(t, auditAllowed, entries[0].Decision)
+	require.Equal(t, uint64(10), entries[0].StartTs)
+
+	require.Equal(t, "mutate", entries[1].Operation)
+	require.Equal(t, []string{"age", "name"}, entries[1].Predicates)
+	require.Equal(t, auditDenied, entries[1].Decision)
+	require.Equal(t, "unauthorized", entries[1].Error)
+
+	require.Equal(t, "mutate", entries[2].Operation)
+	require.Equal(t, []string{"email", "name", "nick"}, entries[2].Predicates)
+
+	entries = append(entries[:2], entries[3:]...)
+	require.Equal(t, "commit", entries[2].Operation)
+	require.Equal(t, []string{"age", "name"}, entries[2].Predicates)
+	require.Equal(t, uint64(11), entries[2].StartTs)
+	require.Equal(t, uint64(12), entries[2].CommitTs)
+
+	require.Equal(t, "alter", entries[3].Operation)
+	require.Equal(t, []string{"age"}, entries[3].Predicates)
+	require.Empty(t, entries[3].Decision)
+
+	require.Equal(t, "admin", entries[4].Operation)
+	require.Equal(t, "/admin/export", entries[4].Endpoint)
+	require.Equal(t, "10.0.0.2", entries[4].ClientIP)
+}
+
+func TestAuditLogRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.json")
+
+	w, err := newAuditWriter(path, 200)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		w.log(&AuditEntry{Operation: "admin", Endpoint: "/admin/shutdown"})
+	}
+	require.NoError(t, w.close())
+
+	files, err := filepath.Glob(path + "*")
+	require.NoError(t, err)
+	sort.Strings(files)
+	require.True(t, len(files) > 1)
+	var total int
+	for _, file := range files {
+		fi, err := os.Stat(file)
+		require.NoError(t, err)
+		require.True(t, fi.Size() <= 200)
+		total += len(readAuditLog(t, file))
+	}
+	require.Equal(t, 10, total)
+}
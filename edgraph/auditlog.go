@@ -0,0 +1,224 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dgraph-io/badger/y"
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The decisions of the authorization of the audited requests.
+const (
+	auditAllowed = "allow"
+	auditDenied  = "deny"
+)
+
+// The suffix of a rotated audit log is the UTC time of its rotation in this format.
+const auditRotationFormat = "2006-01-02T15-04-05.000"
+
+const (
+	// The number of entries waiting to be written before the requests block on the audit log.
+	auditQueueSize = 10000
+	// The audit log is flushed and synced to disk this often.
+	auditSyncInterval = time.Second
+)
+
+// AuditEntry is a request written to the audit log.
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+	// Operation is login, alter, mutate, query, commit, abort or admin.
+	Operation string `json:"operation"`
+	// Endpoint is the path of the admin endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+	// User and Groups are from the ACL JWT of the request, or the user logging in. User is
+	// anonymous if ACLs are enabled and the request has no JWT.
+	User     string   `json:"user,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	ClientIP string   `json:"client_ip,omitempty"`
+	// Predicates are the predicates altered, written by a mutation or read by a query.
+	Predicates []string `json:"predicates,omitempty"`
+	// Decision is allow or deny. It's empty if the request failed before being authorized, or
+	// isn't subject to authorization.
+	Decision string `json:"decision,omitempty"`
+	StartTs  uint64 `json:"start_ts,omitempty"`
+	CommitTs uint64 `json:"commit_ts,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// auditWriter appends the entries to the audit log as JSON lines. Once the log would grow over
+// maxSize, it's renamed with the time of the rotation as suffix and a new log is started. The
+// rotated logs are never written to again, nor deleted.
+//
+// The entries are queued, so that the requests don't wait on the disk, and written by a single
+// goroutine. It flushes and syncs the log every auditSyncInterval, so the entries logged in the
+// last interval are lost if the server crashes.
+type auditWriter struct {
+	path    string
+	maxSize int64
+	fd      *os.File
+	bw      *bufio.Writer
+	size    int64
+
+	entries chan []byte
+	closer  *y.Closer
+}
+
+var auditLog *auditWriter
+
+func newAuditWriter(path string, maxSize int64) (*auditWriter, error) {
+	if maxSize <= 0 {
+		return nil, x.Errorf("Invalid audit log size %d", maxSize)
+	}
+	w := &auditWriter{
+		path:    path,
+		maxSize: maxSize,
+		entries: make(chan []byte, auditQueueSize),
+		closer:  y.NewCloser(1),
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	go w.run()
+	return w, nil
+}
+
+func (w *auditWriter) open() error {
+	fd, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return x.Wrapf(err, "While opening audit log %q", w.path)
+	}
+	fi, err := fd.Stat()
+	if err != nil {
+		fd.Close()
+		return x.Wrapf(err, "While opening audit log %q", w.path)
+	}
+	w.fd, w.bw, w.size = fd, bufio.NewWriter(fd), fi.Size()
+	return nil
+}
+
+// sync flushes the buffered entries and syncs them to disk.
+func (w *auditWriter) sync() error {
+	if err := w.bw.Flush(); err != nil {
+		return x.Wrapf(err, "While writing to audit log %q", w.path)
+	}
+	if err := w.fd.Sync(); err != nil {
+		return x.Wrapf(err, "While syncing audit log %q", w.path)
+	}
+	return nil
+}
+
+func (w *auditWriter) rotate(now time.Time) error {
+	if err := w.sync(); err != nil {
+		return err
+	}
+	// Never replace a rotated log, even if two rotations happen within a millisecond.
+	rotated := w.path + "." + now.Format(auditRotationFormat)
+	for i := 1; ; i++ {
+		if _, err := os.Stat(rotated); os.IsNotExist(err) {
+			break
+		}
+		rotated = fmt.Sprintf("%s.%s.%d", w.path, now.Format(auditRotationFormat), i)
+	}
+	if err := os.Rename(w.path, rotated); err != nil {
+		return x.Wrapf(err, "While rotating audit log %q", w.path)
+	}
+	if err := w.fd.Close(); err != nil {
+		glog.Warningf("While closing rotated audit log: %v", err)
+	}
+	return w.open()
+}
+
+// write appends the encoded entry to the log, rotating it first if needed.
+func (w *auditWriter) write(b []byte) {
+	if w.size > 0 && w.size+int64(len(b)) > w.maxSize {
+		if err := w.rotate(time.Now().UTC()); err != nil {
+			glog.Errorf("%v", err)
+		}
+	}
+	n, err := w.bw.Write(b)
+	w.size += int64(n)
+	if err != nil {
+		glog.Errorf("While writing to the audit log: %v", err)
+	}
+}
+
+// run writes the queued entries until the writer is closed, and then the ones left.
+func (w *auditWriter) run() {
+	defer w.closer.Done()
+	ticker := time.NewTicker(auditSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case b := <-w.entries:
+			w.write(b)
+		case <-ticker.C:
+			if err := w.sync(); err != nil {
+				glog.Errorf("%v", err)
+			}
+		case <-w.closer.HasBeenClosed():
+			for {
+				select {
+				case b := <-w.entries:
+					w.write(b)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// log queues e to be written to the log.
+func (w *auditWriter) log(e *AuditEntry) {
+	if w == nil {
+		return
+	}
+	e.Time = time.Now().UTC()
+	b, err := json.Marshal(e)
+	if err != nil {
+		glog.Errorf("While encoding audit entry: %v", err)
+		return
+	}
+	b = append(b, '\n')
+
+	select {
+	case w.entries <- b:
+	case <-w.closer.HasBeenClosed():
+		glog.Warningf("Audit log closed, dropping entry: %s", b)
+	}
+}
+
+// close writes the queued entries, and syncs and closes the log.
+func (w *auditWriter) close() error {
+	if w == nil {
+		return nil
+	}
+	w.closer.SignalAndWait()
+	if err := w.sync(); err != nil {
+		w.fd.Close()
+		return err
+	}
+	return w.fd.Close()
+}
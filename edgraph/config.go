@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/dgraph-io/dgraph/ee/auth"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/x"
 )
@@ -39,11 +40,26 @@ type Options struct {
 	MutationsMode  int
 	AuthToken      string
 	AllottedMemory float64
+	// QueryTimeout is the longest a query can run for. The requests can only set a shorter
+	// timeout. Zero means no timeout.
+	QueryTimeout time.Duration
+	// SlowQueryLog is the file the queries and mutations slower than SlowQueryThreshold are
+	// logged to. Disabled if empty.
+	SlowQueryLog       string
+	SlowQueryThreshold time.Duration
+	// AuditLog is the file the audit log is written to. Disabled if empty.
+	AuditLog string
+	// AuditLogSize is the size in bytes of the audit log beyond which it's rotated.
+	AuditLogSize int64
+	// RateLimits are the limits of the requests to this server. Disabled if nil.
+	RateLimits *RateLimits
 
 	HmacSecret         []byte
 	AccessJwtTtl       time.Duration
 	RefreshJwtTtl      time.Duration
 	AclRefreshInterval time.Duration
+	// Authenticator authenticates the users who aren't stored in Dgraph. Disabled if nil.
+	Authenticator *auth.Authenticator
 }
 
 var Config Options
@@ -86,6 +102,7 @@ func setConfVar(conf Options) {
 	x.Conf.Set("posting_dir", newStr(conf.PostingDir))
 	x.Conf.Set("wal_dir", newStr(conf.WALDir))
 	x.Conf.Set("allotted_memory", newFloat(conf.AllottedMemory))
+	x.Conf.Set("query_timeout", newStr(conf.QueryTimeout.String()))
 
 	// Set some vars from worker.Config.
 	x.Conf.Set("tracing", newFloat(x.WorkerConfig.Tracing))
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// RunningQuery is a query being processed by this server.
+type RunningQuery struct {
+	Id    uint64    `json:"id"`
+	Start time.Time `json:"start"`
+	Query string    `json:"query"`
+
+	cancel context.CancelFunc
+}
+
+type queryTracker struct {
+	sync.Mutex
+	lastId  uint64
+	running map[uint64]*RunningQuery
+}
+
+var queries = queryTracker{running: make(map[uint64]*RunningQuery)}
+
+// track registers the query q until the returned function is called. The returned context is
+// cancelled after timeout, if it's not zero, or when the query is cancelled with CancelQuery.
+func (t *queryTracker) track(ctx context.Context, q string,
+	timeout time.Duration) (context.Context, func()) {
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	t.lastId++
+	id := t.lastId
+	t.running[id] = &RunningQuery{Id: id, Start: time.Now(), Query: q, cancel: cancel}
+	return ctx, func() {
+		t.Lock()
+		delete(t.running, id)
+		t.Unlock()
+		cancel()
+	}
+}
+
+// RunningQueries returns the queries being processed by this server, oldest first.
+func RunningQueries() []RunningQuery {
+	queries.Lock()
+	defer queries.Unlock()
+	res := make([]RunningQuery, 0, len(queries.running))
+	for _, q := range queries.running {
+		res = append(res, RunningQuery{Id: q.Id, Start: q.Start, Query: q.Query})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Id < res[j].Id })
+	return res
+}
+
+// CancelQuery cancels the running query with the given id.
+func CancelQuery(id uint64) error {
+	queries.Lock()
+	defer queries.Unlock()
+	q, ok := queries.running[id]
+	if !ok {
+		return x.Errorf("No running query with id %d", id)
+	}
+	q.cancel()
+	return nil
+}
+
+// queryTimeout returns the timeout of the request, capped by the one set on the server, or the
+// latter if the request has none.
+func queryTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return Config.QueryTimeout, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, x.Wrapf(err, "Invalid timeout %q", timeout)
+	}
+	if d <= 0 {
+		return 0, x.Errorf("Timeout must be positive, got %q", timeout)
+	}
+	if Config.QueryTimeout > 0 && Config.QueryTimeout < d {
+		return Config.QueryTimeout, nil
+	}
+	return d, nil
+}
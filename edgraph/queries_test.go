@@ -0,0 +1,66 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestQueryTracker(t *testing.T) {
+	ctx1, done1 := queries.track(context.Background(), "{ q1 }", 0)
+	ctx2, done2 := queries.track(context.Background(), "{ q2 }", time.Millisecond)
+	defer done2()
+
+	running := RunningQueries()
+	require.Equal(t, 2, len(running))
+	require.Equal(t, "{ q1 }", running[0].Query)
+	require.Equal(t, "{ q2 }", running[1].Query)
+
+	<-ctx2.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx2.Err())
+
+	require.NoError(t, ctx1.Err())
+	require.NoError(t, CancelQuery(running[0].Id))
+	require.Equal(t, context.Canceled, ctx1.Err())
+	done1()
+	require.Error(t, CancelQuery(running[0].Id))
+	require.Equal(t, 1, len(RunningQueries()))
+}
+
+func TestQueryTimeout(t *testing.T) {
+	Config.QueryTimeout = time.Minute
+	defer func() { Config.QueryTimeout = 0 }()
+
+	d, err := queryTimeout("")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, d)
+	d, err = queryTimeout("30s")
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, d)
+	// The requests can't run for longer than the server allows.
+	d, err = queryTimeout("2m")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, d)
+	_, err = queryTimeout("-1s")
+	require.Error(t, err)
+	_, err = queryTimeout("soon")
+	require.Error(t, err)
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Limit caps the requests charged to a user, a group or an IP address.
+type Limit struct {
+	// QPS is the number of requests allowed per second, on average. Zero means no limit.
+	QPS float64 `json:"qps"`
+	// Burst is the number of requests allowed at once, above QPS. It defaults to QPS.
+	Burst int `json:"burst"`
+	// Concurrent is the number of requests allowed to run at the same time. Zero means no limit.
+	Concurrent int `json:"concurrent"`
+}
+
+// RateLimits are the limits of the requests to this server. With ACLs, every user gets the
+// Default limit unless it has its own, and the requests of the members of a group are also
+// charged to the limit of the group, shared by all of them. Without ACLs, or for requests
+// without an access JWT, every IP address gets the Default limit.
+type RateLimits struct {
+	Default Limit            `json:"default"`
+	Users   map[string]Limit `json:"users"`
+	Groups  map[string]Limit `json:"groups"`
+}
+
+// ReadRateLimits reads the JSON encoded RateLimits in the file at path.
+func ReadRateLimits(path string) (*RateLimits, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, x.Wrapf(err, "While reading rate limits %q", path)
+	}
+	var rl RateLimits
+	if err := json.Unmarshal(b, &rl); err != nil {
+		return nil, x.Wrapf(err, "While parsing rate limits %q", path)
+	}
+	return &rl, nil
+}
+
+// RateLimitError is returned for the requests over a limit.
+type RateLimitError struct {
+	Key string
+	// RetryAfter is how long to wait before retrying the request.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("Too many requests for %s. Retry after %s.", e.Key, e.RetryAfter)
+}
+
+// Beyond this many limiters, the least recently used idle ones are dropped. They would start over
+// just the same. The limiters of requests in flight are kept, so that they're released.
+const maxLimiters = 10000
+
+// limiter is a token bucket, with a cap on the number of active requests.
+type limiter struct {
+	key    string
+	limit  Limit
+	tokens float64
+	last   time.Time
+	active int
+}
+
+func newLimiter(key string, limit Limit, now time.Time) *limiter {
+	if limit.Burst <= 0 {
+		limit.Burst = int(math.Max(1, math.Ceil(limit.QPS)))
+	}
+	return &limiter{key: key, limit: limit, tokens: float64(limit.Burst), last: now}
+}
+
+// acquire takes a token and a slot for a request. It returns how long to wait before retrying if
+// there are none.
+func (l *limiter) acquire(now time.Time) (time.Duration, bool) {
+	if l.limit.QPS > 0 {
+		l.tokens = math.Min(float64(l.limit.Burst), l.tokens+now.Sub(l.last).Seconds()*l.limit.QPS)
+		l.last = now
+		if l.tokens < 1 {
+			return time.Duration((1 - l.tokens) / l.limit.QPS * float64(time.Second)), false
+		}
+	}
+	if l.limit.Concurrent > 0 && l.active >= l.limit.Concurrent {
+		// There's no telling when a slot frees up.
+		return time.Second, false
+	}
+	if l.limit.QPS > 0 {
+		l.tokens--
+	}
+	l.active++
+	return 0, true
+}
+
+type rateLimiter struct {
+	sync.Mutex
+	limits *RateLimits
+	// limiters maps the keys to their limiter in lru, whose front is the most recently used.
+	limiters map[string]*list.Element
+	lru      *list.List
+}
+
+var rateLimits rateLimiter
+
+// limitFor returns the limit of the key, and whether it has one.
+func (r *rateLimiter) limitFor(kind, name string) (Limit, bool) {
+	switch kind {
+	case "user":
+		if limit, ok := r.limits.Users[name]; ok {
+			return limit, true
+		}
+		return r.limits.Default, true
+	case "group":
+		limit, ok := r.limits.Groups[name]
+		return limit, ok
+	}
+	return r.limits.Default, true
+}
+
+// LimitRequest charges a request to the rate limits of its user and groups, found in its access
+// JWT, or of its IP address. It returns a function to call once the request is done, or a
+// RateLimitError if the request is over a limit.
+func LimitRequest(accessJwt, ip string) (func(), error) {
+	if Config.RateLimits == nil {
+		return func() {}, nil
+	}
+	keys := [][2]string{{"ip", ip}}
+	if user, groups, ok := requestUser(accessJwt); ok {
+		if user == x.GrootId {
+			return func() {}, nil
+		}
+		keys = [][2]string{{"user", user}}
+		for _, group := range groups {
+			keys = append(keys, [2]string{"group", group})
+		}
+	}
+	return rateLimits.acquire(keys, time.Now())
+}
+
+// limiter returns the limiter of the key, and makes it the most recently used one.
+func (r *rateLimiter) limiter(key string, limit Limit, now time.Time) *limiter {
+	if e, ok := r.limiters[key]; ok {
+		r.lru.MoveToFront(e)
+		return e.Value.(*limiter)
+	}
+	l := newLimiter(key, limit, now)
+	r.limiters[key] = r.lru.PushFront(l)
+	if r.lru.Len() > maxLimiters {
+		for e := r.lru.Back(); e != nil; e = e.Prev() {
+			if old := e.Value.(*limiter); old.active == 0 && old != l {
+				r.lru.Remove(e)
+				delete(r.limiters, old.key)
+				break
+			}
+		}
+	}
+	return l
+}
+
+func (r *rateLimiter) acquire(keys [][2]string, now time.Time) (func(), error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.limits != Config.RateLimits {
+		r.limits = Config.RateLimits
+		r.limiters = make(map[string]*list.Element)
+		r.lru = list.New()
+	}
+
+	var acquired []*limiter
+	var acquiredKeys []string
+	release := func() {
+		r.Lock()
+		defer r.Unlock()
+		for i, l := range acquired {
+			l.active--
+			recordActive(acquiredKeys[i], l.active)
+		}
+	}
+	for _, kv := range keys {
+		limit, ok := r.limitFor(kv[0], kv[1])
+		if !ok {
+			continue
+		}
+		key := kv[0] + ":" + kv[1]
+		l := r.limiter(key, limit, now)
+		if wait, ok := l.acquire(now); !ok {
+			// Give back what was taken from the other limits.
+			for _, l := range acquired {
+				l.active--
+				if l.limit.QPS > 0 {
+					l.tokens++
+				}
+			}
+			recordLimited(key)
+			return nil, &RateLimitError{Key: key, RetryAfter: wait}
+		}
+		acquired = append(acquired, l)
+		acquiredKeys = append(acquiredKeys, key)
+		recordActive(key, l.active)
+	}
+	return release, nil
+}
+
+// metricsKey returns the tag of the limit key in the metrics. IP addresses are not tagged one
+// by one, to keep the number of metrics bounded.
+func metricsKey(key string) string {
+	if len(key) > 3 && key[:3] == "ip:" {
+		return "ip"
+	}
+	return key
+}
+
+func recordLimited(key string) {
+	ctx, _ := tag.New(x.MetricsContext(), tag.Upsert(x.KeyLimit, metricsKey(key)))
+	stats.Record(ctx, x.RateLimitedRequests.M(1))
+}
+
+func recordActive(key string, active int) {
+	if metricsKey(key) == "ip" {
+		return
+	}
+	ctx, _ := tag.New(x.MetricsContext(), tag.Upsert(x.KeyLimit, key))
+	stats.Record(ctx, x.RateLimitActiveRequests.M(int64(active)))
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	Config.RateLimits = &RateLimits{
+		Default: Limit{QPS: 2},
+		Users:   map[string]Limit{"alice": {QPS: 10, Concurrent: 1}},
+		Groups:  map[string]Limit{"dev": {QPS: 1, Burst: 2}},
+	}
+	defer func() { Config.RateLimits = nil }()
+	r := &rateLimiter{}
+	now := time.Now()
+	ip := [][2]string{{"ip", "10.0.0.1"}}
+
+	for i := 0; i < 2; i++ {
+		release, err := r.acquire(ip, now)
+		require.NoError(t, err)
+		release()
+	}
+	_, err := r.acquire(ip, now)
+	require.Equal(t, &RateLimitError{Key: "ip:10.0.0.1", RetryAfter: 500 * time.Millisecond}, err)
+	// Other addresses have their own limit.
+	_, err = r.acquire([][2]string{{"ip", "10.0.0.2"}}, now)
+	require.NoError(t, err)
+	now = now.Add(500 * time.Millisecond)
+	_, err = r.acquire(ip, now)
+	require.NoError(t, err)
+
+	// Alice can only run one request at a time.
+	alice := [][2]string{{"user", "alice"}, {"group", "dev"}}
+	release, err := r.acquire(alice, now)
+	require.NoError(t, err)
+	_, err = r.acquire(alice, now)
+	require.Equal(t, &RateLimitError{Key: "user:alice", RetryAfter: time.Second}, err)
+	release()
+
+	// The dev group is shared with bob. The token of bob taken by his rejected request is given
+	// back.
+	bob := [][2]string{{"user", "bob"}, {"group", "dev"}}
+	release, err = r.acquire(bob, now)
+	require.NoError(t, err)
+	release()
+	_, err = r.acquire(bob, now)
+	require.Equal(t, "group:dev", err.(*RateLimitError).Key)
+	bobLimiter := r.limiters["user:bob"].Value.(*limiter)
+	require.Equal(t, 1.0, bobLimiter.tokens)
+	require.Equal(t, 0, bobLimiter.active)
+
+	// Groups without a limit are not charged.
+	_, err = r.acquire([][2]string{{"user", "carol"}, {"group", "ops"}}, now)
+	require.NoError(t, err)
+	require.NotContains(t, r.limiters, "group:ops")
+}
+
+func TestRateLimiterEviction(t *testing.T) {
+	Config.RateLimits = &RateLimits{Default: Limit{QPS: 1}}
+	defer func() { Config.RateLimits = nil }()
+	r := &rateLimiter{}
+	now := time.Now()
+	ip := func(i int) [][2]string {
+		return [][2]string{{"ip", fmt.Sprintf("10.0.%d.%d", i/256, i%256)}}
+	}
+	acquire := func(i int) {
+		release, err := r.acquire(ip(i), now)
+		require.NoError(t, err)
+		release()
+	}
+
+	acquire(0)
+	// The request of the second address is in flight.
+	inFlight, err := r.acquire(ip(1), now)
+	require.NoError(t, err)
+	for i := 2; i <= maxLimiters+1; i++ {
+		// The first address stays the most recently used one.
+		_, err := r.acquire(ip(0), now)
+		require.Error(t, err)
+		acquire(i)
+	}
+	require.Equal(t, maxLimiters, len(r.limiters))
+	require.Equal(t, maxLimiters, r.lru.Len())
+	require.Contains(t, r.limiters, "ip:10.0.0.0")
+	require.Contains(t, r.limiters, "ip:10.0.0.1")
+	require.NotContains(t, r.limiters, "ip:10.0.0.2")
+	require.NotContains(t, r.limiters, "ip:10.0.0.3")
+
+	// Once it's done, its limiter can be dropped.
+	inFlight()
+	acquire(maxLimiters + 2)
+	require.NotContains(t, r.limiters, "ip:10.0.0.1")
+	require.Contains(t, r.limiters, "ip:10.0.0.4")
+}
+
+func TestLimitRequestDisabled(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		release, err := LimitRequest("", "10.0.0.1")
+		require.NoError(t, err)
+		release()
+	}
+}
@@ -85,6 +85,15 @@ func InitServerState() {
 
 	State.initStorage()
 
+	if Config.SlowQueryLog != "" {
+		var err error
+		slowLog, err = openSlowQueryLog(Config.SlowQueryLog, Config.SlowQueryThreshold)
+		x.Check(err)
+	}
+	if Config.AuditLog != "" {
+		x.Check(openAuditLog(Config.AuditLog, Config.AuditLogSize))
+	}
+
 	go State.fillTimestampRequests()
 }
 
@@ -197,6 +206,12 @@ func (s *ServerState) Dispose() {
 	}
 	s.vlogTicker.Stop()
 	s.mandatoryVlogTicker.Stop()
+	if err := slowLog.close(); err != nil {
+		glog.Errorf("Error while closing slow query log: %v", err)
+	}
+	if err := closeAuditLog(); err != nil {
+		glog.Errorf("Error while closing audit log: %v", err)
+	}
 }
 
 // Server implements protos.DgraphServer
@@ -275,11 +290,16 @@ func (s *ServerState) getTimestamp(readOnly bool) uint64 {
 	return <-tr.ch
 }
 
-func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, error) {
+func (s *Server) Alter(ctx context.Context, op *api.Operation) (resp *api.Payload, rerr error) {
 	ctx, span := otrace.StartSpan(ctx, "Server.Alter")
 	defer span.End()
 	span.Annotatef(nil, "Alter operation: %+v", op)
 
+	var decision string
+	defer func() {
+		auditAlter(ctx, op, decision, rerr)
+	}()
+
 	// Always print out Alter operations because they are important and rare.
 	glog.Infof("Received ALTER op: %+v", op)
 
@@ -303,13 +323,16 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	}
 	if err := isAlterAllowed(ctx); err != nil {
 		glog.Warningf("Alter denied with error: %v\n", err)
+		decision = auditDenied
 		return nil, err
 	}
 
 	if err := authorizeAlter(ctx, op); err != nil {
 		glog.Warningf("Alter denied with error: %v\n", err)
+		decision = auditDenied
 		return nil, err
 	}
+	decision = auditAllowed
 
 	defer glog.Infof("ALTER op: %+v done", op)
 
@@ -415,24 +438,55 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	return empty, err
 }
 
+// parseReadAt parses the time of a point-in-time query, either an RFC 3339 time or a duration
+// before now.
+func parseReadAt(readAt string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(readAt); err == nil {
+		if d < 0 {
+			return time.Time{}, x.Errorf("Invalid read_at %q: duration must be positive.", readAt)
+		}
+		return now.Add(-d), nil
+	}
+	at, err := time.Parse(time.RFC3339, readAt)
+	if err != nil {
+		return time.Time{}, x.Errorf("Invalid read_at %q: must be an RFC 3339 time or a duration.",
+			readAt)
+	}
+	if at.After(now) {
+		return time.Time{}, x.Errorf("Invalid read_at %q: time is in the future.", readAt)
+	}
+	return at, nil
+}
+
 func annotateStartTs(span *otrace.Span, ts uint64) {
 	span.Annotate([]otrace.Attribute{otrace.Int64Attribute("startTs", int64(ts))}, "")
 }
 
-func (s *Server) Mutate(ctx context.Context, mu *api.Mutation) (resp *api.Assigned, err error) {
+func (s *Server) Mutate(ctx context.Context, mu *api.Mutation) (*api.Assigned, error) {
+	return s.mutate(ctx, &pb.Mutation{Mutation: mu})
+}
+
+// mutate authorizes and runs the mutation, along with its options beyond the client API.
+func (s *Server) mutate(ctx context.Context, mu *pb.Mutation) (resp *api.Assigned, err error) {
 	if err := authorizeMutation(ctx, mu); err != nil {
+		auditMutation(ctx, mu, nil, auditDenied, err)
 		return nil, err
 	}
 
-	return s.doMutate(ctx, mu)
+	resp, err = s.doMutate(ctx, mu)
+	auditMutation(ctx, mu, resp, auditAllowed, err)
+	return resp, err
 }
 
-func (s *Server) doMutate(ctx context.Context, mu *api.Mutation) (resp *api.Assigned, rerr error) {
+func (s *Server) doMutate(ctx context.Context,
+	pmu *pb.Mutation) (resp *api.Assigned, rerr error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
+	mu := pmu.Mutation
 	startTime := time.Now()
 
+	var numEdges int
 	ctx, span := otrace.StartSpan(ctx, methodMutate)
 	ctx = x.WithMethod(ctx, methodMutate)
 	defer func() {
@@ -444,6 +498,7 @@ func (s *Server) doMutate(ctx context.Context, mu *api.Mutation) (resp *api.Assi
 		ctx, _ = tag.New(ctx, tag.Upsert(x.KeyStatus, v))
 		timeSpentMs := x.SinceMs(startTime)
 		ostats.Record(ctx, x.LatencyMs.M(timeSpentMs))
+		logSlowMutation(ctx, pmu, resp, numEdges, time.Since(startTime), rerr)
 	}()
 
 	resp = &api.Assigned{}
@@ -492,6 +547,16 @@ func (s *Server) doMutate(ctx context.Context, mu *api.Mutation) (resp *api.Assi
 		}
 	}()
 
+	if len(pmu.Cond) > 0 && len(pmu.Query) == 0 {
+		return resp, x.Errorf("Mutation condition %s requires a query in upsert block", pmu.Cond)
+	}
+	if len(pmu.Query) > 0 {
+		span.Annotatef(nil, "Got upsert query: %s", pmu.Query)
+		if err := doQueryInUpsert(ctx, &l, pmu, gmu); err != nil {
+			return resp, err
+		}
+	}
+
 	newUids, err := query.AssignUids(ctx, gmu.Set)
 	if err != nil {
 		return resp, err
@@ -501,6 +566,11 @@ func (s *Server) doMutate(ctx context.Context, mu *api.Mutation) (resp *api.Assi
 	if err != nil {
 		return resp, err
 	}
+	numEdges = len(edges)
+	checkNodes, err := authorizeMutationNodes(ctx, mu.StartTs, edges, newUids)
+	if err != nil {
+		return resp, err
+	}
 
 	m := &pb.Mutations{
 		Edges:   edges,
@@ -509,6 +579,16 @@ func (s *Server) doMutate(ctx context.Context, mu *api.Mutation) (resp *api.Assi
 	span.Annotatef(nil, "Applying mutations: %+v", m)
 	resp.Context, err = query.ApplyMutations(ctx, m)
 	span.Annotatef(nil, "Txn Context: %+v. Err=%v", resp.Context, err)
+	if err == nil {
+		if err = checkNodes(); err != nil {
+			// The mutation is applied, so the whole transaction is aborted to drop it, and the
+			// client is told so by the status of the error.
+			resp.Context.Aborted = true
+			_, _ = worker.CommitOverNetwork(ctx, resp.Context)
+			return resp, status.Errorf(codes.Aborted, "Transaction has been aborted: %s",
+				status.Convert(err).Message())
+		}
+	}
 	if !mu.CommitNow {
 		if err == y.ErrConflict {
 			err = status.Error(codes.FailedPrecondition, err.Error())
@@ -551,23 +631,121 @@ func (s *Server) doMutate(ctx context.Context, mu *api.Mutation) (resp *api.Assi
 	return resp, nil
 }
 
+// upsertNeedVars returns the variables of the query of the upsert block that the mutation uses,
+// in its N-Quads or in its condition, along with the parsed condition if it has one.
+func upsertNeedVars(mu *pb.Mutation, gmu *gql.Mutation) ([]string, *gql.FilterTree, error) {
+	needVars := query.UpsertVars(gmu)
+	if len(mu.Cond) == 0 {
+		return needVars, nil, nil
+	}
+	cond, err := gql.ParseUpsertCond(mu.Cond)
+	if err != nil {
+		return nil, nil, err
+	}
+	return x.RemoveDuplicates(append(needVars, cond.CondVars()...)), cond, nil
+}
+
+// doQueryInUpsert processes the query of an upsert block at the start ts of the mutation, so
+// that the query and the mutation run in the same transaction. The variables defined by the
+// query are then substituted into the N-Quads of the mutation. If the mutation carries a
+// condition that doesn't hold, all of its N-Quads are dropped. The query is tracked like the
+// other queries, see queryTimeout.
+func doQueryInUpsert(ctx context.Context, l *query.Latency, mu *pb.Mutation,
+	gmu *gql.Mutation) error {
+	ctx, done := queries.track(ctx, mu.Query, Config.QueryTimeout)
+	defer done()
+
+	needVars, cond, err := upsertNeedVars(mu, gmu)
+	if err != nil {
+		return err
+	}
+	parsedReq, err := gql.ParseWithNeedVars(gql.Request{Str: mu.Query}, needVars)
+	if err != nil {
+		return err
+	}
+	if err = validateQuery(parsedReq.Query); err != nil {
+		return err
+	}
+	if err = filterQueryNodes(ctx, parsedReq.Query); err != nil {
+		return err
+	}
+
+	queryRequest := query.QueryRequest{
+		Latency:  l,
+		GqlQuery: &parsedReq,
+		ReadTs:   mu.Mutation.StartTs,
+	}
+	if err = queryRequest.ProcessQuery(ctx); err != nil {
+		return x.Wrapf(err, "while processing query in upsert block")
+	}
+
+	if cond != nil {
+		ok, err := query.EvalUpsertCond(cond, queryRequest.Vars)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			gmu.Set, gmu.Del = nil, nil
+			return nil
+		}
+	}
+	return query.UpdateMutations(gmu, queryRequest.Vars)
+}
+
 func (s *Server) Query(ctx context.Context, req *api.Request) (*api.Response, error) {
-	if err := authorizeQuery(ctx, req); err != nil {
+	resp, err := s.query(ctx, &pb.Request{Request: req})
+	return resp.GetResponse(), err
+}
+
+// query authorizes and runs the request, along with its options beyond the client API.
+func (s *Server) query(ctx context.Context, req *pb.Request) (*pb.Response, error) {
+	if err := authorizeQuery(ctx, req.Request); err != nil {
+		auditQuery(ctx, req.Request, nil, auditDenied, err)
 		return nil, err
 	}
 	if glog.V(3) {
 		glog.Infof("Got a query: %+v", req)
 	}
 
-	return s.doQuery(ctx, req)
+	resp, err := s.doQueryRequest(ctx, req)
+	auditQuery(ctx, req.Request, resp.GetResponse(), auditAllowed, err)
+	return resp, err
+}
+
+// ExtServer implements pb.DgraphServer, the client API with the options Dgraph has beyond the
+// one of dgo.
+type ExtServer struct{}
+
+// Query runs the query of the request with its options.
+func (s *ExtServer) Query(ctx context.Context, req *pb.Request) (*pb.Response, error) {
+	if req.Request == nil {
+		return nil, x.Errorf("Empty query")
+	}
+	return (&Server{}).query(ctx, req)
+}
+
+// Mutate runs the mutation with its options.
+func (s *ExtServer) Mutate(ctx context.Context, mu *pb.Mutation) (*api.Assigned, error) {
+	if mu.Mutation == nil {
+		return nil, x.Errorf("Empty mutation")
+	}
+	return (&Server{}).mutate(ctx, mu)
+}
+
+// doQuery runs the query of a request of the client API, without options beyond it.
+func (s *Server) doQuery(ctx context.Context, req *api.Request) (*api.Response, error) {
+	resp, err := s.doQueryRequest(ctx, &pb.Request{Request: req})
+	return resp.GetResponse(), err
 }
 
 // This method is used to execute the query and return the response to the
 // client as a protocol buffer message.
-func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Response, rerr error) {
+func (s *Server) doQueryRequest(ctx context.Context,
+	preq *pb.Request) (presp *pb.Response, rerr error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
+	req := preq.Request
 	startTime := time.Now()
 
 	var measurements []ostats.Measurement
@@ -583,6 +761,7 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Respo
 		timeSpentMs := x.SinceMs(startTime)
 		measurements = append(measurements, x.LatencyMs.M(timeSpentMs))
 		ostats.Record(ctx, measurements...)
+		logSlowQuery(ctx, req, presp.GetResponse(), time.Since(startTime), rerr)
 	}()
 
 	if err := x.HealthCheck(); err != nil {
@@ -594,12 +773,20 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Respo
 		measurements = append(measurements, x.PendingQueries.M(-1))
 	}()
 
-	resp = &api.Response{}
+	resp := &api.Response{}
+	presp = &pb.Response{Response: resp}
 	if len(req.Query) == 0 {
 		span.Annotate(nil, "Empty query")
-		return resp, fmt.Errorf("Empty query")
+		return presp, fmt.Errorf("Empty query")
 	}
 
+	timeout, err := queryTimeout(preq.Timeout)
+	if err != nil {
+		return presp, err
+	}
+	ctx, done := queries.track(ctx, req.Query, timeout)
+	defer done()
+
 	var l query.Latency
 	l.Start = time.Now()
 	span.Annotatef(nil, "Query received: %v", req)
@@ -609,11 +796,17 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Respo
 		Variables: req.Vars,
 	})
 	if err != nil {
-		return resp, err
+		return presp, err
 	}
 
 	if err = validateQuery(parsedReq.Query); err != nil {
-		return resp, err
+		return presp, err
+	}
+	if err = filterQueryNodes(ctx, parsedReq.Query); err != nil {
+		return presp, err
+	}
+	if preq.Explain && preq.Profile {
+		return presp, x.Errorf("A query can't be explained and profiled at once.")
 	}
 
 	var queryRequest = query.QueryRequest{
@@ -631,10 +824,32 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Respo
 	default:
 		span.Annotate([]otrace.Attribute{otrace.BoolAttribute("no", true)}, "")
 	}
+	if preq.ReadAt != "" {
+		if !req.ReadOnly {
+			return presp, x.Errorf("A point-in-time query must be read-only.")
+		}
+		if req.StartTs != 0 {
+			return presp, x.Errorf("A point-in-time query can't have a start ts.")
+		}
+		at, err := parseReadAt(preq.ReadAt, time.Now())
+		if err != nil {
+			return presp, err
+		}
+		if req.StartTs, err = worker.TimestampAt(ctx, at); err != nil {
+			return presp, err
+		}
+		if err := posting.Oracle().CheckReadTs(req.StartTs); err != nil {
+			return presp, err
+		}
+	}
+	if req.ReadOnly {
+		// Read-only queries can be served by learner replicas.
+		ctx = worker.WithLearnerReads(ctx)
+	}
 	if req.BestEffort {
 		// Sanity: check that request is read-only too.
 		if !req.ReadOnly {
-			return resp, x.Errorf("A best effort query must be read-only.")
+			return presp, x.Errorf("A best effort query must be read-only.")
 		}
 		if req.StartTs == 0 {
 			req.StartTs = posting.Oracle().MaxAssigned()
@@ -648,10 +863,29 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Respo
 	resp.Txn = &api.TxnContext{StartTs: req.StartTs}
 	annotateStartTs(span, req.StartTs)
 
+	if preq.Explain {
+		plan, err := queryRequest.Explain(ctx)
+		if err != nil {
+			return presp, x.Wrap(err)
+		}
+		resp.Json = []byte("{}")
+		presp.Plan, err = json.Marshal(plan)
+		return presp, err
+	}
+
 	// Core processing happens here.
 	var er query.ExecutionResult
 	if er, err = queryRequest.Process(ctx); err != nil {
-		return resp, x.Wrap(err)
+		return presp, x.Wrap(err)
+	}
+	if preq.Profile {
+		plan, err := queryRequest.Profile(ctx)
+		if err != nil {
+			return presp, x.Wrap(err)
+		}
+		if presp.Plan, err = json.Marshal(plan); err != nil {
+			return presp, err
+		}
 	}
 	var js []byte
 	if len(er.SchemaNode) > 0 || len(er.Types) > 0 {
@@ -674,7 +908,7 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Respo
 		js, err = query.ToJson(&l, er.Subgraphs)
 	}
 	if err != nil {
-		return resp, err
+		return presp, err
 	}
 	resp.Json = js
 	span.Annotatef(nil, "Response = %s", js)
@@ -686,7 +920,7 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request) (resp *api.Respo
 	}
 
 	resp.Latency = gl
-	return resp, err
+	return presp, err
 }
 
 func (s *Server) CommitOrAbort(ctx context.Context, tc *api.TxnContext) (*api.TxnContext, error) {
@@ -706,6 +940,7 @@ func (s *Server) CommitOrAbort(ctx context.Context, tc *api.TxnContext) (*api.Tx
 
 	span.Annotatef(nil, "Txn Context received: %+v", tc)
 	commitTs, err := worker.CommitOverNetwork(ctx, tc)
+	AuditCommit(ctx, tc, commitTs, err)
 	if err == y.ErrAborted {
 		tctx.Aborted = true
 		return tctx, status.Errorf(codes.Aborted, err.Error())
@@ -973,6 +1208,9 @@ func formatTypes(types []*pb.TypeUpdate) []map[string]interface{} {
 	for _, typ := range types {
 		typeMap := make(map[string]interface{})
 		typeMap["name"] = typ.TypeName
+		if typ.Strict {
+			typeMap["strict"] = true
+		}
 		typeMap["fields"] = make([]map[string]string, 0)
 
 		for _, field := range typ.Fields {
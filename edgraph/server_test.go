@@ -17,9 +17,12 @@
 package edgraph
 
 import (
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/require"
 )
@@ -112,3 +115,46 @@ func TestValidateKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReadAt(t *testing.T) {
+	now := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	at, err := parseReadAt("1h", now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-time.Hour), at)
+
+	at, err = parseReadAt("2019-05-01T10:30:00Z", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC), at)
+
+	for _, readAt := range []string{"-1h", "2019-05-01T13:00:00Z", "yesterday"} {
+		_, err := parseReadAt(readAt, now)
+		require.Error(t, err, "read_at %q", readAt)
+	}
+}
+
+func TestUpsertNeedVars(t *testing.T) {
+	mu := &pb.Mutation{
+		Mutation: &api.Mutation{
+			SetNquads: []byte(`uid(u) <name> "bob" .`),
+		},
+		Cond:  `@if(eq(len(n), 0) AND gt(len(u), 0))`,
+		Query: `{ u as var(func: eq(email, "a")) n as var(func: eq(nick, "b")) }`,
+	}
+	gmu, err := parseMutationObject(mu.Mutation)
+	require.NoError(t, err)
+	needVars, cond, err := upsertNeedVars(mu, gmu)
+	require.NoError(t, err)
+	require.NotNil(t, cond)
+	sort.Strings(needVars)
+	require.Equal(t, []string{"n", "u"}, needVars)
+
+	mu.Cond = ""
+	needVars, cond, err = upsertNeedVars(mu, gmu)
+	require.NoError(t, err)
+	require.Nil(t, cond)
+	require.Equal(t, []string{"u"}, needVars)
+
+	mu.Cond = `@if(eq(len(n)))`
+	_, _, err = upsertNeedVars(mu, gmu)
+	require.Error(t, err)
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// SlowQuery is a query or a mutation written to the slow query log.
+type SlowQuery struct {
+	Time time.Time `json:"time"`
+	// Type is query or mutation.
+	Type string `json:"type"`
+	// Query is the text of the query, or the query of the upsert block of the mutation.
+	Query string `json:"query,omitempty"`
+	// Vars are the names of the variables of the query. Their values are not logged.
+	Vars []string `json:"vars,omitempty"`
+	// User is the user of the ACL JWT of the request, or anonymous if there was none.
+	User    string       `json:"user,omitempty"`
+	ReadTs  uint64       `json:"read_ts,omitempty"`
+	Latency *api.Latency `json:"latency,omitempty"`
+	TotalNs uint64       `json:"total_ns"`
+	// ResultSize is the size of the JSON response of the query in bytes, or the number of edges
+	// of the mutation.
+	ResultSize int    `json:"result_size"`
+	Error      string `json:"error,omitempty"`
+}
+
+type slowQueryLog struct {
+	sync.Mutex
+	fd        *os.File
+	threshold time.Duration
+}
+
+var slowLog *slowQueryLog
+
+// openSlowQueryLog opens the slow query log at path, logging the requests slower than threshold.
+func openSlowQueryLog(path string, threshold time.Duration) (*slowQueryLog, error) {
+	fd, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, x.Wrapf(err, "While opening slow query log %q", path)
+	}
+	glog.Infof("Logging queries and mutations slower than %s to %q", threshold, path)
+	return &slowQueryLog{fd: fd, threshold: threshold}, nil
+}
+
+// log writes q to the log, if it took longer than the threshold.
+func (l *slowQueryLog) log(q *SlowQuery, latency time.Duration) {
+	if l == nil || latency < l.threshold {
+		return
+	}
+	q.Time = time.Now().UTC()
+	q.TotalNs = uint64(latency.Nanoseconds())
+	b, err := json.Marshal(q)
+	if err != nil {
+		glog.Errorf("While encoding slow query: %v", err)
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	if _, err := l.fd.Write(append(b, '\n')); err != nil {
+		glog.Errorf("While writing to the slow query log: %v", err)
+	}
+}
+
+func (l *slowQueryLog) close() error {
+	if l == nil {
+		return nil
+	}
+	return l.fd.Close()
+}
+
+func logSlowQuery(ctx context.Context, req *api.Request, resp *api.Response,
+	latency time.Duration, err error) {
+
+	if slowLog == nil || latency < slowLog.threshold {
+		return
+	}
+	q := &SlowQuery{Type: "query", Query: req.Query, User: userOf(ctx), ReadTs: req.StartTs}
+	for name := range req.Vars {
+		q.Vars = append(q.Vars, name)
+	}
+	sort.Strings(q.Vars)
+	if resp != nil {
+		q.Latency = resp.Latency
+		q.ResultSize = len(resp.Json)
+	}
+	if err != nil {
+		q.Error = err.Error()
+	}
+	slowLog.log(q, latency)
+}
+
+func logSlowMutation(ctx context.Context, mu *pb.Mutation, resp *api.Assigned, numEdges int,
+	latency time.Duration, err error) {
+
+	if slowLog == nil || latency < slowLog.threshold {
+		return
+	}
+	q := &SlowQuery{
+		Type:       "mutation",
+		Query:      mu.Query,
+		User:       userOf(ctx),
+		ReadTs:     mu.Mutation.StartTs,
+		ResultSize: numEdges,
+	}
+	if resp != nil {
+		q.Latency = resp.Latency
+	}
+	if err != nil {
+		q.Error = err.Error()
+	}
+	slowLog.log(q, latency)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestSlowQueryLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slowlog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "slow.json")
+
+	slowLog, err = openSlowQueryLog(path, 100*time.Millisecond)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, slowLog.close())
+		slowLog = nil
+	}()
+
+	ctx := context.Background()
+	req := &api.Request{
+		Query:   `query q($name: string) { q(func: eq(name, $name)) { uid } }`,
+		Vars:    map[string]string{"$name": "secret"},
+		StartTs: 10,
+	}
+	resp := &api.Response{
+		Json:    []byte(`{"q":[]}`),
+		Latency: &api.Latency{ParsingNs: 1, ProcessingNs: 2, EncodingNs: 3},
+	}
+	logSlowQuery(ctx, req, resp, 50*time.Millisecond, nil) // Not slow enough.
+	logSlowQuery(ctx, req, resp, time.Second, nil)
+	mu := &pb.Mutation{Mutation: &api.Mutation{StartTs: 12}}
+	logSlowMutation(ctx, mu, nil, 5, 200*time.Millisecond, errors.New("aborted"))
+
+	fd, err := os.Open(path)
+	require.NoError(t, err)
+	defer fd.Close()
+	var logged []SlowQuery
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		require.NotContains(t, scanner.Text(), "secret")
+		var q SlowQuery
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &q))
+		logged = append(logged, q)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, 2, len(logged))
+
+	require.Equal(t, "query", logged[0].Type)
+	require.Equal(t, req.Query, logged[0].Query)
+	require.Equal(t, []string{"$name"}, logged[0].Vars)
+	require.Equal(t, uint64(10), logged[0].ReadTs)
+	require.Equal(t, resp.Latency, logged[0].Latency)
+	require.Equal(t, uint64(time.Second), logged[0].TotalNs)
+	require.Equal(t, len(resp.Json), logged[0].ResultSize)
+
+	require.Equal(t, "mutation", logged[1].Type)
+	require.Equal(t, uint64(12), logged[1].ReadTs)
+	require.Equal(t, 5, logged[1].ResultSize)
+	require.Equal(t, "aborted", logged[1].Error)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/ee/auth"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
 	"github.com/spf13/viper"
@@ -81,6 +82,9 @@ func add(conf *viper.Viper) error {
 }
 
 func userAdd(conf *viper.Viper, userid string, password string) error {
+	if auth.IsExternalUser(userid) {
+		return fmt.Errorf("the user id %s is reserved for the external authenticators", userid)
+	}
 	dc, cancel, err := getClientWithAdminCtx(conf)
 	if err != nil {
 		return fmt.Errorf("unable to get admin context:%v", err)
@@ -255,7 +259,7 @@ func mod(conf *viper.Viper) error {
 
 	if len(userId) != 0 {
 		// when modifying the user, some group options are forbidden
-		if err := checkForbiddenOpts(conf, []string{"pred", "pred_regex", "perm"}); err != nil {
+		if err := checkForbiddenOpts(conf, []string{"pred", "pred_regex", "filter", "perm"}); err != nil {
 			return err
 		}
 
@@ -408,14 +412,19 @@ func chMod(conf *viper.Viper) error {
 	groupId := conf.GetString("group")
 	predicate := conf.GetString("pred")
 	predRegex := conf.GetString("pred_regex")
+	filter := conf.GetString("filter")
 	perm := conf.GetInt("perm")
+	var specified int
+	for _, opt := range []string{predicate, predRegex, filter} {
+		if len(opt) > 0 {
+			specified++
+		}
+	}
 	switch {
 	case len(groupId) == 0:
 		return fmt.Errorf("the groupid must not be empty")
-	case len(predicate) > 0 && len(predRegex) > 0:
-		return fmt.Errorf("one of --pred or --pred_regex must be specified, but not both")
-	case len(predicate) == 0 && len(predRegex) == 0:
-		return fmt.Errorf("one of --pred or --pred_regex must be specified, but not both")
+	case specified != 1:
+		return fmt.Errorf("exactly one of --pred, --pred_regex or --filter must be specified")
 	case perm > 7:
 		return fmt.Errorf("the perm value must be less than or equal to 7, "+
 			"the provided value is %d", perm)
@@ -425,6 +434,11 @@ func chMod(conf *viper.Viper) error {
 			return fmt.Errorf("unable to compile %v as a regular expression: %v",
 				predRegex, err)
 		}
+	case len(filter) > 0:
+		// make sure the filter is valid, whatever the uid of the user
+		if _, err := ParseFilter(filter, map[string]string{"$user": "0x1"}); err != nil {
+			return err
+		}
 	}
 
 	dc, cancel, err := getClientWithAdminCtx(conf)
@@ -460,12 +474,18 @@ func chMod(conf *viper.Viper) error {
 	}
 
 	var newAcl Acl
-	if len(predicate) > 0 {
+	switch {
+	case len(predicate) > 0:
 		newAcl = Acl{
 			Predicate: predicate,
 			Perm:      int32(perm),
 		}
-	} else {
+	case len(filter) > 0:
+		newAcl = Acl{
+			Filter: filter,
+			Perm:   int32(perm),
+		}
+	default:
 		newAcl = Acl{
 			Regex: predRegex,
 			Perm:  int32(perm),
@@ -496,8 +516,12 @@ func chMod(conf *viper.Viper) error {
 		return fmt.Errorf("unable to change mutations for the group %v on predicate %v: %v",
 			groupId, predicate, err)
 	}
-	fmt.Printf("Successfully changed permission for group %v on predicate %v to %v\n",
-		groupId, predicate, perm)
+	if len(filter) > 0 {
+		fmt.Printf("Successfully changed the filter of group %v\n", groupId)
+	} else {
+		fmt.Printf("Successfully changed permission for group %v on predicate %v to %v\n",
+			groupId, predicate, perm)
+	}
 	fmt.Println("The latest info is:")
 	return queryAndPrintGroup(ctx, dc.NewReadOnlyTxn(), groupId)
 }
@@ -576,14 +600,16 @@ func queryGroup(ctx context.Context, txn *dgo.Txn, groupid string,
 func isSameAcl(acl1 *Acl, acl2 *Acl) bool {
 	return (len(acl1.Predicate) > 0 && len(acl2.Predicate) > 0 &&
 		acl1.Predicate == acl2.Predicate) ||
-		(len(acl1.Regex) > 0 && len(acl2.Regex) > 0 && acl1.Regex == acl2.Regex)
+		(len(acl1.Regex) > 0 && len(acl2.Regex) > 0 && acl1.Regex == acl2.Regex) ||
+		// a group has a single filter
+		(len(acl1.Filter) > 0 && len(acl2.Filter) > 0)
 }
 
 // returns whether the existing acls slice is changed
 func updateAcl(acls []Acl, newAcl Acl) ([]Acl, bool) {
 	for idx, aclEntry := range acls {
 		if isSameAcl(&aclEntry, &newAcl) {
-			if aclEntry.Perm == newAcl.Perm {
+			if aclEntry.Perm == newAcl.Perm && aclEntry.Filter == newAcl.Filter {
 				// new permission is the same as the current one, no update
 				return acls, false
 			}
@@ -593,6 +619,7 @@ func updateAcl(acls []Acl, newAcl Acl) ([]Acl, bool) {
 				return acls[:len(acls)-1], true
 			}
 			acls[idx].Perm = newAcl.Perm
+			acls[idx].Filter = newAcl.Filter
 			return acls, true
 		}
 	}
@@ -24,10 +24,13 @@ import (
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/dgraph/z"
 	"github.com/golang/glog"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
@@ -428,3 +431,191 @@ func TestAccessWithoutLoggingIn(t *testing.T) {
 	mutatePredicateWithUserAccount(t, dg, false)
 	alterPredicateWithUserAccount(t, dg, false)
 }
+
+// runAclCmd runs the dgraph acl command with the given arguments as groot.
+func runAclCmd(t *testing.T, args ...string) {
+	args = append([]string{"acl"}, args...)
+	args = append(args, "-a", dgraphEndpoint, "-x", "password")
+	cmd := exec.Command(os.ExpandEnv("$GOPATH/bin/dgraph"), args...)
+	if errOutput, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Unable to run dgraph %v: %v", args, string(errOutput))
+	}
+}
+
+func queryWithUserAccount(t *testing.T, dg *dgo.Dgraph, q string) string {
+	resp, err := dg.NewReadOnlyTxn().Query(context.Background(), q)
+	require.NoError(t, err)
+	return string(resp.GetJson())
+}
+
+// upsertWithUserAccount runs the upsert block as the user. It goes through the Dgraph service of
+// pb, as dgo can't send the query of an upsert block.
+func upsertWithUserAccount(t *testing.T, query, nquads string) error {
+	conn, err := grpc.Dial(dgraphEndpoint, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	resp, err := api.NewDgraphClient(conn).Login(ctx, &api.LoginRequest{
+		Userid:   userid,
+		Password: userpassword,
+	})
+	require.NoError(t, err)
+	var jwt api.Jwt
+	require.NoError(t, jwt.Unmarshal(resp.Json))
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("accessJwt", jwt.AccessJwt))
+	_, err = pb.NewDgraphClient(conn).Mutate(ctx, &pb.Mutation{
+		Mutation: &api.Mutation{CommitNow: true, SetNquads: []byte(nquads)},
+		Query:    query,
+	})
+	return err
+}
+
+func TestNodeFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping because -short=true")
+	}
+
+	dg := z.DgraphClientWithGroot(z.SockAddr)
+	createAccountAndData(t, dg)
+	ctx := context.Background()
+	require.NoError(t, dg.Alter(ctx, &api.Operation{Schema: `
+		name: string @index(exact) .
+		tenant: string @index(exact) .
+		friend: [uid] @reverse .
+		type Node {
+			name: string
+			tenant: string
+			friend: [Node]
+		}
+	`}))
+
+	// The shortest path from a1 to a3 goes through o1, which belongs to another tenant.
+	assigned, err := dg.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		SetNquads: []byte(`
+			_:a1 <name> "a1" .
+			_:a1 <dgraph.type> "Node" .
+			_:a1 <tenant> "acme" .
+			_:a2 <name> "a2" .
+			_:a2 <tenant> "acme" .
+			_:a3 <name> "a3" .
+			_:a3 <tenant> "acme" .
+			_:a4 <name> "a4" .
+			_:a4 <tenant> "acme" .
+			_:o1 <name> "o1" .
+			_:o1 <tenant> "other" .
+			_:b1 <name> "b1" .
+			_:b1 <tenant> "beta" .
+			_:a1 <friend> _:a2 .
+			_:a1 <friend> _:o1 .
+			_:o1 <friend> _:a3 .
+			_:a2 <friend> _:a4 .
+			_:a4 <friend> _:a3 .
+		`),
+	})
+	require.NoError(t, err)
+	uids := assigned.GetUids()
+
+	runAclCmd(t, "add", "-g", "acme")
+	runAclCmd(t, "mod", "-u", userid, "--group_list", "acme")
+	for _, pred := range []string{"name", "tenant", "friend"} {
+		runAclCmd(t, "mod", "-g", "acme", "-p", pred,
+			"-m", strconv.Itoa(int(Read.Code|Write.Code)))
+	}
+	runAclCmd(t, "mod", "-g", "acme", "--filter", `eq(tenant, "acme")`)
+	glog.Infof("Sleeping for 6 seconds for acl caches to be refreshed")
+	time.Sleep(6 * time.Second)
+
+	require.NoError(t, dg.Login(ctx, userid, userpassword))
+
+	// The nodes of the other tenant are left out of the roots, of the uid predicates, of the
+	// reverse predicates and of the counts.
+	z.CompareJSON(t, `{
+		"q": [
+			{"name": "a1", "friend": [{"name": "a2"}], "count(friend)": 1},
+			{"name": "a2", "friend": [{"name": "a4"}], "~friend": [{"name": "a1"}],
+				"count(friend)": 1},
+			{"name": "a3", "~friend": [{"name": "a4"}], "count(friend)": 0},
+			{"name": "a4", "friend": [{"name": "a3"}], "~friend": [{"name": "a2"}],
+				"count(friend)": 1}
+		]
+	}`, queryWithUserAccount(t, dg, `{
+		q(func: has(name), orderasc: name) {
+			name
+			friend { name }
+			~friend { name }
+			count(friend)
+		}
+	}`))
+	z.CompareJSON(t, `{"q": []}`, queryWithUserAccount(t, dg, `{
+		q(func: eq(name, "o1")) { name }
+	}`))
+	z.CompareJSON(t, `{"q": [{"name": "a1", "tenant": "acme", "friend": [{"name": "a2"}]}]}`,
+		queryWithUserAccount(t, dg, fmt.Sprintf(`{
+			q(func: uid(%s)) {
+				expand(_all_) { name }
+			}
+		}`, uids["a1"])))
+
+	// The shortest path avoids the other tenant.
+	z.CompareJSON(t, fmt.Sprintf(`{
+		"_path_": [{"uid": "%s", "friend": {"uid": "%s", "friend": {"uid": "%s",
+			"friend": {"uid": "%s"}}}, "_weight_": 3}],
+		"path": [{"name": "a1"}, {"name": "a2"}, {"name": "a4"}, {"name": "a3"}]
+	}`, uids["a1"], uids["a2"], uids["a4"], uids["a3"]), queryWithUserAccount(t, dg,
+		fmt.Sprintf(`{
+			p as shortest(from: %s, to: %s) { friend }
+			path(func: uid(p)) { name }
+		}`, uids["a1"], uids["a3"])))
+
+	// The query of an upsert block only finds the nodes of the tenant.
+	require.NoError(t, upsertWithUserAccount(t, `{ v as var(func: has(name)) }`,
+		`uid(v) <tenant> "acme" .`))
+
+	// The mutations changing the nodes of the other tenants or linking to them are refused, and
+	// the ones leaving nodes outside of the tenant once applied are refused and their transaction
+	// aborted.
+	for _, nquads := range []string{
+		fmt.Sprintf(`<%s> <name> "o2" .`, uids["o1"]),
+		fmt.Sprintf(`<%s> <friend> <%s> .`, uids["a1"], uids["b1"]),
+		fmt.Sprintf(`_:n <name> "n3" .
+			_:n <tenant> "acme" .
+			_:n <friend> <%s> .`, uids["b1"]),
+	} {
+		_, err := dg.NewTxn().Mutate(ctx, &api.Mutation{CommitNow: true, SetNquads: []byte(nquads)})
+		require.Error(t, err, "the mutation %s should have been refused", nquads)
+		require.Contains(t, err.Error(), "PermissionDenied")
+	}
+	for _, nquads := range []string{
+		fmt.Sprintf(`<%s> <tenant> "other" .`, uids["a1"]),
+		`_:n <name> "n1" .`,
+	} {
+		_, err := dg.NewTxn().Mutate(ctx, &api.Mutation{CommitNow: true, SetNquads: []byte(nquads)})
+		require.Error(t, err, "the mutation %s should have been refused", nquads)
+		require.Contains(t, err.Error(), "Aborted")
+	}
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		SetNquads: []byte(`
+			_:n <name> "n2" .
+			_:n <tenant> "acme" .
+		`),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, dg.Login(ctx, x.GrootId, "password"))
+	z.CompareJSON(t, `{
+		"q": [
+			{"name": "a1", "tenant": "acme"},
+			{"name": "a2", "tenant": "acme"},
+			{"name": "a3", "tenant": "acme"},
+			{"name": "a4", "tenant": "acme"},
+			{"name": "b1", "tenant": "beta"},
+			{"name": "n2", "tenant": "acme"},
+			{"name": "o1", "tenant": "other"}
+		]
+	}`, queryWithUserAccount(t, dg, `{
+		q(func: has(name), orderasc: name) { name tenant }
+	}`))
+}
@@ -71,3 +71,42 @@ func TestUpdateAcl(t *testing.T) {
 	require.Equal(t, "friend", updatedAcls5[0].Predicate,
 		"the left acl should have the original first predicate")
 }
+
+func TestUpdateAclFilter(t *testing.T) {
+	currentAcls := []Acl{{Predicate: "friend", Perm: 4}}
+	newAcl := Acl{Filter: `eq(tenant, "acme")`}
+
+	updatedAcls1, changed := updateAcl(currentAcls, newAcl)
+	require.True(t, changed, "the acl list should be changed through update with a filter")
+	require.Equal(t, 2, len(updatedAcls1))
+
+	// a group has a single filter, which is replaced
+	newAcl.Filter = `uid_in(owner, $user)`
+	updatedAcls2, changed := updateAcl(updatedAcls1, newAcl)
+	require.True(t, changed, "the acl list should be changed through update with a new filter")
+	require.Equal(t, 2, len(updatedAcls2))
+	require.Equal(t, `uid_in(owner, $user)`, updatedAcls2[1].Filter)
+
+	_, changed = updateAcl(updatedAcls2, newAcl)
+	require.False(t, changed, "the acl list should not be changed through update with "+
+		"the same filter")
+
+	newAcl.Perm = -1
+	updatedAcls3, changed := updateAcl(updatedAcls2, newAcl)
+	require.True(t, changed, "the acl list should be changed through removal of the filter")
+	require.Equal(t, []Acl{{Predicate: "friend", Perm: 4}}, updatedAcls3)
+}
+
+func TestParseFilter(t *testing.T) {
+	ft, err := ParseFilter(`uid_in(owner, $user) or eq(public, true)`,
+		map[string]string{"$user": "0x2a"})
+	require.NoError(t, err)
+	require.Equal(t, "or", ft.Op)
+	require.Equal(t, 2, len(ft.Child))
+	require.Equal(t, "0x2a", ft.Child[0].Func.Args[0].Value)
+
+	_, err = ParseFilter(`eq(tenant, "acme")) { uid } } { q2(func: has(name)`, nil)
+	require.Error(t, err)
+	_, err = ParseFilter(`eq(tenant`, nil)
+	require.Error(t, err)
+}
@@ -118,6 +118,8 @@ func initSubcommands() []*x.SubCommand {
 	modFlags.StringP("pred", "p", "", "The predicates whose acls are to be changed")
 	modFlags.StringP("pred_regex", "P", "", "The regular expression specifying predicates"+
 		" whose acls are to be changed")
+	modFlags.StringP("filter", "f", "", "The filter restricting the nodes the group can access, "+
+		"e.g. 'eq(tenant, \"acme\")'. The $user variable is the uid of the user")
 	modFlags.IntP("perm", "m", 0, "The acl represented using "+
 		"an integer: 4 for read, 2 for write, and 1 for modify. Use a negative value to remove a "+
 		"predicate from the group")
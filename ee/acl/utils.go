@@ -22,6 +22,7 @@ import (
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
 	"github.com/spf13/viper"
@@ -96,13 +97,33 @@ func UnmarshalUser(resp *api.Response, userKey string) (user *User, err error) {
 }
 
 // an Acl can have either a single predicate or a regex that can be used to
-// match multiple predicates
+// match multiple predicates, or a filter restricting the nodes the members of
+// the group can access
 type Acl struct {
 	Predicate string `json:"predicate"`
 	Regex     string `json:"regex"`
+	Filter    string `json:"filter,omitempty"`
 	Perm      int32  `json:"perm"`
 }
 
+// ParseFilter parses the filter of an Acl, with the $user variable set to the
+// value in vars, if any.
+func ParseFilter(filter string, vars map[string]string) (*gql.FilterTree, error) {
+	res, err := gql.Parse(gql.Request{
+		Str: fmt.Sprintf("query q($user: string) { q(func: uid(0x1)) @filter(%s) { uid } }",
+			filter),
+		Variables: vars,
+	})
+	if err != nil {
+		return nil, x.Wrapf(err, "While parsing ACL filter %q", filter)
+	}
+	// The filter must not close the block, adding more of them to the query.
+	if len(res.Query) != 1 || res.Query[0].Filter == nil || len(res.Query[0].Children) != 1 {
+		return nil, x.Errorf("Invalid ACL filter %q", filter)
+	}
+	return res.Query[0].Filter, nil
+}
+
 // parse the response and check existing of the uid
 type Group struct {
 	Uid     string `json:"uid"`
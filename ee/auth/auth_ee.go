@@ -0,0 +1,100 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Config configures the authenticators of the users who aren't stored in Dgraph. Each of them is
+// disabled if nil.
+type Config struct {
+	JWT  *JWTConfig  `json:"jwt"`
+	LDAP *LDAPConfig `json:"ldap"`
+}
+
+// The prefixes of the user ids of the identities of the external authenticators. They keep them
+// apart from the users stored in Dgraph, which can't have such ids, and from each other.
+const (
+	JWTUserPrefix  = "jwt:"
+	LDAPUserPrefix = "ldap:"
+)
+
+// IsExternalUser returns whether the user id is the one of an identity of an external
+// authenticator.
+func IsExternalUser(userId string) bool {
+	return strings.HasPrefix(userId, JWTUserPrefix) || strings.HasPrefix(userId, LDAPUserPrefix)
+}
+
+// Identity is a user authenticated by an external authenticator, along with its ACL groups. Its
+// user id has the prefix of the authenticator.
+type Identity struct {
+	UserID string
+	Groups []string
+}
+
+// Authenticator authenticates the users against the identity provider issuing their JWTs, or
+// against the LDAP server storing their passwords.
+type Authenticator struct {
+	jwt  *jwtAuthenticator
+	ldap *ldapAuthenticator
+}
+
+// ReadConfig reads the JSON encoded Config in the file at path, and returns its Authenticator.
+func ReadConfig(path string) (*Authenticator, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, x.Wrapf(err, "While reading authenticators %q", path)
+	}
+	var conf Config
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, x.Wrapf(err, "While parsing authenticators %q", path)
+	}
+	return New(&conf)
+}
+
+// New returns the Authenticator of the configuration.
+func New(conf *Config) (*Authenticator, error) {
+	a := &Authenticator{}
+	if conf.JWT != nil {
+		jwt, err := newJWTAuthenticator(conf.JWT)
+		if err != nil {
+			return nil, err
+		}
+		a.jwt = jwt
+	}
+	if conf.LDAP != nil {
+		ldap, err := newLDAPAuthenticator(conf.LDAP)
+		if err != nil {
+			return nil, err
+		}
+		a.ldap = ldap
+	}
+	return a, nil
+}
+
+// mapGroups returns the ACL groups of the groups of the identity provider. The groups not in the
+// mapping are dropped, so there are none without mapping.
+func mapGroups(groups []string, mapping map[string]string) []string {
+	var out []string
+	for _, group := range groups {
+		if g, ok := mapping[group]; ok {
+			out = append(out, g)
+		}
+	}
+	return out
+}
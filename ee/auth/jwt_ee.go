@@ -0,0 +1,293 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// JWTConfig configures the JWTs issued by an identity provider, which are accepted as access
+// JWTs. They must be signed with RS256, RS384, RS512, ES256, ES384 or ES512 by one of the keys of
+// the JWKS file, and expire.
+type JWTConfig struct {
+	// JWKSFile is the JSON Web Key Set of the public keys of the identity provider. The key
+	// matching the kid header of the JWTs checks their signature.
+	JWKSFile string `json:"jwks_file"`
+	// Issuer and Audience are the iss and aud claims the JWTs must have. Both must be set.
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"`
+	// UserClaim is the claim of the user id, sub by default.
+	UserClaim string `json:"user_claim"`
+	// GroupsClaim is the claim of the groups of the user, a string or a list of strings. It's
+	// groups by default.
+	GroupsClaim string `json:"groups_claim"`
+	// GroupMap maps the groups of the identity provider to ACL groups. The groups not in it are
+	// dropped, and the users have no group if it's not set.
+	GroupMap map[string]string `json:"group_map"`
+}
+
+type jwtAuthenticator struct {
+	conf *JWTConfig
+	keys []publicKey
+}
+
+type publicKey struct {
+	kid string
+	key interface{}
+}
+
+// jsonWebKey is a public RSA or EC key of a JWKS, as defined by RFC 7517 and RFC 7518.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// N and E are the modulus and the exponent of RSA keys.
+	N string `json:"n"`
+	E string `json:"e"`
+	// Crv, X and Y are the curve and the coordinates of EC keys.
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func newJWTAuthenticator(conf *JWTConfig) (*jwtAuthenticator, error) {
+	switch {
+	case len(conf.JWKSFile) == 0:
+		return nil, x.Errorf("The JWKS file of the JWT authenticator must be set")
+	case len(conf.Issuer) == 0 || len(conf.Audience) == 0:
+		// Otherwise, the JWTs issued by the provider for other applications would be accepted.
+		return nil, x.Errorf("The issuer and audience of the JWT authenticator must be set")
+	}
+	b, err := ioutil.ReadFile(conf.JWKSFile)
+	if err != nil {
+		return nil, x.Wrapf(err, "While reading JWKS %q", conf.JWKSFile)
+	}
+	keys, err := parseJWKS(b)
+	if err != nil {
+		return nil, x.Wrapf(err, "While parsing JWKS %q", conf.JWKSFile)
+	}
+	c := *conf
+	if len(c.UserClaim) == 0 {
+		c.UserClaim = "sub"
+	}
+	if len(c.GroupsClaim) == 0 {
+		c.GroupsClaim = "groups"
+	}
+	return &jwtAuthenticator{conf: &c, keys: keys}, nil
+}
+
+func parseJWKS(b []byte) ([]publicKey, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &jwks); err != nil {
+		return nil, err
+	}
+	var keys []publicKey
+	for _, k := range jwks.Keys {
+		if len(k.Use) > 0 && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key %q", k.Kid)
+		}
+		keys = append(keys, publicKey{kid: k.Kid, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signing key")
+	}
+	return keys, nil
+}
+
+func (k *jsonWebKey) publicKey() (interface{}, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) == 0 {
+			return nil, errors.New("missing parameter")
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported curve %q", k.Crv)
+		}
+		px, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		py, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(px, py) {
+			return nil, errors.New("point not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: px, Y: py}, nil
+	}
+	return nil, errors.Errorf("unsupported key type %q", k.Kty)
+}
+
+// IsProviderJWT returns whether the JWT is signed like those of the identity provider, rather
+// than with the HMAC secret of the access JWTs issued by Dgraph.
+func (a *Authenticator) IsProviderJWT(token string) bool {
+	if a == nil || a.jwt == nil {
+		return false
+	}
+	t, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return false
+	}
+	switch t.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		return true
+	}
+	return false
+}
+
+// VerifyJWT verifies the JWT issued by the identity provider, and returns the user and groups of
+// its claims.
+func (a *Authenticator) VerifyJWT(token string) (*Identity, error) {
+	if a == nil || a.jwt == nil {
+		return nil, x.Errorf("The JWTs of identity providers are not accepted")
+	}
+	return a.jwt.verify(token)
+}
+
+func (ja *jwtAuthenticator) verify(tokenStr string) (*Identity, error) {
+	token, err := jwt.Parse(tokenStr, ja.keyOf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse jwt token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("claims in jwt token is not map claims")
+	}
+	// Valid only checks the expiration time if there's one.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("the jwt token has no expiration time")
+	}
+	if !claims.VerifyIssuer(ja.conf.Issuer, true) {
+		return nil, errors.Errorf("the jwt token is not issued by %s", ja.conf.Issuer)
+	}
+	if !hasAudience(claims["aud"], ja.conf.Audience) {
+		return nil, errors.Errorf("the jwt token is not intended for %s", ja.conf.Audience)
+	}
+
+	userId, ok := claims[ja.conf.UserClaim].(string)
+	if !ok || len(userId) == 0 {
+		return nil, errors.Errorf("the jwt token has no %s claim", ja.conf.UserClaim)
+	}
+	groups, err := stringList(claims[ja.conf.GroupsClaim])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s claim", ja.conf.GroupsClaim)
+	}
+	return &Identity{
+		UserID: JWTUserPrefix + userId,
+		Groups: mapGroups(groups, ja.conf.GroupMap),
+	}, nil
+}
+
+// keyOf returns the key of the JWKS which checks the signature of the token. The key type must
+// match the signing method, so that a public key is never used as an HMAC secret.
+func (ja *jwtAuthenticator) keyOf(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, k := range ja.keys {
+		if len(kid) > 0 && k.kid != kid {
+			continue
+		}
+		switch k.key.(type) {
+		case *rsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+				return k.key, nil
+			}
+		case *ecdsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+				return k.key, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("no key with kid %q for signing method %v", kid, token.Header["alg"])
+}
+
+// hasAudience returns whether the aud claim, a string or a list of strings, has the audience.
+func hasAudience(aud interface{}, audience string) bool {
+	auds, err := stringList(aud)
+	if err != nil {
+		return false
+	}
+	for _, a := range auds {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
+
+func stringList(claim interface{}) ([]string, error) {
+	switch v := claim.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, errors.Errorf("%v is not a string", e)
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	}
+	return nil, errors.Errorf("%v is neither a string nor a list of strings", claim)
+}
@@ -0,0 +1,220 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+)
+
+func b64(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func writeJWKS(t *testing.T, dir string, keys ...jsonWebKey) string {
+	b, err := json.Marshal(map[string][]jsonWebKey{"keys": keys})
+	require.NoError(t, err)
+	path := filepath.Join(dir, "jwks.json")
+	require.NoError(t, ioutil.WriteFile(path, b, 0600))
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{},
+	claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if len(kid) > 0 {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func TestVerifyJWT(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := writeJWKS(t, dir,
+		jsonWebKey{Kty: "RSA", Kid: "rsa", Use: "sig", N: b64(rsaKey.N),
+			E: b64(big.NewInt(int64(rsaKey.E)))},
+		jsonWebKey{Kty: "EC", Kid: "ec", Crv: "P-256", X: b64(ecKey.X), Y: b64(ecKey.Y)},
+		jsonWebKey{Kty: "RSA", Kid: "enc", Use: "enc", N: b64(otherKey.N),
+			E: b64(big.NewInt(int64(otherKey.E)))})
+	a, err := New(&Config{JWT: &JWTConfig{
+		JWKSFile: jwks,
+		Issuer:   "https://sso.example.com",
+		Audience: "dgraph",
+		GroupMap: map[string]string{"engineering": "dev", "ops": "sre"},
+	}})
+	require.NoError(t, err)
+
+	claims := func(override jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":    "alice",
+			"iss":    "https://sso.example.com",
+			"aud":    []string{"dgraph", "other"},
+			"exp":    time.Now().Add(time.Minute).Unix(),
+			"groups": []string{"engineering", "sales", "ops"},
+		}
+		for k, v := range override {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	rs256 := sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(nil))
+	require.True(t, a.IsProviderJWT(rs256))
+	id, err := a.VerifyJWT(rs256)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "jwt:alice", Groups: []string{"dev", "sre"}}, id)
+
+	es256 := sign(t, jwt.SigningMethodES256, "ec", ecKey, claims(jwt.MapClaims{
+		"aud": "dgraph", "groups": "ops"}))
+	require.True(t, a.IsProviderJWT(es256))
+	id, err = a.VerifyJWT(es256)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "jwt:alice", Groups: []string{"sre"}}, id)
+
+	// The key is chosen by the signing method without kid.
+	id, err = a.VerifyJWT(sign(t, jwt.SigningMethodES256, "", ecKey, claims(nil)))
+	require.NoError(t, err)
+	require.Equal(t, "jwt:alice", id.UserID)
+
+	// The access JWTs issued by Dgraph are signed with HMAC.
+	hs256 := sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims(nil))
+	require.False(t, a.IsProviderJWT(hs256))
+	_, err = a.VerifyJWT(hs256)
+	require.Error(t, err)
+	// Nor is the public key usable as an HMAC secret.
+	pub, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	_, err = a.VerifyJWT(sign(t, jwt.SigningMethodHS256, "rsa", pub, claims(nil)))
+	require.Error(t, err)
+
+	invalid := map[string]string{
+		"signed by another key": sign(t, jwt.SigningMethodRS256, "rsa", otherKey, claims(nil)),
+		"signed by an encryption key": sign(t, jwt.SigningMethodRS256, "enc", otherKey,
+			claims(nil)),
+		"signed with the key of another kid": sign(t, jwt.SigningMethodES256, "rsa", ecKey,
+			claims(nil)),
+		"expired": sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{
+			"exp": time.Now().Add(-time.Minute).Unix()})),
+		"without expiration": sign(t, jwt.SigningMethodRS256, "rsa", rsaKey,
+			claims(jwt.MapClaims{"exp": nil})),
+		"of another issuer": sign(t, jwt.SigningMethodRS256, "rsa", rsaKey,
+			claims(jwt.MapClaims{"iss": "https://evil.example.com"})),
+		"for another audience": sign(t, jwt.SigningMethodRS256, "rsa", rsaKey,
+			claims(jwt.MapClaims{"aud": "other"})),
+		"without user": sign(t, jwt.SigningMethodRS256, "rsa", rsaKey,
+			claims(jwt.MapClaims{"sub": nil})),
+		"with invalid groups": sign(t, jwt.SigningMethodRS256, "rsa", rsaKey,
+			claims(jwt.MapClaims{"groups": 42})),
+		"malformed": "not a jwt",
+	}
+	for name, token := range invalid {
+		_, err := a.VerifyJWT(token)
+		require.Error(t, err, "the JWT %s should be rejected", name)
+	}
+}
+
+func TestVerifyJWTClaims(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	jwks := writeJWKS(t, dir,
+		jsonWebKey{Kty: "EC", Kid: "ec", Crv: "P-384", X: b64(key.X), Y: b64(key.Y)})
+	conf := &JWTConfig{
+		JWKSFile:    jwks,
+		Issuer:      "https://sso.example.com",
+		Audience:    "dgraph",
+		UserClaim:   "email",
+		GroupsClaim: "roles",
+		GroupMap:    map[string]string{"dev": "dev", "sre": "sre"},
+	}
+	a, err := New(&Config{JWT: conf})
+	require.NoError(t, err)
+
+	token := sign(t, jwt.SigningMethodES384, "ec", key, jwt.MapClaims{
+		"email": "bob@example.com",
+		"roles": []string{"dev", "sre"},
+		"iss":   "https://sso.example.com",
+		"aud":   "dgraph",
+		"exp":   time.Now().Add(time.Minute).Unix(),
+	})
+	id, err := a.VerifyJWT(token)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "jwt:bob@example.com", Groups: []string{"dev", "sre"}},
+		id)
+
+	// Without group map, the users have no group.
+	conf.GroupMap = nil
+	a, err = New(&Config{JWT: conf})
+	require.NoError(t, err)
+	id, err = a.VerifyJWT(token)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "jwt:bob@example.com"}, id)
+
+	// The issuer and the audience must be set.
+	_, err = New(&Config{JWT: &JWTConfig{JWKSFile: jwks, Issuer: "https://sso.example.com"}})
+	require.Error(t, err)
+	_, err = New(&Config{JWT: &JWTConfig{JWKSFile: jwks, Audience: "dgraph"}})
+	require.Error(t, err)
+}
+
+func TestIsExternalUser(t *testing.T) {
+	require.True(t, IsExternalUser("jwt:alice"))
+	require.True(t, IsExternalUser("ldap:alice"))
+	require.False(t, IsExternalUser("alice"))
+	require.False(t, IsExternalUser("groot"))
+	require.False(t, IsExternalUser("alice:jwt"))
+}
+
+func TestParseJWKS(t *testing.T) {
+	_, err := parseJWKS([]byte(`{"keys": []}`))
+	require.Error(t, err)
+	_, err = parseJWKS([]byte(`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`))
+	require.Error(t, err)
+	_, err = parseJWKS([]byte(`{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`))
+	require.Error(t, err, "the point is not on the curve")
+	_, err = parseJWKS([]byte(`{"keys": [{"kty": "RSA", "n": "AQAB"}]}`))
+	require.Error(t, err, "the exponent is missing")
+}
@@ -0,0 +1,166 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/ldap.v3"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// LDAPConfig configures the LDAP server checking the passwords of the users logging in, with a
+// simple bind as the user.
+type LDAPConfig struct {
+	// Addr is the host:port of the LDAP server.
+	Addr string `json:"addr"`
+	// TLS connects with LDAPS. The certificate of the server is checked against the CAs of
+	// CAFile, or the system ones if not set.
+	TLS    bool   `json:"tls"`
+	CAFile string `json:"ca_file"`
+	// BindDN is the DN of the users, with %s replaced by their escaped user id, e.g.
+	// uid=%s,ou=people,dc=example,dc=com.
+	BindDN string `json:"bind_dn"`
+	// GroupsAttr is the attribute of the entry of the users with the DNs of their groups, e.g.
+	// memberOf.
+	GroupsAttr string `json:"groups_attr"`
+	// GroupMap maps the DNs of the groups to ACL groups. The groups not in it are dropped, and
+	// the users have no group if it's not set.
+	GroupMap map[string]string `json:"group_map"`
+}
+
+// The timeout of the connection to the LDAP server, and of each of the requests.
+const ldapTimeout = 10 * time.Second
+
+type ldapAuthenticator struct {
+	conf    *LDAPConfig
+	tlsConf *tls.Config
+}
+
+func newLDAPAuthenticator(conf *LDAPConfig) (*ldapAuthenticator, error) {
+	switch {
+	case len(conf.Addr) == 0:
+		return nil, x.Errorf("The address of the LDAP server must be set")
+	case strings.Count(conf.BindDN, "%s") != 1:
+		return nil, x.Errorf("The bind DN %q must have a single %%s for the user id", conf.BindDN)
+	}
+	la := &ldapAuthenticator{conf: conf}
+	if !conf.TLS {
+		return la, nil
+	}
+	host, _, err := net.SplitHostPort(conf.Addr)
+	if err != nil {
+		return nil, x.Wrapf(err, "Invalid address of the LDAP server %q", conf.Addr)
+	}
+	la.tlsConf = &tls.Config{ServerName: host}
+	if len(conf.CAFile) > 0 {
+		ca, err := ioutil.ReadFile(conf.CAFile)
+		if err != nil {
+			return nil, x.Wrapf(err, "While reading the CAs of the LDAP server %q", conf.CAFile)
+		}
+		la.tlsConf.RootCAs = x509.NewCertPool()
+		if !la.tlsConf.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, x.Errorf("No certificate in %q", conf.CAFile)
+		}
+	}
+	return la, nil
+}
+
+// HasLDAP returns whether the passwords of the users are checked by the LDAP server.
+func (a *Authenticator) HasLDAP() bool {
+	return a != nil && a.ldap != nil
+}
+
+// Bind checks the password of the user with a simple bind to the LDAP server, and returns its
+// groups.
+func (a *Authenticator) Bind(userId, password string) (*Identity, error) {
+	if !a.HasLDAP() {
+		return nil, x.Errorf("The passwords are not checked by an LDAP server")
+	}
+	return a.ldap.bind(userId, password)
+}
+
+func (la *ldapAuthenticator) bind(userId, password string) (*Identity, error) {
+	// A simple bind without password is an unauthenticated bind, which succeeds.
+	if len(userId) == 0 || len(password) == 0 {
+		return nil, errors.New("the user id and the password must not be empty")
+	}
+	dn := fmt.Sprintf(la.conf.BindDN, escapeDN(userId))
+
+	dialer := &net.Dialer{Timeout: ldapTimeout}
+	var conn net.Conn
+	var err error
+	if la.tlsConf != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", la.conf.Addr, la.tlsConf)
+	} else {
+		conn, err = dialer.Dial("tcp", la.conf.Addr)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to the LDAP server")
+	}
+	l := ldap.NewConn(conn, la.tlsConf != nil)
+	l.SetTimeout(ldapTimeout)
+	l.Start()
+	defer l.Close()
+
+	if err := l.Bind(dn, password); err != nil {
+		return nil, errors.Wrapf(err, "unable to bind as %s", dn)
+	}
+	identity := &Identity{UserID: LDAPUserPrefix + userId}
+	if len(la.conf.GroupsAttr) == 0 {
+		return identity, nil
+	}
+	res, err := l.Search(ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases,
+		0, int(ldapTimeout/time.Second), false, "(objectClass=*)",
+		[]string{la.conf.GroupsAttr}, nil))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s of %s", la.conf.GroupsAttr, dn)
+	}
+	var dns []string
+	for _, entry := range res.Entries {
+		for _, attr := range entry.Attributes {
+			if strings.EqualFold(attr.Name, la.conf.GroupsAttr) {
+				dns = append(dns, attr.Values...)
+			}
+		}
+	}
+	identity.Groups = mapGroups(dns, la.conf.GroupMap)
+	return identity, nil
+}
+
+// escapeDN escapes the value of an attribute of a DN, as defined by RFC 4514.
+func escapeDN(value string) string {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == 0:
+			sb.WriteString(`\00`)
+			continue
+		case strings.IndexByte(`"+,;<=>\`, c) >= 0,
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(value)-1 && c == ' ':
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
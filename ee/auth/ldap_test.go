@@ -0,0 +1,245 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package auth
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ber "gopkg.in/asn1-ber.v1"
+	"gopkg.in/ldap.v3"
+)
+
+// ldapServer is a stand-in LDAP server, which only answers the simple binds of its users and the
+// searches of their memberOf attribute.
+type ldapServer struct {
+	l         net.Listener
+	passwords map[string]string
+	memberOf  map[string][]string
+}
+
+func newLDAPServer(t *testing.T) *ldapServer {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	s := &ldapServer{
+		l: l,
+		passwords: map[string]string{
+			"uid=alice,ou=people,dc=example,dc=com":          "alice-pass",
+			`uid=bob\,admin,ou=people,dc=example,dc=com`:     "bob-pass",
+			"uid=carol,ou=people,dc=example,dc=com":          "carol-pass",
+			"uid=search-fails,ou=people,dc=example,dc=com":   "pass",
+			"uid=not-a-person,ou=services,dc=example,dc=com": "pass",
+		},
+		memberOf: map[string][]string{
+			"uid=alice,ou=people,dc=example,dc=com": {
+				"cn=dev,ou=groups,dc=example,dc=com",
+				`cn=sre\, oncall,ou=groups,dc=example,dc=com`,
+				"cn=sales,ou=groups,dc=example,dc=com",
+			},
+			`uid=bob\,admin,ou=people,dc=example,dc=com`: {"cn=admins,ou=groups,dc=example,dc=com"},
+		},
+	}
+	go s.serve()
+	return s
+}
+
+func (s *ldapServer) serve() {
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func ldapString(value string) *ber.Packet {
+	return ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "")
+}
+
+func ldapResult(tag ber.Tag, code int) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated,
+		code, ""))
+	op.AppendChild(ldapString(""))
+	op.AppendChild(ldapString(""))
+	return op
+}
+
+func (s *ldapServer) handle(conn net.Conn) {
+	defer conn.Close()
+	var bound string
+	for {
+		msg, err := ber.ReadPacket(conn)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		reply := func(op *ber.Packet) {
+			envelope := ber.NewSequence("")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive,
+				ber.TagInteger, msg.Children[0].Value, ""))
+			envelope.AppendChild(op)
+			_, _ = conn.Write(envelope.Bytes())
+		}
+
+		op := msg.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, password := op.Children[1].Data.String(), op.Children[2].Data.String()
+			if pass, ok := s.passwords[dn]; !ok || pass != password {
+				reply(ldapResult(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials))
+				continue
+			}
+			bound = dn
+			reply(ldapResult(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess))
+		case ldap.ApplicationSearchRequest:
+			dn := op.Children[0].Data.String()
+			switch {
+			case dn == "uid=search-fails,ou=people,dc=example,dc=com":
+				reply(ldapResult(ldap.ApplicationSearchResultDone,
+					ldap.LDAPResultInsufficientAccessRights))
+				continue
+			case dn != bound:
+				reply(ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject))
+				continue
+			}
+			ref := ber.Encode(ber.ClassApplication, ber.TypeConstructed,
+				ldap.ApplicationSearchResultReference, nil, "")
+			ref.AppendChild(ldapString("ldap://other/"))
+			reply(ref)
+
+			entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed,
+				ldap.ApplicationSearchResultEntry, nil, "")
+			entry.AppendChild(ldapString(dn))
+			attrs := ber.NewSequence("")
+			for name, vals := range map[string][]string{
+				"objectClass": {"person"},
+				"memberOf":    s.memberOf[dn],
+			} {
+				attr := ber.NewSequence("")
+				attr.AppendChild(ldapString(name))
+				set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+				for _, val := range vals {
+					set.AppendChild(ldapString(val))
+				}
+				attr.AppendChild(set)
+				attrs.AppendChild(attr)
+			}
+			entry.AppendChild(attrs)
+			reply(entry)
+			reply(ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		default:
+			return
+		}
+	}
+}
+
+// groupMap maps the groups of the ldapServer to ACL groups.
+var groupMap = map[string]string{
+	"cn=dev,ou=groups,dc=example,dc=com":          "dev",
+	`cn=sre\, oncall,ou=groups,dc=example,dc=com`: "sre",
+	"cn=admins,ou=groups,dc=example,dc=com":       "admins",
+}
+
+func TestLDAPBind(t *testing.T) {
+	s := newLDAPServer(t)
+	defer s.l.Close()
+
+	a, err := New(&Config{LDAP: &LDAPConfig{
+		Addr:       s.l.Addr().String(),
+		BindDN:     "uid=%s,ou=people,dc=example,dc=com",
+		GroupsAttr: "memberof",
+		GroupMap:   groupMap,
+	}})
+	require.NoError(t, err)
+	require.True(t, a.HasLDAP())
+
+	id, err := a.Bind("alice", "alice-pass")
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "ldap:alice", Groups: []string{"dev", "sre"}}, id)
+
+	id, err = a.Bind("bob,admin", "bob-pass")
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "ldap:bob,admin", Groups: []string{"admins"}}, id)
+
+	id, err = a.Bind("carol", "carol-pass")
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "ldap:carol"}, id)
+
+	invalid := map[string][2]string{
+		"wrong password":  {"alice", "bob-pass"},
+		"unknown user":    {"dave", "alice-pass"},
+		"empty password":  {"alice", ""},
+		"empty user":      {"", "pass"},
+		"injected DN":     {"not-a-person,ou=services", "pass"},
+		"failed search":   {"search-fails", "pass"},
+		"password of bob": {"bob", "bob-pass"},
+		"longer password": {"alice", "alice-pass\x00"},
+	}
+	for name, creds := range invalid {
+		_, err := a.Bind(creds[0], creds[1])
+		require.Error(t, err, "the bind with %s should fail", name)
+	}
+}
+
+func TestLDAPGroupMap(t *testing.T) {
+	s := newLDAPServer(t)
+	defer s.l.Close()
+
+	a, err := New(&Config{LDAP: &LDAPConfig{
+		Addr:       s.l.Addr().String(),
+		BindDN:     "uid=%s,ou=people,dc=example,dc=com",
+		GroupsAttr: "memberOf",
+		GroupMap:   map[string]string{"cn=dev,ou=groups,dc=example,dc=com": "developers"},
+	}})
+	require.NoError(t, err)
+	id, err := a.Bind("alice", "alice-pass")
+	require.NoError(t, err)
+	require.Equal(t, &Identity{UserID: "ldap:alice", Groups: []string{"developers"}}, id)
+
+	// Without group map, or without groups attribute, the users have no group.
+	for _, conf := range []*LDAPConfig{
+		{GroupsAttr: "memberOf"},
+		{GroupMap: groupMap},
+	} {
+		conf.Addr = s.l.Addr().String()
+		conf.BindDN = "uid=%s,ou=people,dc=example,dc=com"
+		a, err = New(&Config{LDAP: conf})
+		require.NoError(t, err)
+		id, err = a.Bind("alice", "alice-pass")
+		require.NoError(t, err)
+		require.Equal(t, &Identity{UserID: "ldap:alice"}, id)
+	}
+}
+
+func TestLDAPConfig(t *testing.T) {
+	_, err := New(&Config{LDAP: &LDAPConfig{BindDN: "uid=%s,dc=example,dc=com"}})
+	require.Error(t, err)
+	_, err = New(&Config{LDAP: &LDAPConfig{Addr: "localhost:389", BindDN: "dc=example,dc=com"}})
+	require.Error(t, err)
+	_, err = New(&Config{LDAP: &LDAPConfig{Addr: "localhost", BindDN: "uid=%s", TLS: true}})
+	require.Error(t, err)
+	a, err := New(&Config{})
+	require.NoError(t, err)
+	require.False(t, a.HasLDAP())
+	require.False(t, a.IsProviderJWT("a.b.c"))
+}
+
+func TestEscapeDN(t *testing.T) {
+	require.Equal(t, "alice", escapeDN("alice"))
+	require.Equal(t, `a\,b\+c\=d\\e\"f\<g\>h\;i`, escapeDN(`a,b+c=d\e"f<g>h;i`))
+	require.Equal(t, `\ a b\ `, escapeDN(" a b "))
+	require.Equal(t, `\#a#`, escapeDN("#a#"))
+	require.Equal(t, `a\00`, escapeDN("a\x00"))
+}
@@ -16,9 +16,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 
@@ -35,6 +37,7 @@ type Request struct {
 	Backup   *pb.BackupRequest
 	Manifest *Manifest
 	Version  uint64
+	Key      []byte // Encryption key, nil for unencrypted backups.
 }
 
 // Process uses the request values to create a stream writer then hand off the data
@@ -55,16 +58,32 @@ func (r *Request) Process(ctx context.Context) error {
 	}
 	glog.V(3).Infof("Backup manifest version: %d", r.Version)
 
+	// The data is encrypted as it's written, before it reaches the handler.
+	var w io.Writer = handler
+	var ew io.WriteCloser
+	if r.Key != nil {
+		if ew, err = enc.NewWriter(r.Key, handler); err != nil {
+			return err
+		}
+		w = ew
+	}
+
 	stream := r.DB.NewStreamAt(r.Backup.ReadTs)
 	stream.LogPrefix = "Dgraph.Backup"
 	// Here we return the max version in the original request obejct. We will use this
 	// to create our manifest to complete the backup.
-	r.Backup.Since, err = stream.Backup(handler, r.Version)
+	r.Backup.Since, err = stream.Backup(w, r.Version)
 	if err != nil {
 		glog.Errorf("While taking backup: %v", err)
 		return err
 	}
 	glog.V(2).Infof("Backup group %d version: %d", r.Backup.GroupId, r.Backup.Since)
+	if ew != nil {
+		if err = ew.Close(); err != nil {
+			glog.Errorf("While closing encrypted writer: %v", err)
+			return err
+		}
+	}
 	if err = handler.Close(); err != nil {
 		glog.Errorf("While closing handler: %v", err)
 		return err
@@ -73,15 +92,33 @@ func (r *Request) Process(ctx context.Context) error {
 	return nil
 }
 
+const (
+	// backupFull is the type of backups that contain all the data up to ReadTs.
+	backupFull = "full"
+	// backupIncremental is the type of backups that contain the changes since their base.
+	backupIncremental = "incremental"
+)
+
 // Manifest records backup details, these are values used during restore.
+// Type is the type of backup, full or incremental.
+// Since is the version of the base backup an incremental backup was taken against.
 // Version is the maximum version seen.
 // Groups are the IDs of the groups involved.
 // ReadTs is the original backup request timestamp.
+// Encrypted is true if the backup files are encrypted.
 type Manifest struct {
 	sync.Mutex
-	Version uint64   `json:"version"`
-	ReadTs  uint64   `json:"read_ts"`
-	Groups  []uint32 `json:"groups"`
+	Type      string   `json:"type"`
+	Since     uint64   `json:"since"`
+	Version   uint64   `json:"version"`
+	ReadTs    uint64   `json:"read_ts"`
+	Groups    []uint32 `json:"groups"`
+	Encrypted bool     `json:"encrypted"`
+}
+
+// valid returns false for manifests of unfinished or empty backups, which restore skips.
+func (m *Manifest) valid() bool {
+	return m.ReadTs != 0 && m.Version != 0 && len(m.Groups) != 0
 }
 
 // ManifestStatus combines a manifest along with other information about it
@@ -94,8 +131,44 @@ type ManifestStatus struct {
 
 // GoString implements the GoStringer interface for Manifest.
 func (m *Manifest) GoString() string {
-	return fmt.Sprintf(`Manifest{Version: %d, ReadTs: %d, Groups: %v}`,
-		m.Version, m.ReadTs, m.Groups)
+	return fmt.Sprintf(`Manifest{Type: %q, Since: %d, Version: %d, ReadTs: %d, Groups: %v}`,
+		m.Type, m.Since, m.Version, m.ReadTs, m.Groups)
+}
+
+// setBase picks the backup this one is taken against out of the manifests found at the
+// destination, sorted in the order the backups were taken. The version of the base becomes
+// the version the backup starts at, zero for a full backup.
+func (r *Request) setBase(manifests []*Manifest) error {
+	r.Version = 0
+	if r.Backup.Type == backupFull {
+		return nil
+	}
+
+	var base *Manifest
+	for _, m := range manifests {
+		if m.valid() && (r.Backup.BaseReadTs == 0 || m.ReadTs == r.Backup.BaseReadTs) {
+			base = m
+		}
+	}
+	switch {
+	case base != nil:
+		r.Version = base.Version
+	case r.Backup.BaseReadTs != 0:
+		return x.Errorf("No backup with read_ts %d found at %s",
+			r.Backup.BaseReadTs, r.Backup.Location)
+	case r.Backup.Type == backupIncremental:
+		return x.Errorf("No previous backup found at %s to take an incremental backup against",
+			r.Backup.Location)
+	}
+	return nil
+}
+
+// checkChanges returns ErrBackupNoChanges if nothing changed since the base backup.
+func (r *Request) checkChanges() error {
+	if r.Version != 0 && r.Version >= r.Backup.SnapshotTs {
+		return ErrBackupNoChanges
+	}
+	return nil
 }
 
 // Complete will finalize a backup by writing the manifest at the backup destination.
@@ -111,6 +184,13 @@ func (r *Request) Complete(ctx context.Context) error {
 	if r.Manifest.ReadTs == 0 {
 		r.Manifest.ReadTs = r.Backup.ReadTs
 	}
+	// The handler picked the same base as the groups did when taking the backup.
+	r.Manifest.Since = r.Version
+	r.Manifest.Type = backupFull
+	if r.Version != 0 {
+		r.Manifest.Type = backupIncremental
+	}
+	r.Manifest.Encrypted = r.Key != nil
 	if err = json.NewEncoder(handler).Encode(r.Manifest); err != nil {
 		return err
 	}
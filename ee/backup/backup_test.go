@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -215,7 +216,7 @@ func RestoreFull(t *testing.T, c *dgo.Dgraph) {
 
 	// restore this backup dir (3 files total)
 	t.Logf("--- Restoring from: %q", dirs[0])
-	_, err := runRestore("./data/restore", dirs[0])
+	_, err := runRestore("./data/restore", dirs[0], Target{}, nil)
 	require.NoError(t, err)
 
 	// just check p1 which should have the 'movie' predicate (moved during setup)
@@ -276,9 +277,10 @@ func RestoreIncr1(t *testing.T, c *dgo.Dgraph) {
 	})
 	require.True(t, len(dirs) == 2)
 
-	// restore this backup dir (3 files total)
-	t.Logf("--- Restoring from: %q", dirs[1])
-	_, err := runRestore("./data/restore", dirs[1])
+	// restore up to this backup, replaying the full backup before it.
+	manifest := filepath.Join(dirs[1], backupManifest)
+	t.Logf("--- Restoring up to: %q", manifest)
+	_, err := runRestore("./data/restore", "./data/backups", Target{Manifest: manifest}, nil)
 	require.NoError(t, err)
 
 	// just check p1 which should have the 'movie' predicate (moved during setup)
@@ -334,9 +336,9 @@ func RestoreIncr2(t *testing.T, c *dgo.Dgraph) {
 	})
 	require.True(t, len(dirs) == 3)
 
-	// restore this backup dir (3 files total)
-	t.Logf("--- Restoring from: %q", dirs[2])
-	_, err := runRestore("./data/restore", dirs[2])
+	// restore the latest backup, replaying the whole chain.
+	t.Logf("--- Restoring up to: %q", dirs[2])
+	_, err := runRestore("./data/restore", "./data/backups", Target{}, nil)
 	require.NoError(t, err)
 
 	// just check p1 which should have the 'movie' predicate (moved during setup)
@@ -0,0 +1,47 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package backup
+
+import (
+	"io"
+	"net/url"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// NewExportWriter returns a writer for the file at path under the destination of the export
+// request. The destination can use any of the URI schemes supported by backups, the
+// credentials to access it are taken from the request.
+func NewExportWriter(req *pb.ExportRequest, path string) (io.WriteCloser, error) {
+	uri, err := url.Parse(req.Destination)
+	if err != nil {
+		return nil, err
+	}
+	h := getHandler(uri.Scheme)
+	if h == nil {
+		return nil, x.Errorf("Unable to handle url: %s", uri)
+	}
+
+	r := &Request{Backup: &pb.BackupRequest{
+		Location:     req.Destination,
+		AccessKey:    req.AccessKey,
+		SecretKey:    req.SecretKey,
+		SessionToken: req.SessionToken,
+		Anonymous:    req.Anonymous,
+	}}
+	if err := h.CreateFile(uri, r, path); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
@@ -42,6 +42,17 @@ func (h *fileHandler) readManifest(path string, m *Manifest) error {
 	return json.Unmarshal(b, m)
 }
 
+// findManifests returns the paths of the manifests under path, sorted in the order the
+// backups were taken.
+func (h *fileHandler) findManifests(path string) []string {
+	suffix := filepath.Join(string(filepath.Separator), backupManifest)
+	manifests := x.WalkPathFunc(path, func(path string, isdir bool) bool {
+		return !isdir && strings.HasSuffix(path, suffix)
+	})
+	sort.Strings(manifests)
+	return manifests
+}
+
 // Create prepares the a path to save backup files.
 // Returns error on failure, nil on success.
 func (h *fileHandler) Create(uri *url.URL, req *Request) error {
@@ -52,31 +63,25 @@ func (h *fileHandler) Create(uri *url.URL, req *Request) error {
 		return x.Errorf("The path %q does not exist or it is inaccessible.", uri.Path)
 	}
 
-	// Find the max version from the latest backup. This is done only when starting a new
-	// backup, not when creating a manifest.
+	// Find the backup this one is taken against, and with it the version to use in Backup().
+	// If we can't find a manifest file, this is a full backup.
+	var manifests []*Manifest
+	for _, path := range h.findManifests(uri.Path) {
+		var m Manifest
+		if err := h.readManifest(path, &m); err != nil {
+			return x.Wrapf(err, "While reading %q", path)
+		}
+		manifests = append(manifests, &m)
+	}
+	if err := req.setBase(manifests); err != nil {
+		return err
+	}
+
 	if req.Manifest == nil {
-		// Walk the path and find the most recent backup.
-		// If we can't find a manifest file, this is a full backup.
-		var lastManifest string
-		suffix := filepath.Join(string(filepath.Separator), backupManifest)
-		_ = x.WalkPathFunc(uri.Path, func(path string, isdir bool) bool {
-			if !isdir && strings.HasSuffix(path, suffix) && path > lastManifest {
-				lastManifest = path
-			}
-			return false
-		})
-		// Found a manifest now we extract the version to use in Backup().
-		if lastManifest != "" {
-			var m Manifest
-			if err := h.readManifest(lastManifest, &m); err != nil {
-				return err
-			}
-			// No new changes since last check
-			if m.Version == req.Backup.SnapshotTs {
-				return ErrBackupNoChanges
-			}
-			// Return the version of last backup
-			req.Version = m.Version
+		// No new changes since the base backup. This is checked only when starting a new
+		// backup, not when creating a manifest.
+		if err := req.checkChanges(); err != nil {
+			return err
 		}
 		fileName = fmt.Sprintf(backupNameFmt, req.Backup.ReadTs, req.Backup.GroupId)
 	} else {
@@ -99,60 +104,45 @@ func (h *fileHandler) Create(uri *url.URL, req *Request) error {
 	return nil
 }
 
-// Load uses tries to load any backup files found.
-// Returns nil and the maximum Ts version on success, error otherwise.
-func (h *fileHandler) Load(uri *url.URL, fn loadFn) (uint64, error) {
+// CreateFile creates the file at path under the location, and the directories leading to it.
+func (h *fileHandler) CreateFile(uri *url.URL, req *Request, path string) error {
 	if !pathExist(uri.Path) {
-		return 0, x.Errorf("The path %q does not exist or it is inaccessible.", uri.Path)
+		return x.Errorf("The path %q does not exist or it is inaccessible.", uri.Path)
 	}
 
-	// Get a lisst of all the manifest files at the location.
-	suffix := filepath.Join(string(filepath.Separator), backupManifest)
-	manifests := x.WalkPathFunc(uri.Path, func(path string, isdir bool) bool {
-		return !isdir && strings.HasSuffix(path, suffix)
-	})
-	if len(manifests) == 0 {
-		return 0, x.Errorf("No manifests found at path: %s", uri.Path)
+	path = filepath.Join(uri.Path, path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
 	}
-	sort.Strings(manifests)
-	if glog.V(3) {
-		fmt.Printf("Found backup manifest(s): %v\n", manifests)
+	var err error
+	if h.fp, err = os.Create(path); err != nil {
+		return err
 	}
+	glog.V(2).Infof("Using file path: %q", path)
+	return nil
+}
 
-	// version is returned with the max manifest version found.
-	var version uint64
-
-	// Process each manifest, first check that they are valid and then confirm the
-	// backup files for each group exist. Each group in manifest must have a backup file,
-	// otherwise this is a failure and the user must remedy.
-	for _, manifest := range manifests {
-		var m Manifest
-		if err := h.readManifest(manifest, &m); err != nil {
-			return 0, x.Wrapf(err, "While reading %q", manifest)
-		}
-		if m.ReadTs == 0 || m.Version == 0 || len(m.Groups) == 0 {
-			if glog.V(2) {
-				fmt.Printf("Restore: skip backup: %s: %#v\n", manifest, &m)
-			}
-			continue
-		}
-
-		// Check the files for each group in the manifest exist.
-		path := filepath.Dir(manifest)
+// Load loads the backup files of the given backups, in order.
+// Returns nil on success, error otherwise.
+func (h *fileHandler) Load(uri *url.URL, manifests []*ManifestStatus, fn loadFn) error {
+	// Each group in manifest must have a backup file, otherwise this is a failure and the
+	// user must remedy.
+	for _, m := range manifests {
+		path := filepath.Dir(m.FileName)
 		for _, groupId := range m.Groups {
 			file := filepath.Join(path, fmt.Sprintf(backupNameFmt, m.ReadTs, groupId))
 			fp, err := os.Open(file)
 			if err != nil {
-				return 0, x.Wrapf(err, "Failed to open %q", file)
+				return x.Wrapf(err, "Failed to open %q", file)
 			}
-			defer fp.Close()
-			if err = fn(fp, int(groupId)); err != nil {
-				return 0, err
+			err = fn(fp, int(groupId))
+			fp.Close()
+			if err != nil {
+				return err
 			}
 		}
-		version = m.Version
 	}
-	return version, nil
+	return nil
 }
 
 // ListManifests loads the manifests in the locations and returns them.
@@ -162,14 +152,10 @@ func (h *fileHandler) ListManifests(uri *url.URL) ([]string, error) {
 	}
 
 	// Get a list of all the manifest files at the location.
-	suffix := filepath.Join(string(filepath.Separator), backupManifest)
-	manifests := x.WalkPathFunc(uri.Path, func(path string, isdir bool) bool {
-		return !isdir && strings.HasSuffix(path, suffix)
-	})
+	manifests := h.findManifests(uri.Path)
 	if len(manifests) == 0 {
 		return nil, x.Errorf("No manifests found at path: %s", uri.Path)
 	}
-	sort.Strings(manifests)
 	if glog.V(3) {
 		fmt.Printf("Found backup manifest(s): %v\n", manifests)
 	}
@@ -13,10 +13,18 @@
 package backup
 
 import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
 	"io"
 	"net/url"
+	"path/filepath"
 
+	bpb "github.com/dgraph-io/badger/pb"
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/x"
+
+	"github.com/golang/glog"
 )
 
 const (
@@ -36,11 +44,16 @@ const (
 	//
 	// Example manifest:
 	// {
+	//   "type": "incremental",
+	//   "since": 2170,
 	//   "version": 2280,
 	//   "groups": [ 1, 2, 3 ],
 	//   "read_ts": 110001
 	// }
 	//
+	// "type" is either "full" or "incremental".
+	// "since" is the version of the backup an incremental backup was taken against, zero for
+	// full backups.
 	// "version" is the maximum data version, obtained from Backup() after it runs. This value
 	// is used for subsequent incremental backups.
 	// "groups" are the group IDs that participated.
@@ -64,14 +77,23 @@ type handler interface {
 	// The Request object has the DB, estimated tablets size, and backup parameters.
 	Create(*url.URL, *Request) error
 
-	// Load will scan location URI for backup files, then load them via loadFn.
-	// Objects implementing this function will be used for retrieving (dowload) backup files
-	// and loading the data into a DB. The restore CLI command uses this call.
+	// CreateFile prepares the location for writing the file at the given path, relative to the
+	// location. Unlike Create, no backup manifests are involved. Exports use this call to write
+	// their files to any of the locations backups can be written to.
+	//
+	// The URL object is parsed as described in `newHandler`.
+	// The Request object only needs the credentials to access the location.
+	CreateFile(*url.URL, *Request, string) error
+
+	// Load will read the backup files of the given backups at location URI, in order, then
+	// load them via loadFn. Objects implementing this function will be used for retrieving
+	// (dowload) backup files and loading the data into a DB. The restore CLI command uses
+	// this call.
 	//
 	// The URL object is parsed as described in `newHandler`.
 	// The loadFn receives the files as they are processed by a handler, to do the actual
 	// load to DB.
-	Load(*url.URL, loadFn) (uint64, error)
+	Load(*url.URL, []*ManifestStatus, loadFn) error
 
 	// ListManifests will scan the provided URI and return the paths to the manifests stored
 	// in that location.
@@ -143,37 +165,205 @@ func (r *Request) newHandler() (handler, error) {
 // A reader and the backup groupId are passed as arguments.
 type loadFn func(reader io.Reader, groupId int) error
 
-// Load will scan location l for backup files, then load them sequentially through reader.
-// Returns the maximum Ts version on success, otherwise an error.
-func Load(l string, fn loadFn) (version uint64, err error) {
-	uri, err := url.Parse(l)
+// Target selects the backup a restore stops at. If Manifest is set, it is the path of the
+// manifest of that backup, as listed by ListManifests. Otherwise, it is the latest backup taken
+// at or before ReadTs, or the latest backup if ReadTs is zero.
+type Target struct {
+	Manifest string
+	ReadTs   uint64
+}
+
+// pick returns the index of the target backup in manifests.
+func (t Target) pick(manifests []*ManifestStatus) (int, error) {
+	target := -1
+	for i, m := range manifests {
+		switch {
+		case t.Manifest != "":
+			if filepath.Clean(m.FileName) == filepath.Clean(t.Manifest) {
+				target = i
+			}
+		case t.ReadTs == 0 || m.ReadTs <= t.ReadTs:
+			target = i
+		}
+	}
+	if target >= 0 {
+		return target, nil
+	}
+	if t.Manifest != "" {
+		return 0, x.Errorf("Backup with manifest %q not found", t.Manifest)
+	}
+	return 0, x.Errorf("No backup found taken at or before %d", t.ReadTs)
+}
+
+// backupChain returns the backups that must be loaded, in order, to restore the backup at
+// index i of manifests: the full backup the chain starts at, followed by the incremental
+// backups leading up to i. Manifests must be sorted in the order the backups were taken.
+func backupChain(manifests []*ManifestStatus, i int) ([]*ManifestStatus, error) {
+	var chain []*ManifestStatus
+	for {
+		m := manifests[i]
+		chain = append(chain, m)
+		if m.Type == backupFull || i == 0 && m.Type == "" {
+			break
+		}
+		base := i - 1
+		if m.Type != "" {
+			// Incremental backups can be taken against any previous backup, find it by
+			// version.
+			for base >= 0 && manifests[base].Version != m.Since {
+				base--
+			}
+		}
+		// Manifests without a type were written before backup types existed, each of those
+		// backups was taken against the one before it.
+		if base < 0 {
+			return nil, x.Errorf("Base backup of %s with version %d not found",
+				m.FileName, m.Since)
+		}
+		i = base
+	}
+	for l, r := 0, len(chain)-1; l < r; l, r = l+1, r-1 {
+		chain[l], chain[r] = chain[r], chain[l]
+	}
+	return chain, nil
+}
+
+// Load will scan location l for backup files, then load the chain of backups that ends at
+// the one selected by target, sequentially through reader. Encrypted backups are decrypted
+// with key.
+// Returns the version of the restored backup on success, otherwise an error.
+func Load(l string, target Target, key []byte, fn loadFn) (uint64, error) {
+	uri, h, manifests, err := listManifests(l)
 	if err != nil {
 		return 0, err
 	}
 
-	h := getHandler(uri.Scheme)
-	if h == nil {
-		return 0, x.Errorf("Unsupported URI: %v", uri)
+	// Backups without a valid manifest are skipped.
+	var valid []*ManifestStatus
+	for _, m := range manifests {
+		if !m.valid() {
+			if glog.V(2) {
+				fmt.Printf("Restore: skip backup: %s: %#v\n", m.FileName, m.Manifest)
+			}
+			continue
+		}
+		valid = append(valid, m)
+	}
+	i, err := target.pick(valid)
+	if err != nil {
+		return 0, err
+	}
+	chain, err := backupChain(valid, i)
+	if err != nil {
+		return 0, err
 	}
+	for _, m := range chain {
+		if glog.V(2) {
+			fmt.Printf("Restore: %s backup: %s\n", m.Type, m.FileName)
+		}
+		if err := h.Load(uri, []*ManifestStatus{m}, decryptFn(m, key, fn)); err != nil {
+			return 0, err
+		}
+	}
+	return valid[i].Version, nil
+}
+
+// decryptFn returns a loadFn that decrypts the backup files of m, if it's encrypted, before
+// passing them to fn.
+func decryptFn(m *ManifestStatus, key []byte, fn loadFn) loadFn {
+	if !m.Encrypted {
+		return fn
+	}
+	return func(r io.Reader, groupId int) error {
+		if key == nil {
+			return x.Errorf("Backup %s is encrypted, an encryption key is required",
+				m.FileName)
+		}
+		dr, err := enc.NewReader(key, r)
+		if err != nil {
+			return x.Wrapf(err, "While decrypting backup %s", m.FileName)
+		}
+		return fn(dr, groupId)
+	}
+}
+
+// Verify checks that every backup at location l can be restored: its chain of backups must be
+// complete, and its backup files must exist and be readable to the end. Encrypted backups are
+// decrypted with key. The outcome for each backup is passed to fn, with a nil error if the
+// backup is fine.
+func Verify(l string, key []byte, fn func(m *ManifestStatus, err error)) error {
+	uri, h, manifests, err := listManifests(l)
+	if err != nil {
+		return err
+	}
+
+	var valid []*ManifestStatus
+	for _, m := range manifests {
+		if !m.valid() {
+			fn(m, x.Errorf("Incomplete backup, skipped by restore"))
+			continue
+		}
+		valid = append(valid, m)
+		if _, err := backupChain(valid, len(valid)-1); err != nil {
+			fn(m, err)
+			continue
+		}
+		fn(m, h.Load(uri, []*ManifestStatus{m}, decryptFn(m, key,
+			func(r io.Reader, groupId int) error {
+				return verifyBackupFile(r)
+			})))
+	}
+	return nil
+}
 
-	return h.Load(uri, fn)
+// verifyBackupFile reads a backup file to the end, checking that it is made of complete KV
+// entries in the format written by badger's Backup.
+func verifyBackupFile(r io.Reader) error {
+	br := bufio.NewReaderSize(r, 16<<10)
+	var buf []byte
+	for {
+		var sz uint64
+		err := binary.Read(br, binary.LittleEndian, &sz)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return x.Wrapf(err, "While reading backup entry size")
+		}
+		if uint64(cap(buf)) < sz {
+			buf = make([]byte, sz)
+		}
+		if _, err = io.ReadFull(br, buf[:sz]); err != nil {
+			return x.Wrapf(err, "While reading backup entry")
+		}
+		var kv bpb.KV
+		if err = kv.Unmarshal(buf[:sz]); err != nil {
+			return x.Wrapf(err, "While decoding backup entry")
+		}
+	}
 }
 
 // ListManifests scans location l for backup files and returns the list of manifests.
 func ListManifests(l string) ([]*ManifestStatus, error) {
+	_, _, manifests, err := listManifests(l)
+	return manifests, err
+}
+
+// listManifests scans location l for backup files and returns the manifests, sorted in the
+// order the backups were taken, along with the handler used to read them.
+func listManifests(l string) (*url.URL, handler, []*ManifestStatus, error) {
 	uri, err := url.Parse(l)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	h := getHandler(uri.Scheme)
 	if h == nil {
-		return nil, x.Errorf("Unsupported URI: %v", uri)
+		return nil, nil, nil, x.Errorf("Unsupported URI: %v", uri)
 	}
 
 	paths, err := h.ListManifests(uri)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	var listedManifests []*ManifestStatus
@@ -182,12 +372,12 @@ func ListManifests(l string) ([]*ManifestStatus, error) {
 		var ms ManifestStatus
 
 		if err := h.ReadManifest(path, &m); err != nil {
-			return nil, x.Wrapf(err, "While reading %q", path)
+			return nil, nil, nil, x.Wrapf(err, "While reading %q", path)
 		}
 		ms.Manifest = &m
 		ms.FileName = path
 		listedManifests = append(listedManifests, &ms)
 	}
 
-	return listedManifests, nil
+	return uri, h, listedManifests, nil
 }
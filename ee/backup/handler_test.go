@@ -13,8 +13,13 @@
 package backup
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
 	"testing"
 
+	bpb "github.com/dgraph-io/badger/pb"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,3 +39,151 @@ func TestGetHandler(t *testing.T) {
 		require.Equal(t, tc.out, actual)
 	}
 }
+
+func manifestsForTest(specs ...*Manifest) []*ManifestStatus {
+	var manifests []*ManifestStatus
+	for _, m := range specs {
+		m.Groups = []uint32{1}
+		manifests = append(manifests, &ManifestStatus{
+			Manifest: m,
+			FileName: fmt.Sprintf("dgraph.%d/manifest.json", m.ReadTs),
+		})
+	}
+	return manifests
+}
+
+func chainNames(chain []*ManifestStatus) []string {
+	var names []string
+	for _, m := range chain {
+		names = append(names, m.FileName)
+	}
+	return names
+}
+
+func TestBackupChain(t *testing.T) {
+	manifests := manifestsForTest(
+		&Manifest{Type: backupFull, Version: 10, ReadTs: 11},
+		&Manifest{Type: backupIncremental, Since: 10, Version: 20, ReadTs: 21},
+		&Manifest{Type: backupIncremental, Since: 20, Version: 30, ReadTs: 31},
+		&Manifest{Type: backupFull, Version: 40, ReadTs: 41},
+		// Taken against the first incremental backup, skipping the ones after it.
+		&Manifest{Type: backupIncremental, Since: 20, Version: 50, ReadTs: 51},
+	)
+	tests := []struct {
+		target int
+		chain  []string
+	}{
+		{0, []string{"dgraph.11/manifest.json"}},
+		{2, []string{"dgraph.11/manifest.json", "dgraph.21/manifest.json",
+			"dgraph.31/manifest.json"}},
+		{3, []string{"dgraph.41/manifest.json"}},
+		{4, []string{"dgraph.11/manifest.json", "dgraph.21/manifest.json",
+			"dgraph.51/manifest.json"}},
+	}
+	for _, tc := range tests {
+		chain, err := backupChain(manifests, tc.target)
+		require.NoError(t, err)
+		require.Equal(t, tc.chain, chainNames(chain))
+	}
+
+	// The base of an incremental backup is missing.
+	_, err := backupChain(manifests[1:], 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Base backup of dgraph.21/manifest.json with version 10")
+}
+
+func TestBackupChainUntyped(t *testing.T) {
+	// Manifests written before backup types existed replay every backup before them.
+	manifests := manifestsForTest(
+		&Manifest{Version: 10, ReadTs: 11},
+		&Manifest{Version: 20, ReadTs: 21},
+		&Manifest{Type: backupIncremental, Since: 20, Version: 30, ReadTs: 31},
+	)
+	chain, err := backupChain(manifests, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"dgraph.11/manifest.json", "dgraph.21/manifest.json",
+		"dgraph.31/manifest.json"}, chainNames(chain))
+}
+
+func TestTargetPick(t *testing.T) {
+	manifests := manifestsForTest(
+		&Manifest{Type: backupFull, Version: 10, ReadTs: 11},
+		&Manifest{Type: backupIncremental, Since: 10, Version: 20, ReadTs: 21},
+		&Manifest{Type: backupIncremental, Since: 20, Version: 30, ReadTs: 31},
+	)
+	tests := []struct {
+		target Target
+		idx    int
+		err    string
+	}{
+		{target: Target{}, idx: 2},
+		{target: Target{Manifest: "dgraph.21/manifest.json"}, idx: 1},
+		{target: Target{ReadTs: 30}, idx: 1},
+		{target: Target{ReadTs: 31}, idx: 2},
+		{target: Target{ReadTs: 5}, err: "No backup found taken at or before 5"},
+		{target: Target{Manifest: "missing"}, err: `Backup with manifest "missing" not found`},
+	}
+	for _, tc := range tests {
+		idx, err := tc.target.pick(manifests)
+		if tc.err != "" {
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.idx, idx)
+	}
+}
+
+func TestSetBase(t *testing.T) {
+	manifests := []*Manifest{
+		{Type: backupFull, Version: 10, ReadTs: 11, Groups: []uint32{1}},
+		{Type: backupIncremental, Since: 10, Version: 20, ReadTs: 21, Groups: []uint32{1}},
+		// Unfinished backups are never used as base.
+		{ReadTs: 31},
+	}
+	tests := []struct {
+		backup  pb.BackupRequest
+		version uint64
+		err     string
+	}{
+		{backup: pb.BackupRequest{}, version: 20},
+		{backup: pb.BackupRequest{Type: backupFull}, version: 0},
+		{backup: pb.BackupRequest{Type: backupIncremental}, version: 20},
+		{backup: pb.BackupRequest{BaseReadTs: 11}, version: 10},
+		{backup: pb.BackupRequest{BaseReadTs: 31}, err: "No backup with read_ts 31"},
+	}
+	for _, tc := range tests {
+		r := &Request{Backup: &tc.backup}
+		err := r.setBase(manifests)
+		if tc.err != "" {
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.version, r.Version)
+	}
+
+	// No previous backups means a full backup, unless an incremental one was asked for.
+	r := &Request{Backup: &pb.BackupRequest{}}
+	require.NoError(t, r.setBase(nil))
+	require.Equal(t, uint64(0), r.Version)
+	r = &Request{Backup: &pb.BackupRequest{Type: backupIncremental}}
+	require.Error(t, r.setBase(nil))
+}
+
+func TestVerifyBackupFile(t *testing.T) {
+	var buf bytes.Buffer
+	for _, key := range []string{"a", "b", "c"} {
+		kv := &bpb.KV{Key: []byte(key), Value: []byte("value"), Version: 1}
+		data, err := kv.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, uint64(len(data))))
+		buf.Write(data)
+	}
+	require.NoError(t, verifyBackupFile(bytes.NewReader(buf.Bytes())))
+
+	truncated := buf.Bytes()[:buf.Len()-2]
+	require.Error(t, verifyBackupFile(bytes.NewReader(truncated)))
+}
@@ -23,23 +23,28 @@ import (
 
 	"github.com/dgraph-io/badger"
 	"github.com/dgraph-io/badger/options"
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
 )
 
 var Restore x.SubCommand
 var LsBackup x.SubCommand
+var Backup x.SubCommand
 
 var opt struct {
 	location, pdir, zero string
+	manifest             string
+	readTs               uint64
+	keyFile              string
 }
 
 func init() {
 	initRestore()
 	initBackupLs()
+	initBackup()
 }
 
 func initRestore() {
@@ -68,6 +73,14 @@ Source URI parts:
 
 The --posting flag sets the posting list parent dir to store the loaded backup files.
 
+Encrypted backups are decrypted with the key in the file given by --encryption_key_file, the
+same key file the backups were taken with.
+
+By default the latest backup is restored. The --manifest flag restores the backup with the
+given manifest instead, as listed by 'dgraph backup ls'. The --read_ts flag restores the latest
+backup taken at or before the given timestamp. Restoring an incremental backup replays the
+chain of backups it was taken against, starting at the last full backup.
+
 Using the --zero flag will use a Dgraph Zero address to update the start timestamp using
 the restored version. Otherwise, the timestamp must be manually updated through Zero's HTTP
 'assign' command.
@@ -87,6 +100,10 @@ $ dgraph restore -p /var/db/dgraph -l s3://s3.us-west-2.amazonaws.com/srfrog/dgr
 # Restore from dir and update Ts:
 $ dgraph restore -p . -l /var/backups/dgraph -z localhost:5080
 
+# Restore the backups up to the one with the given manifest:
+$ dgraph restore -p . -l /var/backups/dgraph \
+    -m /var/backups/dgraph/dgraph.20190402.100000/manifest.json
+
 		`,
 		Args: cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -104,6 +121,13 @@ $ dgraph restore -p . -l /var/backups/dgraph -z localhost:5080
 	flag.StringVarP(&opt.pdir, "postings", "p", "",
 		"Directory where posting lists are stored (required).")
 	flag.StringVarP(&opt.zero, "zero", "z", "", "gRPC address for Dgraph zero. ex: localhost:5080")
+	x.RegisterClientTLSFlags(flag)
+	flag.StringVarP(&opt.manifest, "manifest", "m", "",
+		"Restore up to the backup with this manifest, instead of the latest one.")
+	flag.Uint64Var(&opt.readTs, "read_ts", 0,
+		"Restore up to the latest backup taken at or before this timestamp.")
+	flag.StringVarP(&opt.keyFile, "encryption_key_file", "k", "",
+		"The file that stores the key used to encrypt the backups, if they are encrypted.")
 	_ = Restore.Cmd.MarkFlagRequired("postings")
 	_ = Restore.Cmd.MarkFlagRequired("location")
 }
@@ -141,7 +165,8 @@ Usage examples:
 # Run using location in S3:
 $ dgraph lsbackup -l s3://s3.us-west-2.amazonaws.com/srfrog/dgraph
 		`,
-		Args: cobra.NoArgs,
+		Args:       cobra.NoArgs,
+		Deprecated: "use 'dgraph backup ls' instead.",
 		Run: func(cmd *cobra.Command, args []string) {
 			defer x.StartProfile(Restore.Conf).Stop()
 			if err := runLsbackupCmd(); err != nil {
@@ -157,6 +182,64 @@ $ dgraph lsbackup -l s3://s3.us-west-2.amazonaws.com/srfrog/dgraph
 	_ = LsBackup.Cmd.MarkFlagRequired("location")
 }
 
+func initBackup() {
+	Backup.Cmd = &cobra.Command{
+		Use:   "backup",
+		Short: "Inspect the backups in given location",
+		Long: `
+backup looks at a location where backups are stored. Backups are originated from HTTP at
+/admin/backup, then can be restored using CLI restore command.
+
+The --location flag indicates a source URI with Dgraph backup objects. This URI supports all
+the schemes used for backup.
+
+Usage examples:
+
+# List the backups in S3:
+$ dgraph backup ls -l s3://s3.us-west-2.amazonaws.com/srfrog/dgraph
+
+# Verify that the backups in a local dir can be restored:
+$ dgraph backup verify -l /var/backups/dgraph
+		`,
+	}
+	flag := Backup.Cmd.PersistentFlags()
+	flag.StringVarP(&opt.location, "location", "l", "",
+		"Sets the source location URI (required).")
+	flag.StringVarP(&opt.keyFile, "encryption_key_file", "k", "",
+		"The file that stores the key used to encrypt the backups, if they are encrypted.")
+	_ = Backup.Cmd.MarkPersistentFlagRequired("location")
+
+	Backup.Cmd.AddCommand(&cobra.Command{
+		Use:   "ls",
+		Short: "List info on backups in given location",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(Backup.Conf).Stop()
+			if err := runLsbackupCmd(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		},
+	})
+	Backup.Cmd.AddCommand(&cobra.Command{
+		Use:   "verify",
+		Short: "Check that the backups in given location can be restored",
+		Long: `
+verify checks that every backup in the location can be restored. The backups an incremental
+backup was taken against must all be present, and the backup files of each backup must exist
+and be readable to the end. Verify exits with an error if any backup fails these checks.
+		`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(Backup.Conf).Stop()
+			if err := runVerifyCmd(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		},
+	})
+}
+
 func runRestoreCmd() error {
 	var (
 		start time.Time
@@ -171,20 +254,25 @@ func runRestoreCmd() error {
 	if opt.zero != "" {
 		fmt.Println("Updating Zero timestamp at:", opt.zero)
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		zero, err := grpc.DialContext(ctx, opt.zero,
-			grpc.WithBlock(),
-			grpc.WithInsecure())
+		tlsCfg, err := x.LoadClientTLSConfig(Restore.Conf)
+		if err != nil {
+			return err
+		}
+		zero, err := x.SetupConnection(opt.zero, tlsCfg, false)
 		if err != nil {
 			return x.Wrapf(err, "Unable to connect to %s", opt.zero)
 		}
 		zc = pb.NewZeroClient(zero)
 	}
 
+	key, err := readKeyFile()
+	if err != nil {
+		return err
+	}
+
 	start = time.Now()
-	version, err := runRestore(opt.pdir, opt.location)
+	version, err := runRestore(opt.pdir, opt.location,
+		Target{Manifest: opt.manifest, ReadTs: opt.readTs}, key)
 	if err != nil {
 		return err
 	}
@@ -211,7 +299,7 @@ func runRestoreCmd() error {
 }
 
 // runRestore calls badger.Load and tries to load data into a new DB.
-func runRestore(pdir, location string) (uint64, error) {
+func runRestore(pdir, location string, target Target, key []byte) (uint64, error) {
 	bo := badger.DefaultOptions
 	bo.SyncWrites = true
 	bo.TableLoadingMode = options.MemoryMap
@@ -222,8 +310,9 @@ func runRestore(pdir, location string) (uint64, error) {
 	}
 
 	// Scan location for backup files and load them. Each file represents a node group,
-	// and we create a new p dir for each.
-	return Load(location, func(r io.Reader, groupId int) error {
+	// and we create a new p dir for each. Incremental backups are loaded on top of the
+	// backups they were taken against.
+	return Load(location, target, key, func(r io.Reader, groupId int) error {
 		bo := bo
 		bo.Dir = filepath.Join(pdir, fmt.Sprintf("p%d", groupId))
 		bo.ValueDir = bo.Dir
@@ -249,14 +338,54 @@ func runLsbackupCmd() error {
 		return x.Errorf("Error while listing manifests: %v", err.Error())
 	}
 
-	fmt.Printf("Name\tVersion\tReadTs\tGroups\n")
+	fmt.Printf("Name\tType\tSince\tVersion\tReadTs\tGroups\tEncrypted\n")
 	for _, manifest := range manifests {
-		fmt.Printf("%v\t%v\t%v\t%v\n",
+		typ := manifest.Type
+		if typ == "" {
+			typ = "-"
+		}
+		fmt.Printf("%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
 			manifest.FileName,
+			typ,
+			manifest.Since,
 			manifest.Version,
 			manifest.ReadTs,
-			manifest.Groups)
+			manifest.Groups,
+			manifest.Encrypted)
 	}
 
 	return nil
 }
+
+// readKeyFile reads the encryption key given in the flags, if any.
+func readKeyFile() ([]byte, error) {
+	if opt.keyFile == "" {
+		return nil, nil
+	}
+	return enc.ReadKeyFile(opt.keyFile)
+}
+
+func runVerifyCmd() error {
+	fmt.Println("Verifying backups from:", opt.location)
+	key, err := readKeyFile()
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	err = Verify(opt.location, key, func(m *ManifestStatus, err error) {
+		if err != nil {
+			failed++
+			fmt.Printf("%v\tFAILED: %v\n", m.FileName, err)
+			return
+		}
+		fmt.Printf("%v\tOK\n", m.FileName)
+	})
+	if err != nil {
+		return x.Errorf("Error while verifying backups: %v", err.Error())
+	}
+	if failed > 0 {
+		return x.Errorf("%d backup(s) failed verification", failed)
+	}
+	return nil
+}
@@ -54,6 +54,10 @@ type s3Handler struct {
 }
 
 func (h *s3Handler) credentialsInRequest() bool {
+	// There's no request when listing or loading backups for restore.
+	if h.req == nil {
+		return false
+	}
 	return h.req.Backup.GetAccessKey() != "" && h.req.Backup.GetSecretKey() != ""
 }
 
@@ -68,7 +72,7 @@ func (h *s3Handler) setup(uri *url.URL) (*minio.Client, error) {
 	glog.V(2).Infof("Backup using host: %s, path: %s", uri.Host, uri.Path)
 
 	var creds credentials.Value
-	if h.req.Backup.GetAnonymous() {
+	if h.req != nil && h.req.Backup.GetAnonymous() {
 		// No need to setup credentials.
 	} else if !h.credentialsInRequest() {
 		var provider credentials.Provider
@@ -161,31 +165,25 @@ func (h *s3Handler) Create(uri *url.URL, req *Request) error {
 		return err
 	}
 
-	// Find the max version from the latest backup. This is done only when starting a new
-	// backup, not when creating a manifest.
-	if req.Manifest == nil {
-		// Get list of objects inside the bucket and find the most recent backup.
-		// If we can't find a manifest file, this is a full backup.
-		var lastManifest string
-		done := make(chan struct{})
-		defer close(done)
-		suffix := "/" + backupManifest
-		for object := range mc.ListObjects(h.bucketName, h.objectPrefix, true, done) {
-			if strings.HasSuffix(object.Key, suffix) && object.Key > lastManifest {
-				lastManifest = object.Key
-			}
+	// Find the backup this one is taken against, and with it the version to use in Backup().
+	// If we can't find a manifest file, this is a full backup.
+	var manifests []*Manifest
+	for _, object := range h.findManifests(mc) {
+		var m Manifest
+		if err := h.readManifest(mc, object, &m); err != nil {
+			return x.Wrapf(err, "While reading %q", object)
 		}
-		if lastManifest != "" {
-			var m Manifest
-			if err := h.readManifest(mc, lastManifest, &m); err != nil {
-				return err
-			}
-			// No new changes since last check
-			if m.Version >= req.Backup.SnapshotTs {
-				return ErrBackupNoChanges
-			}
-			// Return the version of last backup
-			req.Version = m.Version
+		manifests = append(manifests, &m)
+	}
+	if err := req.setBase(manifests); err != nil {
+		return err
+	}
+
+	if req.Manifest == nil {
+		// No new changes since the base backup. This is checked only when starting a new
+		// backup, not when creating a manifest.
+		if err := req.checkChanges(); err != nil {
+			return err
 		}
 		objectName = fmt.Sprintf(backupNameFmt, req.Backup.ReadTs, req.Backup.GroupId)
 	} else {
@@ -196,6 +194,24 @@ func (h *s3Handler) Create(uri *url.URL, req *Request) error {
 	object := filepath.Join(h.objectPrefix,
 		fmt.Sprintf(backupPathFmt, req.Backup.UnixTs),
 		objectName)
+	h.startUpload(mc, uri, object)
+	return nil
+}
+
+// CreateFile creates a new session and sends our data stream to the object at path under the
+// location.
+func (h *s3Handler) CreateFile(uri *url.URL, req *Request, path string) error {
+	h.req = req
+	mc, err := h.setup(uri)
+	if err != nil {
+		return err
+	}
+	h.startUpload(mc, uri, filepath.Join(h.objectPrefix, path))
+	return nil
+}
+
+// startUpload uploads everything written to the handler to object, until it's closed.
+func (h *s3Handler) startUpload(mc *minio.Client, uri *url.URL, object string) {
 	glog.V(2).Infof("Sending data to %s blob %q ...", uri.Scheme, object)
 
 	h.cerr = make(chan error, 1)
@@ -203,8 +219,6 @@ func (h *s3Handler) Create(uri *url.URL, req *Request) error {
 	go func() {
 		h.cerr <- h.upload(mc, object)
 	}()
-
-	return nil
 }
 
 // readManifest reads a manifest file at path using the handler.
@@ -218,17 +232,10 @@ func (h *s3Handler) readManifest(mc *minio.Client, object string, m *Manifest) e
 	return json.NewDecoder(reader).Decode(m)
 }
 
-// Load creates a new session, scans for backup objects in a bucket, then tries to
-// load any backup objects found.
-// Returns nil and the maximum Ts version on success, error otherwise.
-func (h *s3Handler) Load(uri *url.URL, fn loadFn) (uint64, error) {
-	mc, err := h.setup(uri)
-	if err != nil {
-		return 0, err
-	}
-
+// findManifests returns the names of the manifest objects in the bucket, sorted in the order
+// the backups were taken.
+func (h *s3Handler) findManifests(mc *minio.Client) []string {
 	var manifests []string
-
 	doneCh := make(chan struct{})
 	defer close(doneCh)
 
@@ -238,56 +245,48 @@ func (h *s3Handler) Load(uri *url.URL, fn loadFn) (uint64, error) {
 			manifests = append(manifests, object.Key)
 		}
 	}
-	if len(manifests) == 0 {
-		return 0, x.Errorf("No manifests found at: %s", uri.String())
-	}
 	sort.Strings(manifests)
-	if glog.V(3) {
-		fmt.Printf("Found backup manifest(s) %s: %v\n", uri.Scheme, manifests)
-	}
-
-	// version is returned with the max manifest version found.
-	var version uint64
+	return manifests
+}
 
-	// Process each manifest, first check that they are valid and then confirm the
-	// backup files for each group exist. Each group in manifest must have a backup file,
-	// otherwise this is a failure and the user must remedy.
-	for _, manifest := range manifests {
-		var m Manifest
-		if err := h.readManifest(mc, manifest, &m); err != nil {
-			return 0, x.Wrapf(err, "While reading %q", manifest)
-		}
-		if m.ReadTs == 0 || m.Version == 0 || len(m.Groups) == 0 {
-			if glog.V(2) {
-				fmt.Printf("Restore: skip backup: %s: %#v\n", manifest, &m)
-			}
-			continue
-		}
+// Load creates a new session and loads the backup objects of the given backups, in order.
+// Returns nil on success, error otherwise.
+func (h *s3Handler) Load(uri *url.URL, manifests []*ManifestStatus, fn loadFn) error {
+	mc, err := h.setup(uri)
+	if err != nil {
+		return err
+	}
 
-		// Load the backup for each group in manifest.
-		path := filepath.Dir(manifest)
+	// Each group in manifest must have a backup object, otherwise this is a failure and the
+	// user must remedy.
+	for _, m := range manifests {
+		path := filepath.Dir(m.FileName)
 		for _, groupId := range m.Groups {
 			object := filepath.Join(path, fmt.Sprintf(backupNameFmt, m.ReadTs, groupId))
-			reader, err := mc.GetObject(h.bucketName, object, minio.GetObjectOptions{})
-			if err != nil {
-				return 0, x.Wrapf(err, "Failed to get %q", object)
-			}
-			defer reader.Close()
-			st, err := reader.Stat()
-			if err != nil {
-				return 0, x.Wrapf(err, "Stat failed %q", object)
-			}
-			if st.Size <= 0 {
-				return 0, x.Errorf("Remote object is empty or inaccessible: %s", object)
-			}
-			fmt.Printf("Downloading %q, %d bytes\n", object, st.Size)
-			if err = fn(reader, int(groupId)); err != nil {
-				return 0, err
+			if err := h.loadObject(mc, object, int(groupId), fn); err != nil {
+				return err
 			}
 		}
-		version = m.Version
 	}
-	return version, nil
+	return nil
+}
+
+// loadObject downloads a single backup object and hands it to fn.
+func (h *s3Handler) loadObject(mc *minio.Client, object string, groupId int, fn loadFn) error {
+	reader, err := mc.GetObject(h.bucketName, object, minio.GetObjectOptions{})
+	if err != nil {
+		return x.Wrapf(err, "Failed to get %q", object)
+	}
+	defer reader.Close()
+	st, err := reader.Stat()
+	if err != nil {
+		return x.Wrapf(err, "Stat failed %q", object)
+	}
+	if st.Size <= 0 {
+		return x.Errorf("Remote object is empty or inaccessible: %s", object)
+	}
+	fmt.Printf("Downloading %q, %d bytes\n", object, st.Size)
+	return fn(reader, groupId)
 }
 
 // ListManifests loads the manifests in the locations and returns them.
@@ -298,20 +297,10 @@ func (h *s3Handler) ListManifests(uri *url.URL) ([]string, error) {
 	}
 	h.uri = uri
 
-	var manifests []string
-	doneCh := make(chan struct{})
-	defer close(doneCh)
-
-	suffix := "/" + backupManifest
-	for object := range mc.ListObjects(h.bucketName, h.objectPrefix, true, doneCh) {
-		if strings.HasSuffix(object.Key, suffix) {
-			manifests = append(manifests, object.Key)
-		}
-	}
+	manifests := h.findManifests(mc)
 	if len(manifests) == 0 {
 		return nil, x.Errorf("No manifests found at: %s", uri.String())
 	}
-	sort.Strings(manifests)
 	if glog.V(3) {
 		fmt.Printf("Found backup manifest(s) %s: %v\n", uri.Scheme, manifests)
 	}
@@ -357,6 +346,14 @@ func (h *s3Handler) Close() error {
 	return <-h.cerr
 }
 
+// CloseWithError aborts the upload with err, the object isn't written.
+func (h *s3Handler) CloseWithError(err error) error {
+	if err := h.pwriter.CloseWithError(err); err != nil {
+		glog.Errorf("Unexpected error when closing pipe: %v", err)
+	}
+	return <-h.cerr
+}
+
 func (h *s3Handler) Write(b []byte) (int, error) {
 	return h.pwriter.Write(b)
 }
@@ -0,0 +1,40 @@
+// +build oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enc
+
+import (
+	"io"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// ReadKeyFile is not supported in the oss version.
+func ReadKeyFile(path string) ([]byte, error) {
+	return nil, x.ErrNotSupported
+}
+
+// NewWriter is not supported in the oss version.
+func NewWriter(key []byte, w io.Writer) (io.WriteCloser, error) {
+	return nil, x.ErrNotSupported
+}
+
+// NewReader is not supported in the oss version.
+func NewReader(key []byte, r io.Reader) (io.Reader, error) {
+	return nil, x.ErrNotSupported
+}
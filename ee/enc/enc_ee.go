@@ -0,0 +1,233 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package enc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Encrypted streams are made of a header followed by chunks. The header is the magic string
+// and a random nonce prefix. Each chunk is a 4 byte big endian length followed by that many
+// bytes of AES-GCM sealed data, holding at most chunkSize bytes of plaintext. The nonce of a
+// chunk is the nonce prefix followed by the 4 byte big endian index of the chunk. The last
+// chunk is sealed with lastChunk as additional data, so that truncated streams are detected.
+const (
+	magic      = "DGRAPHENC1"
+	prefixSize = 8
+	chunkSize  = 64 << 10
+	lengthSize = 4
+	maxChunks  = 1<<32 - 1
+)
+
+var (
+	notLastChunk = []byte{0}
+	lastChunk    = []byte{1}
+)
+
+// ReadKeyFile reads the encryption key stored in the file at path. The key must be 16, 24 or
+// 32 bytes long, to select AES-128, AES-192 or AES-256.
+func ReadKeyFile(path string) ([]byte, error) {
+	key, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, x.Wrapf(err, "While reading encryption key file %q", path)
+	}
+	if _, err := aes.NewCipher(key); err != nil {
+		return nil, x.Errorf("Encryption key in %q must be 16, 24 or 32 bytes long, got %d",
+			path, len(key))
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func nonce(prefix []byte, chunk uint32) []byte {
+	n := make([]byte, prefixSize+4)
+	copy(n, prefix)
+	binary.BigEndian.PutUint32(n[prefixSize:], chunk)
+	return n
+}
+
+type writer struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	prefix []byte
+	chunk  uint32
+	buf    []byte
+	out    []byte
+}
+
+// NewWriter returns a writer that encrypts everything written to it with key, and writes it
+// to w. Close must be called to write the last chunk, it doesn't close w.
+func NewWriter(key []byte, w io.Writer) (io.WriteCloser, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append([]byte(magic), prefix...)); err != nil {
+		return nil, err
+	}
+	return &writer{
+		w:      w,
+		aead:   aead,
+		prefix: prefix,
+		buf:    make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (ew *writer) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		if len(ew.buf) == chunkSize {
+			if err := ew.flush(notLastChunk); err != nil {
+				return n, err
+			}
+		}
+		c := copy(ew.buf[len(ew.buf):chunkSize], p)
+		ew.buf = ew.buf[:len(ew.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func (ew *writer) flush(ad []byte) error {
+	if ew.chunk == maxChunks {
+		return x.Errorf("Encrypted stream is too long")
+	}
+	if ew.out == nil {
+		ew.out = make([]byte, lengthSize, lengthSize+chunkSize+ew.aead.Overhead())
+	}
+	ew.out = ew.aead.Seal(ew.out[:lengthSize], nonce(ew.prefix, ew.chunk), ew.buf, ad)
+	binary.BigEndian.PutUint32(ew.out, uint32(len(ew.out)-lengthSize))
+	if _, err := ew.w.Write(ew.out); err != nil {
+		return err
+	}
+	ew.chunk++
+	ew.buf = ew.buf[:0]
+	return nil
+}
+
+// Close writes the last chunk of the stream.
+func (ew *writer) Close() error {
+	return ew.flush(lastChunk)
+}
+
+type reader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	prefix []byte
+	chunk  uint32
+	in     []byte
+	buf    []byte
+	plain  []byte
+	done   bool
+}
+
+// NewReader returns a reader that decrypts the stream read from r, written by NewWriter with
+// the same key. Reads fail if the stream was tampered with or is truncated.
+func NewReader(key []byte, r io.Reader) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, len(magic)+prefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, x.Wrapf(err, "While reading encryption header")
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, x.Errorf("Input is not encrypted or uses an unknown format")
+	}
+	return &reader{
+		r:      r,
+		aead:   aead,
+		prefix: header[len(magic):],
+	}, nil
+}
+
+func (er *reader) Read(p []byte) (int, error) {
+	for len(er.plain) == 0 {
+		if er.done {
+			return 0, io.EOF
+		}
+		if err := er.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, er.plain)
+	er.plain = er.plain[n:]
+	return n, nil
+}
+
+func (er *reader) next() error {
+	var length [lengthSize]byte
+	if _, err := io.ReadFull(er.r, length[:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return x.Errorf("Encrypted stream is truncated")
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > chunkSize+uint32(er.aead.Overhead()) {
+		return x.Errorf("Invalid chunk in encrypted stream")
+	}
+	if uint32(cap(er.in)) < size {
+		er.in = make([]byte, size)
+	}
+	er.in = er.in[:size]
+	if _, err := io.ReadFull(er.r, er.in); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return x.Errorf("Encrypted stream is truncated")
+		}
+		return err
+	}
+
+	// The plaintext goes to a separate buffer, as a failed Open may overwrite it and the
+	// ciphertext is needed again to check whether this is the last chunk.
+	n := nonce(er.prefix, er.chunk)
+	plain, err := er.aead.Open(er.buf[:0], n, er.in, notLastChunk)
+	if err != nil {
+		if plain, err = er.aead.Open(er.buf[:0], n, er.in, lastChunk); err != nil {
+			return x.Errorf("Unable to decrypt: wrong key or corrupted data")
+		}
+		// Nothing may follow the last chunk, or the stream was tampered with.
+		var extra [1]byte
+		if _, err := io.ReadFull(er.r, extra[:]); err != io.EOF {
+			if err != nil {
+				return err
+			}
+			return x.Errorf("Encrypted stream has trailing data after its last chunk")
+		}
+		er.done = true
+	}
+	er.chunk++
+	er.buf = plain
+	er.plain = plain
+	return nil
+}
@@ -0,0 +1,112 @@
+// +build !oss
+
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package enc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encrypt(t *testing.T, key, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(key, &buf)
+	require.NoError(t, err)
+	// Write in uneven pieces to cross chunk boundaries.
+	for len(data) > 0 {
+		n := rand.Intn(3*chunkSize/2) + 1
+		if n > len(data) {
+			n = len(data)
+		}
+		_, err := w.Write(data[:n])
+		require.NoError(t, err)
+		data = data[n:]
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decrypt(key, data []byte) ([]byte, error) {
+	r, err := NewReader(key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func TestRoundTrip(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 5*chunkSize + 7} {
+		data := make([]byte, size)
+		rand.Read(data)
+		encrypted := encrypt(t, key, data)
+		require.False(t, size > 16 && bytes.Contains(encrypted, data[:16]))
+
+		decrypted, err := decrypt(key, encrypted)
+		require.NoError(t, err)
+		require.Equal(t, len(data), len(decrypted))
+		require.True(t, bytes.Equal(data, decrypted))
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	data := make([]byte, 3*chunkSize)
+	rand.Read(data)
+	encrypted := encrypt(t, key, data)
+
+	_, err := decrypt([]byte("fedcba9876543210"), encrypted)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "wrong key or corrupted data")
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)/2] ^= 1
+	_, err = decrypt(key, tampered)
+	require.Error(t, err)
+
+	// Dropping whole chunks at the end must be detected too.
+	lastChunkSize := lengthSize + 16
+	_, err = decrypt(key, encrypted[:len(encrypted)-lastChunkSize])
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "truncated")
+
+	_, err = decrypt(key, append(append([]byte{}, encrypted...), 0))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "trailing data")
+
+	_, err = decrypt(key, data)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not encrypted")
+}
+
+func TestReadKeyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "key")
+	require.NoError(t, ioutil.WriteFile(path, []byte("0123456789abcdef"), 0600))
+	key, err := ReadKeyFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("0123456789abcdef"), key)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("short"), 0600))
+	_, err = ReadKeyFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be 16, 24 or 32 bytes long, got 5")
+}
@@ -49,6 +49,7 @@ type GraphQuery struct {
 	NeedsVar   []VarContext
 	Func       *Function
 	Expand     string // Which variable to expand with.
+	Type       string // Type given by the @type directive, if any.
 
 	Args map[string]string
 	// Query can have multiple sort parameters.
@@ -157,6 +158,7 @@ type Function struct {
 	NeedsVar   []VarContext // If the function requires some variable
 	IsCount    bool         // gt(count(friends),0)
 	IsValueVar bool         // eq(val(s), 5)
+	IsLenVar   bool         // eq(len(s), 0)
 }
 
 // filterOpPrecedence is a map from filterOp (a string) to its precedence.
@@ -460,6 +462,15 @@ type Result struct {
 // Parse initializes and runs the lexer. It also constructs the GraphQuery subgraph
 // from the lexed items.
 func Parse(r Request) (res Result, rerr error) {
+	return ParseWithNeedVars(r, nil)
+}
+
+// ParseWithNeedVars performs parsing of a query with given needVars.
+//
+// The needVars parameter is passed in the case of an upsert block, where variables defined
+// in the query are used by the mutation, e.g. uid(v) in a set operation. Without it, an error
+// is reported complaining that the variable v is defined but not used in the query block.
+func ParseWithNeedVars(r Request, needVars []string) (res Result, rerr error) {
 	query := r.Str
 	vmap := convertToVarMap(r.Variables)
 
@@ -539,6 +550,9 @@ func Parse(r Request) (res Result, rerr error) {
 		}
 
 		allVars := res.QueryVars
+		if len(needVars) > 0 {
+			allVars = append(allVars, &Vars{Needs: needVars})
+		}
 		if err := checkDependency(allVars); err != nil {
 			return res, err
 		}
@@ -1420,7 +1434,7 @@ func validFuncName(name string) bool {
 
 	switch name {
 	case "regexp", "anyofterms", "allofterms", "alloftext", "anyoftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "anyof", "allof", "type", "match", "between":
 		return true
 	}
 	return false
@@ -1508,9 +1522,17 @@ L:
 					}
 					function.NeedsVar = append(function.NeedsVar, nestedFunc.NeedsVar...)
 					function.NeedsVar[0].Typ = ValueVar
+				} else if nestedFunc.Name == "len" {
+					// Number of elements in a variable, eq(len(a), 0)
+					function.Attr = nestedFunc.Attr
+					function.IsLenVar = true
+					function.NeedsVar = append(function.NeedsVar, VarContext{
+						Name: nestedFunc.Attr,
+						Typ:  AnyVar,
+					})
 				} else {
 					if nestedFunc.Name != "count" {
-						return nil, itemInFunc.Errorf("Only val/count allowed as function "+
+						return nil, itemInFunc.Errorf("Only val/count/len allowed as function "+
 							"within another. Got: %s", nestedFunc.Name)
 					}
 					function.Attr = nestedFunc.Attr
@@ -1952,9 +1974,18 @@ func parseType(it *lex.ItemIterator, gq *GraphQuery) error {
 		return it.Item().Errorf("Expected ) after the type name in type directive")
 	}
 
-	// For now @type(TypeName) is equivalent of filtering using the type function.
-	// Later the type declarations will be used to ensure that the fields inside
-	// each block correspond to the specified type.
+	if len(gq.Type) > 0 {
+		return it.Item().Errorf("Only one type directive allowed.")
+	}
+	if gq.Filter != nil {
+		return it.Item().Errorf("Use the type function inside the filter directive " +
+			"instead of using both filter and type directives.")
+	}
+
+	// @type(TypeName) filters the nodes using the type function. The fields asked for
+	// inside the block are checked against the declaration of the type while processing
+	// the query.
+	gq.Type = typeName
 	gq.Filter = &FilterTree{
 		Func: &Function{
 			Name: "type",
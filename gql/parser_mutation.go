@@ -18,36 +18,60 @@ package gql
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgraph/lex"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 )
 
-func ParseMutation(mutation string) (*api.Mutation, error) {
+// ParseMutation parses a mutation block, or an upsert block holding a query and a
+// mutation, into a pb.Mutation. The query of an upsert block is stored in
+// pb.Mutation#Query.
+func ParseMutation(mutation string) (*pb.Mutation, error) {
 	lexer := lex.NewLexer(mutation)
-	lexer.Run(lexInsideMutation)
+	lexer.Run(lexIdentifyBlock)
 	it := lexer.NewIterator()
-	var mu api.Mutation
 
 	if !it.Next() {
 		return nil, errors.New("Invalid mutation")
 	}
+	var mu *pb.Mutation
+	var err error
 	item := it.Item()
-	if item.Typ != itemLeftCurl {
+	switch item.Typ {
+	case itemUpsertBlock:
+		mu, err = parseUpsertBlock(it)
+	case itemLeftCurl:
+		var m *api.Mutation
+		if m, err = parseMutationBlock(it); err == nil {
+			mu = &pb.Mutation{Mutation: m}
+		}
+	default:
 		return nil, x.Errorf("Expected { at the start of block. Got: [%s]", item.Val)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// mutations must be enclosed in a single block.
+	if it.Next() && it.Item().Typ != lex.ItemEOF {
+		return nil, x.Errorf("Unexpected %s after the end of the block.", it.Item().Val)
+	}
+	return mu, nil
+}
 
+// parseMutationBlock parses the set and delete operations of a mutation block. The
+// iterator must be positioned at the opening brace of the block.
+func parseMutationBlock(it *lex.ItemIterator) (*api.Mutation, error) {
+	var mu api.Mutation
 	for it.Next() {
 		item := it.Item()
 		if item.Typ == itemText {
 			continue
 		}
 		if item.Typ == itemRightCurl {
-			// mutations must be enclosed in a single block.
-			if it.Next() && it.Item().Typ != lex.ItemEOF {
-				return nil, x.Errorf("Unexpected %s after the end of the block.", it.Item().Val)
-			}
 			return &mu, nil
 		}
 		if item.Typ == itemMutationOp {
@@ -59,6 +83,153 @@ func ParseMutation(mutation string) (*api.Mutation, error) {
 	return nil, x.Errorf("Invalid mutation.")
 }
 
+// parseUpsertBlock parses an upsert block holding exactly one query op and one mutation op,
+// i.e. upsert { query { ... } mutation { set { ... } delete { ... } } }. The mutation op may
+// carry a condition, mutation @if(...) { ... }, which is stored in pb.Mutation#Cond. The
+// iterator must be positioned at the upsert keyword.
+func parseUpsertBlock(it *lex.ItemIterator) (*pb.Mutation, error) {
+	if !it.Next() || it.Item().Typ != itemLeftCurl {
+		return nil, x.Errorf("Expected { after the upsert keyword.")
+	}
+
+	var mu *pb.Mutation
+	var queryText string
+	for it.Next() {
+		item := it.Item()
+		switch item.Typ {
+		case itemRightCurl:
+			if len(queryText) == 0 {
+				return nil, x.Errorf("Query op not found in upsert block.")
+			}
+			if mu == nil {
+				return nil, x.Errorf("Mutation op not found in upsert block.")
+			}
+			mu.Query = queryText
+			return mu, nil
+
+		case itemUpsertBlockOp:
+			op := item.Val
+			var cond string
+			if it.Next() && it.Item().Typ == itemUpsertBlockOpCond {
+				if op != "mutation" {
+					return nil, x.Errorf("Condition not allowed on %s op in upsert block.", op)
+				}
+				cond = it.Item().Val
+				if _, err := ParseUpsertCond(cond); err != nil {
+					return nil, err
+				}
+				it.Next()
+			}
+			if it.Item().Typ != itemUpsertBlockOpContent {
+				return nil, x.Errorf("Expected { after %s op in upsert block.", op)
+			}
+			content := it.Item().Val
+
+			switch op {
+			case "query":
+				if len(queryText) > 0 {
+					return nil, x.Errorf("Multiple query ops inside upsert block.")
+				}
+				queryText = content
+			case "mutation":
+				if mu != nil {
+					return nil, x.Errorf("Multiple mutation ops inside upsert block.")
+				}
+				m, err := parseUpsertMutation(content)
+				if err != nil {
+					return nil, err
+				}
+				mu = &pb.Mutation{Mutation: m, Cond: cond}
+			default:
+				return nil, x.Errorf("Invalid op %s inside upsert block.", op)
+			}
+
+		case lex.ItemError:
+			return nil, errors.New(item.Val)
+
+		default:
+			return nil, x.Errorf("Unexpected %s inside upsert block.", item.Val)
+		}
+	}
+	return nil, x.Errorf("Invalid upsert block.")
+}
+
+// ParseUpsertCond parses the condition of the mutation op of an upsert block, e.g.
+// @if(eq(len(v), 0) AND gt(len(w), 1)), into a filter tree. The functions eq, le, lt, ge and
+// gt may be used to compare the number of elements in a query variable, given by len(v), with
+// an integer, and they may be combined with AND, OR and NOT.
+func ParseUpsertCond(cond string) (*FilterTree, error) {
+	// The condition is lexed as a directive inside a block.
+	lexer := lex.NewLexer("{" + cond + "}")
+	lexer.Run(lexTopLevel)
+	if err := lexer.ValidateResult(); err != nil {
+		return nil, err
+	}
+	it := lexer.NewIterator()
+
+	expect := func(typ lex.ItemType, val string) bool {
+		return it.Next() && it.Item().Typ == typ && (val == "" || it.Item().Val == val)
+	}
+	if !expect(itemLeftCurl, "") || !expect(itemAt, "") || !expect(itemName, "if") {
+		return nil, x.Errorf("Invalid condition %s in upsert block, expected @if(...).", cond)
+	}
+	ft, err := parseFilter(it)
+	if err != nil {
+		return nil, err
+	}
+	if !expect(itemRightCurl, "") {
+		return nil, x.Errorf("Unexpected %s after the condition in upsert block.", it.Item().Val)
+	}
+	if err := ft.validateUpsertCond(); err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// validateUpsertCond checks that every function in the condition of an upsert block compares
+// len() of a query variable with an integer.
+func (f *FilterTree) validateUpsertCond() error {
+	if f.Func != nil {
+		if !f.Func.IsLenVar || !isInequalityFn(f.Func.Name) {
+			return x.Errorf("Only eq, le, lt, ge and gt on len() of a variable are allowed "+
+				"in the condition of an upsert block. Got: %s", f.Func.Name)
+		}
+		if len(f.Func.Args) != 1 {
+			return x.Errorf("Expected one argument to compare len(%s) with. Got: %d",
+				f.Func.Attr, len(f.Func.Args))
+		}
+		if _, err := strconv.ParseInt(f.Func.Args[0].Value, 0, 64); err != nil {
+			return x.Errorf("Expected an integer to compare len(%s) with. Got: %s",
+				f.Func.Attr, f.Func.Args[0].Value)
+		}
+	}
+	for _, ch := range f.Child {
+		if err := ch.validateUpsertCond(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CondVars returns the names of the query variables used by the condition of an upsert block.
+func (f *FilterTree) CondVars() []string {
+	var v Vars
+	f.collectVars(&v)
+	return x.RemoveDuplicates(v.Needs)
+}
+
+// parseUpsertMutation parses the text of the mutation op of an upsert block.
+func parseUpsertMutation(mutation string) (*api.Mutation, error) {
+	lexer := lex.NewLexer(mutation)
+	lexer.Run(lexInsideMutation)
+	it := lexer.NewIterator()
+
+	if !it.Next() || it.Item().Typ != itemLeftCurl {
+		return nil, x.Errorf("Expected { at the start of mutation op in upsert block.")
+	}
+	return parseMutationBlock(it)
+}
+
 // parseMutationOp parses and stores set or delete operation string in Mutation.
 func parseMutationOp(it *lex.ItemIterator, op string, mu *api.Mutation) error {
 	parse := false
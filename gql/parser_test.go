@@ -2019,6 +2019,26 @@ func TestParseFilter_simplest(t *testing.T) {
 	require.Equal(t, `(namefilter name "a")`, res.Query[0].Children[0].Children[0].Filter.debugString())
 }
 
+func TestParseFilter_between(t *testing.T) {
+	query := `
+	query {
+		me(func: between(age, 18, 30)) {
+			friends @filter(between(dob, "1909-01-01", "1910-01-01")) {
+				name
+			}
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.NotNil(t, res.Query[0])
+	require.Equal(t, "between", res.Query[0].Func.Name)
+	require.Equal(t, "age", res.Query[0].Func.Attr)
+	require.Equal(t, []Arg{{Value: "18"}, {Value: "30"}}, res.Query[0].Func.Args)
+	require.Equal(t, `(between dob "1909-01-01" "1910-01-01")`,
+		res.Query[0].Children[0].Filter.debugString())
+}
+
 // Test operator precedence. and should be evaluated before or.
 func TestParseFilter_op(t *testing.T) {
 	query := `
@@ -4008,7 +4028,7 @@ func TestEqUidFunctionErr(t *testing.T) {
 	`
 	_, err := Parse(Request{Str: query})
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "Only val/count allowed as function within another. Got: uid")
+	require.Contains(t, err.Error(), "Only val/count/len allowed as function within another. Got: uid")
 }
 
 func TestAggRoot1(t *testing.T) {
@@ -4336,7 +4356,7 @@ func TestParseMutation(t *testing.T) {
 	mu, err := ParseMutation(m)
 	require.NoError(t, err)
 	require.NotNil(t, mu)
-	sets, err := parseNquads(mu.SetNquads)
+	sets, err := parseNquads(mu.Mutation.SetNquads)
 	require.NoError(t, err)
 	require.EqualValues(t, &api.NQuad{
 		Subject: "name", Predicate: "is", ObjectId: "something"},
@@ -4344,7 +4364,7 @@ func TestParseMutation(t *testing.T) {
 	require.EqualValues(t, &api.NQuad{
 		Subject: "hometown", Predicate: "is", ObjectId: "san/francisco"},
 		sets[1])
-	dels, err := parseNquads(mu.DelNquads)
+	dels, err := parseNquads(mu.Mutation.DelNquads)
 	require.NoError(t, err)
 	require.EqualValues(t, &api.NQuad{
 		Subject: "name", Predicate: "is", ObjectId: "something-else"},
@@ -4385,6 +4405,149 @@ func TestParseMutationTooManyBlocks(t *testing.T) {
 	}
 }
 
+func TestParseUpsertBlock(t *testing.T) {
+	m := `
+		upsert {
+			# query finds the existing node, if any
+			query {
+				me(func: eq(email, "someone@dgraph.io")) {
+					v as uid
+				}
+			}
+
+			mutation {
+				set {
+					uid(v) <name> "Some One" .
+					uid(v) <email> "someone@dgraph.io" .
+				}
+			}
+		}
+	`
+	mu, err := ParseMutation(m)
+	require.NoError(t, err)
+	require.NotNil(t, mu)
+	require.Contains(t, mu.Query, `me(func: eq(email, "someone@dgraph.io"))`)
+	sets, err := parseNquads(mu.Mutation.SetNquads)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(sets))
+	require.Equal(t, "uid(v)", sets[0].Subject)
+
+	r, err := ParseWithNeedVars(Request{Str: mu.Query}, []string{"v"})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Query))
+
+	_, err = Parse(Request{Str: mu.Query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "defined but not used")
+}
+
+func TestParseUpsertBlockErrors(t *testing.T) {
+	tests := []struct {
+		m      string
+		errStr string
+	}{
+		{m: `upsert { mutation { set { _:a <name> "a" . } } }`,
+			errStr: "Query op not found in upsert block",
+		},
+		{m: `upsert { query { me(func: uid(1)) { uid } } }`,
+			errStr: "Mutation op not found in upsert block",
+		},
+		{m: `upsert {
+				query { me(func: uid(1)) { v as uid } }
+				query { me(func: uid(2)) { w as uid } }
+				mutation { set { uid(v) <name> "a" . } }
+			}`,
+			errStr: "Multiple query ops inside upsert block",
+		},
+		{m: `upsert {
+				query { me(func: uid(1)) { v as uid } }
+				schema { name }
+			}`,
+			errStr: "Invalid op schema inside upsert block",
+		},
+		{m: `upsert {
+				query { me(func: uid(1)) { v as uid } }
+				mutation { set { uid(v) <name> "a" . } }
+			} { set { _:a <name> "a" . } }`,
+			errStr: "after the end of the block",
+		},
+		{m: `upsert {
+				query { me(func: uid(1)) { v as uid } }
+				mutation { set { uid(v) <name> "a" . } }`,
+			errStr: "Unclosed upsert block",
+		},
+	}
+	for _, tc := range tests {
+		mu, err := ParseMutation(tc.m)
+		require.Error(t, err, tc.m)
+		require.Contains(t, err.Error(), tc.errStr)
+		require.Nil(t, mu)
+	}
+}
+
+func TestParseUpsertBlockWithCond(t *testing.T) {
+	m := `
+		upsert {
+			query {
+				me(func: eq(email, "someone@dgraph.io")) {
+					v as uid
+				}
+			}
+
+			mutation @if(eq(len(v), 0) OR (gt(len(v), 1) AND NOT lt(len(v), 3))) {
+				set {
+					_:a <email> "someone@dgraph.io" .
+				}
+			}
+		}
+	`
+	mu, err := ParseMutation(m)
+	require.NoError(t, err)
+	require.Equal(t, "@if(eq(len(v), 0) OR (gt(len(v), 1) AND NOT lt(len(v), 3)))", mu.Cond)
+
+	cond, err := ParseUpsertCond(mu.Cond)
+	require.NoError(t, err)
+	require.Equal(t, "(OR (eq v \"0\") (AND (gt v \"1\") (NOT (lt v \"3\"))))",
+		cond.debugString())
+	require.Equal(t, []string{"v"}, cond.CondVars())
+	require.True(t, cond.Child[0].Func.IsLenVar)
+
+	_, err = ParseWithNeedVars(Request{Str: mu.Query}, cond.CondVars())
+	require.NoError(t, err)
+}
+
+func TestParseUpsertCondErrors(t *testing.T) {
+	tests := []struct {
+		cond   string
+		errStr string
+	}{
+		{cond: `@filter(eq(len(v), 0))`, errStr: "expected @if(...)"},
+		{cond: `@if(eq(name, "a"))`, errStr: "Only eq, le, lt, ge and gt on len()"},
+		{cond: `@if(has(len(v)))`, errStr: "Only eq, le, lt, ge and gt on len()"},
+		{cond: `@if(eq(len(v), "a"))`, errStr: "Expected an integer"},
+		{cond: `@if(eq(len(v), 1, 2))`, errStr: "Expected one argument"},
+		{cond: `@if(eq(len(v), 0)`, errStr: "while lexing"},
+	}
+	for _, tc := range tests {
+		_, err := ParseUpsertCond(tc.cond)
+		require.Error(t, err, tc.cond)
+		require.Contains(t, err.Error(), tc.errStr, tc.cond)
+	}
+
+	_, err := ParseMutation(`upsert {
+			query @if(eq(len(v), 0)) { me(func: uid(1)) { v as uid } }
+			mutation { set { uid(v) <name> "a" . } }
+		}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Condition not allowed on query op")
+
+	_, err = ParseMutation(`upsert {
+			query { me(func: uid(1)) { v as uid } }
+			mutation @if(eq(len(v), 0) { set { uid(v) <name> "a" . } }
+		}`)
+	require.Error(t, err)
+}
+
 func TestParseMissingGraphQLVar(t *testing.T) {
 	for _, q := range []string{
 		"{ q(func: eq(name, $a)) { name }}",
@@ -4650,11 +4813,38 @@ func TestTypeInPredicate(t *testing.T) {
 	require.Equal(t, "type", gq.Query[0].Children[0].Filter.Func.Name)
 	require.Equal(t, 1, len(gq.Query[0].Children[0].Filter.Func.Args))
 	require.Equal(t, "Person", gq.Query[0].Children[0].Filter.Func.Args[0].Value)
+	require.Equal(t, "Person", gq.Query[0].Children[0].Type)
 
 	require.Equal(t, 1, len(gq.Query[0].Children[0].Children))
 	require.Equal(t, "name", gq.Query[0].Children[0].Children[0].Attr)
 }
 
+func TestTypeDirectiveWithFilter(t *testing.T) {
+	q := `
+	query {
+		me(func: uid(0x01)) {
+			friend @filter(has(name)) @type(Person) {
+				name
+			}
+		}
+	}`
+	_, err := Parse(Request{Str: q})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "instead of using both filter and type directives")
+
+	q = `
+	query {
+		me(func: uid(0x01)) {
+			friend @type(Person) @type(Animal) {
+				name
+			}
+		}
+	}`
+	_, err = Parse(Request{Str: q})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only one type directive allowed")
+}
+
 func TestMultipleTypeDirectives(t *testing.T) {
 	q := `
 	query {
@@ -64,8 +64,127 @@ const (
 	itemRightSquare
 	itemComma
 	itemMathOp
+	itemUpsertBlock          // upsert keyword
+	itemUpsertBlockOp        // query or mutation inside an upsert block
+	itemUpsertBlockOpContent // text of a query or mutation inside an upsert block
+	itemUpsertBlockOpCond    // @if condition of a mutation inside an upsert block
 )
 
+// lexIdentifyBlock lexes the start of a mutation request. Requests starting with the
+// upsert keyword are lexed as an upsert block, everything else as a plain mutation block.
+func lexIdentifyBlock(l *lex.Lexer) lex.StateFn {
+	l.Mode = lexIdentifyBlock
+	for {
+		switch r := l.Next(); {
+		case isSpace(r) || lex.IsEndOfLine(r):
+			l.Ignore()
+		case r == '#':
+			return lexComment
+		case isNameBegin(r):
+			l.Backup()
+			end := l.Pos
+			for end < len(l.Input) && isNameSuffix(rune(l.Input[end])) {
+				end++
+			}
+			if l.Input[l.Pos:end] != "upsert" {
+				return lexInsideMutation
+			}
+			l.AcceptRun(isNameSuffix)
+			l.Emit(itemUpsertBlock)
+			return lexUpsertBlock
+		default:
+			l.Backup()
+			return lexInsideMutation
+		}
+	}
+}
+
+// lexUpsertBlock lexes the body of an upsert block. The text of every operation inside
+// the block is emitted as a whole and parsed separately.
+func lexUpsertBlock(l *lex.Lexer) lex.StateFn {
+	l.Mode = lexUpsertBlock
+	for {
+		switch r := l.Next(); {
+		case r == rightCurl:
+			l.Depth--
+			l.Emit(itemRightCurl)
+			if l.Depth == 0 {
+				return lexTopLevel
+			}
+		case r == leftCurl:
+			if l.Depth > 0 {
+				l.Backup()
+				return lexUpsertBlockContent
+			}
+			l.Depth++
+			l.Emit(itemLeftCurl)
+		case isSpace(r) || lex.IsEndOfLine(r):
+			l.Ignore()
+		case isNameBegin(r):
+			l.AcceptRun(isNameSuffix)
+			l.Emit(itemUpsertBlockOp)
+		case r == at:
+			return lexUpsertBlockOpCond
+		case r == '#':
+			return lexComment
+		case r == lex.EOF:
+			return l.Errorf("Unclosed upsert block")
+		default:
+			return l.Errorf("Unrecognized character inside upsert block: %#U", r)
+		}
+	}
+}
+
+// lexUpsertBlockContent absorbs the text of an operation inside an upsert block along
+// with its enclosing braces.
+func lexUpsertBlockContent(l *lex.Lexer) lex.StateFn {
+	depth := 0
+	for {
+		switch r := l.Next(); {
+		case r == lex.EOF:
+			return l.Errorf("Unclosed block inside upsert block")
+		case r == quote:
+			if err := l.LexQuotedString(); err != nil {
+				return l.Errorf("%v", err)
+			}
+		case r == leftCurl:
+			depth++
+		case r == rightCurl:
+			depth--
+			if depth == 0 {
+				l.Emit(itemUpsertBlockOpContent)
+				return lexUpsertBlock
+			}
+		}
+	}
+}
+
+// lexUpsertBlockOpCond absorbs the condition of an operation inside an upsert block, i.e.
+// @if(...), up to and including its closing round bracket.
+func lexUpsertBlockOpCond(l *lex.Lexer) lex.StateFn {
+	depth := 0
+	for {
+		switch r := l.Next(); {
+		case r == lex.EOF:
+			return l.Errorf("Unclosed condition inside upsert block")
+		case r == quote:
+			if err := l.LexQuotedString(); err != nil {
+				return l.Errorf("%v", err)
+			}
+		case r == leftRound:
+			depth++
+		case r == rightRound:
+			depth--
+			if depth <= 0 {
+				l.Emit(itemUpsertBlockOpCond)
+				return lexUpsertBlock
+			}
+		case r == leftCurl || r == rightCurl:
+			return l.Errorf("Unexpected %#U in condition inside upsert block", r)
+		}
+	}
+}
+
 func lexInsideMutation(l *lex.Lexer) lex.StateFn {
 	l.Mode = lexInsideMutation
 	for {
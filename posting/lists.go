@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"os/exec"
 	"runtime"
@@ -174,16 +175,29 @@ func GetNoStore(key []byte) (rlist *List, err error) {
 	return getNew(key, pstore)
 }
 
+// GetNoStoreAt is like GetNoStore, but reads the list from the versions at or below readTs.
+func GetNoStoreAt(key []byte, readTs uint64) (rlist *List, err error) {
+	return getNewAt(key, pstore, readTs)
+}
+
 // This doesn't sync, so call this only when you don't care about dirty posting lists in
 // memory(for example before populating snapshot) or after calling syncAllMarks
 type LocalCache struct {
 	sync.RWMutex
 
+	// The lists are read from the versions at or below readTs.
+	readTs uint64
 	plists map[string]*List
 }
 
 func NewLocalCache() *LocalCache {
-	return &LocalCache{plists: make(map[string]*List)}
+	return NewLocalCacheAt(math.MaxUint64)
+}
+
+// NewLocalCacheAt returns a cache reading the lists from the versions at or below readTs, instead
+// of from their latest version.
+func NewLocalCacheAt(readTs uint64) *LocalCache {
+	return &LocalCache{readTs: readTs, plists: make(map[string]*List)}
 }
 
 func (lc *LocalCache) getNoStore(key string) *List {
@@ -214,7 +228,7 @@ func (lc *LocalCache) Get(key []byte) (*List, error) {
 		return pl, nil
 	}
 
-	pl, err := getNew(key, pstore)
+	pl, err := getNewAt(key, pstore, lc.readTs)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +69,7 @@ func (txn *Txn) Fill(ctx *api.TxnContext, gid uint32) {
 		// We don'txn need to send the whole conflict key to Zero. Solving #2338
 		// should be done by sending a list of mutating predicates to Zero,
 		// along with the keys to be used for conflict detection.
-		fps := strconv.FormatUint(farm.Fingerprint64([]byte(key)), 36)
+		fps := conflictFingerprint(key)
 		if !x.HasString(ctx.Keys, fps) {
 			ctx.Keys = append(ctx.Keys, fps)
 		}
@@ -85,6 +85,17 @@ func (txn *Txn) Fill(ctx *api.TxnContext, gid uint32) {
 	}
 }
 
+func conflictFingerprint(conflictKey string) string {
+	return strconv.FormatUint(farm.Fingerprint64([]byte(conflictKey)), 36)
+}
+
+// ReadConflictKey returns the conflict key sent to Zero for a read of the data key, so that the
+// transaction aborts if another one writes a scalar value of the key or reads it the same way
+// concurrently. The writes of uid values have a key per uid, which the read doesn't cover.
+func ReadConflictKey(key []byte) string {
+	return conflictFingerprint(fmt.Sprintf("%s|%d", key, 0))
+}
+
 // Don't call this for schema mutations. Directly commit them.
 // This function only stores deltas to the commit timestamps. It does not try to generate a state.
 // State generation is done via rollups, which happen when a snapshot is created.
@@ -243,7 +254,12 @@ func ReadPostingList(key []byte, it *badger.Iterator) (*List, error) {
 
 // TODO: We should only create a posting list with a specific readTs.
 func getNew(key []byte, pstore *badger.DB) (*List, error) {
-	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	return getNewAt(key, pstore, math.MaxUint64)
+}
+
+// getNewAt reads the posting list of the key from the versions at or below readTs.
+func getNewAt(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
+	txn := pstore.NewTransactionAt(readTs, false)
 	defer txn.Discard()
 
 	// When we do rollups, an older version would go to the top of the LSM tree, which can cause
@@ -96,6 +96,10 @@ type oracle struct {
 	// Used for waiting logic for transactions with startTs > maxpending so that we don't read an
 	// uncommitted transaction.
 	waiters map[uint64][]chan struct{}
+
+	// Lowest timestamp data can be read at. Versions below it might have been rolled up and
+	// discarded. Do not use mutex on this, only use atomics.
+	minReadTs uint64
 }
 
 func (o *oracle) init() {
@@ -222,6 +226,31 @@ func (o *oracle) ProcessDelta(delta *pb.OracleDelta) {
 	x.AssertTrue(atomic.CompareAndSwapUint64(&o.maxAssigned, curMax, delta.MaxAssigned))
 }
 
+// SetMinReadTs sets the lowest timestamp data can be read at. It's called once versions below
+// ts can be discarded, so it never goes back.
+func (o *oracle) SetMinReadTs(ts uint64) {
+	for {
+		min := o.MinReadTs()
+		if ts <= min || atomic.CompareAndSwapUint64(&o.minReadTs, min, ts) {
+			return
+		}
+	}
+}
+
+// MinReadTs returns the lowest timestamp data can be read at.
+func (o *oracle) MinReadTs() uint64 {
+	return atomic.LoadUint64(&o.minReadTs)
+}
+
+// CheckReadTs returns an error if the data at readTs might not be available anymore.
+func (o *oracle) CheckReadTs(readTs uint64) error {
+	if min := o.MinReadTs(); readTs < min {
+		return x.Errorf("Data at timestamp %d has been compacted away. "+
+			"The lowest timestamp that can be read at is %d.", readTs, min)
+	}
+	return nil
+}
+
 func (o *oracle) ResetTxns() {
 	o.Lock()
 	defer o.Unlock()
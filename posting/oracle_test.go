@@ -0,0 +1,38 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinReadTs(t *testing.T) {
+	o := new(oracle)
+	o.init()
+	require.NoError(t, o.CheckReadTs(1))
+
+	o.SetMinReadTs(35)
+	require.Equal(t, uint64(35), o.MinReadTs())
+	require.NoError(t, o.CheckReadTs(35))
+	require.Error(t, o.CheckReadTs(34))
+
+	// It never goes back.
+	o.SetMinReadTs(20)
+	require.Equal(t, uint64(35), o.MinReadTs())
+}
@@ -48,7 +48,7 @@ func (x DirectedEdge_Op) String() string {
 }
 
 func (DirectedEdge_Op) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{17, 0}
+	return fileDescriptor_f80abaa17e25ccc8, []int{18, 0}
 }
 
 type Mutations_DropOp int32
@@ -79,7 +79,7 @@ func (x Mutations_DropOp) String() string {
 }
 
 func (Mutations_DropOp) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{18, 0}
+	return fileDescriptor_f80abaa17e25ccc8, []int{19, 0}
 }
 
 type Posting_ValType int32
@@ -131,7 +131,7 @@ func (x Posting_ValType) String() string {
 }
 
 func (Posting_ValType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{22, 0}
+	return fileDescriptor_f80abaa17e25ccc8, []int{23, 0}
 }
 
 type Posting_PostingType int32
@@ -159,7 +159,7 @@ func (x Posting_PostingType) String() string {
 }
 
 func (Posting_PostingType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{22, 1}
+	return fileDescriptor_f80abaa17e25ccc8, []int{23, 1}
 }
 
 type SchemaUpdate_Directive int32
@@ -190,7 +190,38 @@ func (x SchemaUpdate_Directive) String() string {
 }
 
 func (SchemaUpdate_Directive) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{34, 0}
+	return fileDescriptor_f80abaa17e25ccc8, []int{35, 0}
+}
+
+type IngestPredicate_Op int32
+
+const (
+	IngestPredicate_START IngestPredicate_Op = 0
+	IngestPredicate_DATA  IngestPredicate_Op = 1
+	IngestPredicate_DONE  IngestPredicate_Op = 2
+	IngestPredicate_ABORT IngestPredicate_Op = 3
+)
+
+var IngestPredicate_Op_name = map[int32]string{
+	0: "START",
+	1: "DATA",
+	2: "DONE",
+	3: "ABORT",
+}
+
+var IngestPredicate_Op_value = map[string]int32{
+	"START": 0,
+	"DATA":  1,
+	"DONE":  2,
+	"ABORT": 3,
+}
+
+func (x IngestPredicate_Op) String() string {
+	return proto.EnumName(IngestPredicate_Op_name, int32(x))
+}
+
+func (IngestPredicate_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{39, 0}
 }
 
 type List struct {
@@ -878,6 +909,7 @@ type RaftContext struct {
 	Group                uint32   `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
 	Addr                 string   `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`
 	SnapshotTs           uint64   `protobuf:"varint,4,opt,name=snapshot_ts,json=snapshotTs,proto3" json:"snapshot_ts,omitempty"`
+	IsLearner            bool     `protobuf:"varint,5,opt,name=is_learner,json=isLearner,proto3" json:"is_learner,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -944,17 +976,26 @@ func (m *RaftContext) GetSnapshotTs() uint64 {
 	return 0
 }
 
+func (m *RaftContext) GetIsLearner() bool {
+	if m != nil {
+		return m.IsLearner
+	}
+	return false
+}
+
 // Member stores information about RAFT group member for a single RAFT node.
 // Note that each server can be serving multiple RAFT groups. Each group would have
 // one RAFT node per server serving that group.
 type Member struct {
-	Id                   uint64   `protobuf:"fixed64,1,opt,name=id,proto3" json:"id,omitempty"`
-	GroupId              uint32   `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	Addr                 string   `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`
-	Leader               bool     `protobuf:"varint,4,opt,name=leader,proto3" json:"leader,omitempty"`
-	AmDead               bool     `protobuf:"varint,5,opt,name=am_dead,json=amDead,proto3" json:"am_dead,omitempty"`
-	LastUpdate           uint64   `protobuf:"varint,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
-	ClusterInfoOnly      bool     `protobuf:"varint,13,opt,name=cluster_info_only,json=clusterInfoOnly,proto3" json:"cluster_info_only,omitempty"`
+	Id              uint64 `protobuf:"fixed64,1,opt,name=id,proto3" json:"id,omitempty"`
+	GroupId         uint32 `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Addr            string `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`
+	Leader          bool   `protobuf:"varint,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	AmDead          bool   `protobuf:"varint,5,opt,name=am_dead,json=amDead,proto3" json:"am_dead,omitempty"`
+	LastUpdate      uint64 `protobuf:"varint,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	ClusterInfoOnly bool   `protobuf:"varint,13,opt,name=cluster_info_only,json=clusterInfoOnly,proto3" json:"cluster_info_only,omitempty"`
+	// A learner receives the Raft log of its group, but doesn't vote or become the leader.
+	Learner              bool     `protobuf:"varint,14,opt,name=learner,proto3" json:"learner,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1042,6 +1083,13 @@ func (m *Member) GetClusterInfoOnly() bool {
 	return false
 }
 
+func (m *Member) GetLearner() bool {
+	if m != nil {
+		return m.Learner
+	}
+	return false
+}
+
 type Group struct {
 	Members              map[uint64]*Member `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Tablets              map[string]*Tablet `protobuf:"bytes,2,rep,name=tablets,proto3" json:"tablets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -1123,6 +1171,7 @@ type ZeroProposal struct {
 	Txn                  *api.TxnContext   `protobuf:"bytes,7,opt,name=txn,proto3" json:"txn,omitempty"`
 	Key                  string            `protobuf:"bytes,8,opt,name=key,proto3" json:"key,omitempty"`
 	Cid                  string            `protobuf:"bytes,9,opt,name=cid,proto3" json:"cid,omitempty"`
+	TsAt                 *TimestampAt      `protobuf:"bytes,10,opt,name=ts_at,json=tsAt,proto3" json:"ts_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -1224,21 +1273,30 @@ func (m *ZeroProposal) GetCid() string {
 	return ""
 }
 
+func (m *ZeroProposal) GetTsAt() *TimestampAt {
+	if m != nil {
+		return m.TsAt
+	}
+	return nil
+}
+
 // MembershipState is used to pack together the current membership state of all the nodes
 // in the caller server; and the membership updates recorded by the callee server since
 // the provided lastUpdate.
 type MembershipState struct {
-	Counter              uint64             `protobuf:"varint,1,opt,name=counter,proto3" json:"counter,omitempty"`
-	Groups               map[uint32]*Group  `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Zeros                map[uint64]*Member `protobuf:"bytes,3,rep,name=zeros,proto3" json:"zeros,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	MaxLeaseId           uint64             `protobuf:"varint,4,opt,name=maxLeaseId,proto3" json:"maxLeaseId,omitempty"`
-	MaxTxnTs             uint64             `protobuf:"varint,5,opt,name=maxTxnTs,proto3" json:"maxTxnTs,omitempty"`
-	MaxRaftId            uint64             `protobuf:"varint,6,opt,name=maxRaftId,proto3" json:"maxRaftId,omitempty"`
-	Removed              []*Member          `protobuf:"bytes,7,rep,name=removed,proto3" json:"removed,omitempty"`
-	Cid                  string             `protobuf:"bytes,8,opt,name=cid,proto3" json:"cid,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Counter    uint64             `protobuf:"varint,1,opt,name=counter,proto3" json:"counter,omitempty"`
+	Groups     map[uint32]*Group  `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Zeros      map[uint64]*Member `protobuf:"bytes,3,rep,name=zeros,proto3" json:"zeros,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MaxLeaseId uint64             `protobuf:"varint,4,opt,name=maxLeaseId,proto3" json:"maxLeaseId,omitempty"`
+	MaxTxnTs   uint64             `protobuf:"varint,5,opt,name=maxTxnTs,proto3" json:"maxTxnTs,omitempty"`
+	MaxRaftId  uint64             `protobuf:"varint,6,opt,name=maxRaftId,proto3" json:"maxRaftId,omitempty"`
+	Removed    []*Member          `protobuf:"bytes,7,rep,name=removed,proto3" json:"removed,omitempty"`
+	Cid        string             `protobuf:"bytes,8,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Max assigned timestamps over time, in increasing order. Only kept by Zero.
+	TsLog                []*TimestampAt `protobuf:"bytes,9,rep,name=ts_log,json=tsLog,proto3" json:"ts_log,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *MembershipState) Reset()         { *m = MembershipState{} }
@@ -1330,6 +1388,70 @@ func (m *MembershipState) GetCid() string {
 	return ""
 }
 
+func (m *MembershipState) GetTsLog() []*TimestampAt {
+	if m != nil {
+		return m.TsLog
+	}
+	return nil
+}
+
+// TimestampAt maps a wall-clock time, in Unix nanoseconds, to the max assigned timestamp at that
+// time.
+type TimestampAt struct {
+	At                   int64    `protobuf:"varint,1,opt,name=at,proto3" json:"at,omitempty"`
+	Ts                   uint64   `protobuf:"varint,2,opt,name=ts,proto3" json:"ts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TimestampAt) Reset()         { *m = TimestampAt{} }
+func (m *TimestampAt) String() string { return proto.CompactTextString(m) }
+func (*TimestampAt) ProtoMessage()    {}
+func (*TimestampAt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{15}
+}
+func (m *TimestampAt) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimestampAt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimestampAt.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TimestampAt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimestampAt.Merge(m, src)
+}
+func (m *TimestampAt) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimestampAt) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimestampAt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimestampAt proto.InternalMessageInfo
+
+func (m *TimestampAt) GetAt() int64 {
+	if m != nil {
+		return m.At
+	}
+	return 0
+}
+
+func (m *TimestampAt) GetTs() uint64 {
+	if m != nil {
+		return m.Ts
+	}
+	return 0
+}
+
 type ConnectionState struct {
 	Member               *Member          `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
 	State                *MembershipState `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
@@ -1343,7 +1465,7 @@ func (m *ConnectionState) Reset()         { *m = ConnectionState{} }
 func (m *ConnectionState) String() string { return proto.CompactTextString(m) }
 func (*ConnectionState) ProtoMessage()    {}
 func (*ConnectionState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{15}
+	return fileDescriptor_f80abaa17e25ccc8, []int{16}
 }
 func (m *ConnectionState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1409,7 +1531,7 @@ func (m *Tablet) Reset()         { *m = Tablet{} }
 func (m *Tablet) String() string { return proto.CompactTextString(m) }
 func (*Tablet) ProtoMessage()    {}
 func (*Tablet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{16}
+	return fileDescriptor_f80abaa17e25ccc8, []int{17}
 }
 func (m *Tablet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1499,7 +1621,7 @@ func (m *DirectedEdge) Reset()         { *m = DirectedEdge{} }
 func (m *DirectedEdge) String() string { return proto.CompactTextString(m) }
 func (*DirectedEdge) ProtoMessage()    {}
 func (*DirectedEdge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{17}
+	return fileDescriptor_f80abaa17e25ccc8, []int{18}
 }
 func (m *DirectedEdge) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1609,7 +1731,7 @@ func (m *Mutations) Reset()         { *m = Mutations{} }
 func (m *Mutations) String() string { return proto.CompactTextString(m) }
 func (*Mutations) ProtoMessage()    {}
 func (*Mutations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{18}
+	return fileDescriptor_f80abaa17e25ccc8, []int{19}
 }
 func (m *Mutations) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1709,7 +1831,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{19}
+	return fileDescriptor_f80abaa17e25ccc8, []int{20}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1775,6 +1897,7 @@ type Proposal struct {
 	Delta                *OracleDelta     `protobuf:"bytes,8,opt,name=delta,proto3" json:"delta,omitempty"`
 	Snapshot             *Snapshot        `protobuf:"bytes,9,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 	Index                uint64           `protobuf:"varint,10,opt,name=index,proto3" json:"index,omitempty"`
+	Ingest               *IngestPredicate `protobuf:"bytes,11,opt,name=ingest,proto3" json:"ingest,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -1784,7 +1907,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{20}
+	return fileDescriptor_f80abaa17e25ccc8, []int{21}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1869,20 +1992,29 @@ func (m *Proposal) GetIndex() uint64 {
 	return 0
 }
 
+func (m *Proposal) GetIngest() *IngestPredicate {
+	if m != nil {
+		return m.Ingest
+	}
+	return nil
+}
+
 type KVS struct {
 	Kv []*pb.KV `protobuf:"bytes,1,rep,name=kv,proto3" json:"kv,omitempty"`
 	// done used to indicate if the stream of KVS is over.
-	Done                 bool     `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Done bool `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	// Set in the first message of an IngestPredicate stream.
+	Ingest               *IngestPredicate `protobuf:"bytes,3,opt,name=ingest,proto3" json:"ingest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *KVS) Reset()         { *m = KVS{} }
 func (m *KVS) String() string { return proto.CompactTextString(m) }
 func (*KVS) ProtoMessage()    {}
 func (*KVS) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{21}
+	return fileDescriptor_f80abaa17e25ccc8, []int{22}
 }
 func (m *KVS) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1925,6 +2057,13 @@ func (m *KVS) GetDone() bool {
 	return false
 }
 
+func (m *KVS) GetIngest() *IngestPredicate {
+	if m != nil {
+		return m.Ingest
+	}
+	return nil
+}
+
 // Posting messages.
 type Posting struct {
 	Uid         uint64              `protobuf:"fixed64,1,opt,name=uid,proto3" json:"uid,omitempty"`
@@ -1947,7 +2086,7 @@ func (m *Posting) Reset()         { *m = Posting{} }
 func (m *Posting) String() string { return proto.CompactTextString(m) }
 func (*Posting) ProtoMessage()    {}
 func (*Posting) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{22}
+	return fileDescriptor_f80abaa17e25ccc8, []int{23}
 }
 func (m *Posting) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2061,7 +2200,7 @@ func (m *UidBlock) Reset()         { *m = UidBlock{} }
 func (m *UidBlock) String() string { return proto.CompactTextString(m) }
 func (*UidBlock) ProtoMessage()    {}
 func (*UidBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{23}
+	return fileDescriptor_f80abaa17e25ccc8, []int{24}
 }
 func (m *UidBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2116,7 +2255,7 @@ func (m *UidPack) Reset()         { *m = UidPack{} }
 func (m *UidPack) String() string { return proto.CompactTextString(m) }
 func (*UidPack) ProtoMessage()    {}
 func (*UidPack) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{24}
+	return fileDescriptor_f80abaa17e25ccc8, []int{25}
 }
 func (m *UidPack) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2173,7 +2312,7 @@ func (m *PostingList) Reset()         { *m = PostingList{} }
 func (m *PostingList) String() string { return proto.CompactTextString(m) }
 func (*PostingList) ProtoMessage()    {}
 func (*PostingList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{25}
+	return fileDescriptor_f80abaa17e25ccc8, []int{26}
 }
 func (m *PostingList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2242,7 +2381,7 @@ func (m *FacetParam) Reset()         { *m = FacetParam{} }
 func (m *FacetParam) String() string { return proto.CompactTextString(m) }
 func (*FacetParam) ProtoMessage()    {}
 func (*FacetParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{26}
+	return fileDescriptor_f80abaa17e25ccc8, []int{27}
 }
 func (m *FacetParam) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2297,7 +2436,7 @@ func (m *FacetParams) Reset()         { *m = FacetParams{} }
 func (m *FacetParams) String() string { return proto.CompactTextString(m) }
 func (*FacetParams) ProtoMessage()    {}
 func (*FacetParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{27}
+	return fileDescriptor_f80abaa17e25ccc8, []int{28}
 }
 func (m *FacetParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2351,7 +2490,7 @@ func (m *Facets) Reset()         { *m = Facets{} }
 func (m *Facets) String() string { return proto.CompactTextString(m) }
 func (*Facets) ProtoMessage()    {}
 func (*Facets) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{28}
+	return fileDescriptor_f80abaa17e25ccc8, []int{29}
 }
 func (m *Facets) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2398,7 +2537,7 @@ func (m *FacetsList) Reset()         { *m = FacetsList{} }
 func (m *FacetsList) String() string { return proto.CompactTextString(m) }
 func (*FacetsList) ProtoMessage()    {}
 func (*FacetsList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{29}
+	return fileDescriptor_f80abaa17e25ccc8, []int{30}
 }
 func (m *FacetsList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2447,7 +2586,7 @@ func (m *Function) Reset()         { *m = Function{} }
 func (m *Function) String() string { return proto.CompactTextString(m) }
 func (*Function) ProtoMessage()    {}
 func (*Function) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{30}
+	return fileDescriptor_f80abaa17e25ccc8, []int{31}
 }
 func (m *Function) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2511,7 +2650,7 @@ func (m *FilterTree) Reset()         { *m = FilterTree{} }
 func (m *FilterTree) String() string { return proto.CompactTextString(m) }
 func (*FilterTree) ProtoMessage()    {}
 func (*FilterTree) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{31}
+	return fileDescriptor_f80abaa17e25ccc8, []int{32}
 }
 func (m *FilterTree) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2577,7 +2716,7 @@ func (m *SchemaRequest) Reset()         { *m = SchemaRequest{} }
 func (m *SchemaRequest) String() string { return proto.CompactTextString(m) }
 func (*SchemaRequest) ProtoMessage()    {}
 func (*SchemaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{32}
+	return fileDescriptor_f80abaa17e25ccc8, []int{33}
 }
 func (m *SchemaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2645,7 +2784,7 @@ func (m *SchemaResult) Reset()         { *m = SchemaResult{} }
 func (m *SchemaResult) String() string { return proto.CompactTextString(m) }
 func (*SchemaResult) ProtoMessage()    {}
 func (*SchemaResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{33}
+	return fileDescriptor_f80abaa17e25ccc8, []int{34}
 }
 func (m *SchemaResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2706,7 +2845,7 @@ func (m *SchemaUpdate) Reset()         { *m = SchemaUpdate{} }
 func (m *SchemaUpdate) String() string { return proto.CompactTextString(m) }
 func (*SchemaUpdate) ProtoMessage()    {}
 func (*SchemaUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{34}
+	return fileDescriptor_f80abaa17e25ccc8, []int{35}
 }
 func (m *SchemaUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2815,6 +2954,7 @@ func (m *SchemaUpdate) GetObjectTypeName() string {
 type TypeUpdate struct {
 	TypeName             string          `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
 	Fields               []*SchemaUpdate `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Strict               bool            `protobuf:"varint,3,opt,name=strict,proto3" json:"strict,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -2824,7 +2964,7 @@ func (m *TypeUpdate) Reset()         { *m = TypeUpdate{} }
 func (m *TypeUpdate) String() string { return proto.CompactTextString(m) }
 func (*TypeUpdate) ProtoMessage()    {}
 func (*TypeUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{35}
+	return fileDescriptor_f80abaa17e25ccc8, []int{36}
 }
 func (m *TypeUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2867,6 +3007,13 @@ func (m *TypeUpdate) GetFields() []*SchemaUpdate {
 	return nil
 }
 
+func (m *TypeUpdate) GetStrict() bool {
+	if m != nil {
+		return m.Strict
+	}
+	return false
+}
+
 // Bulk loader proto.
 type MapEntry struct {
 	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -2882,7 +3029,7 @@ func (m *MapEntry) Reset()         { *m = MapEntry{} }
 func (m *MapEntry) String() string { return proto.CompactTextString(m) }
 func (*MapEntry) ProtoMessage()    {}
 func (*MapEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{36}
+	return fileDescriptor_f80abaa17e25ccc8, []int{37}
 }
 func (m *MapEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2946,7 +3093,7 @@ func (m *MovePredicatePayload) Reset()         { *m = MovePredicatePayload{} }
 func (m *MovePredicatePayload) String() string { return proto.CompactTextString(m) }
 func (*MovePredicatePayload) ProtoMessage()    {}
 func (*MovePredicatePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{37}
+	return fileDescriptor_f80abaa17e25ccc8, []int{38}
 }
 func (m *MovePredicatePayload) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3003,26 +3150,31 @@ func (m *MovePredicatePayload) GetTxnTs() uint64 {
 	return 0
 }
 
-type TxnStatus struct {
-	StartTs              uint64   `protobuf:"varint,1,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
-	CommitTs             uint64   `protobuf:"varint,2,opt,name=commit_ts,json=commitTs,proto3" json:"commit_ts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Ingestion of bulk loader output into a running cluster. The existing keys of the predicate are
+// deleted at ts, the new ones written at ts+1, and if the ingestion is aborted, the old ones are
+// written back at ts+2. DONE marks that all the keys have been written; the ingestion is then
+// committed or aborted by Zero like a transaction starting at ts.
+type IngestPredicate struct {
+	Predicate            string             `protobuf:"bytes,1,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	Ts                   uint64             `protobuf:"varint,2,opt,name=ts,proto3" json:"ts,omitempty"`
+	Op                   IngestPredicate_Op `protobuf:"varint,3,opt,name=op,proto3,enum=pb.IngestPredicate_Op" json:"op,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *TxnStatus) Reset()         { *m = TxnStatus{} }
-func (m *TxnStatus) String() string { return proto.CompactTextString(m) }
-func (*TxnStatus) ProtoMessage()    {}
-func (*TxnStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{38}
+func (m *IngestPredicate) Reset()         { *m = IngestPredicate{} }
+func (m *IngestPredicate) String() string { return proto.CompactTextString(m) }
+func (*IngestPredicate) ProtoMessage()    {}
+func (*IngestPredicate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{39}
 }
-func (m *TxnStatus) XXX_Unmarshal(b []byte) error {
+func (m *IngestPredicate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TxnStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *IngestPredicate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TxnStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_IngestPredicate.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3032,53 +3184,60 @@ func (m *TxnStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *TxnStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TxnStatus.Merge(m, src)
+func (m *IngestPredicate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestPredicate.Merge(m, src)
 }
-func (m *TxnStatus) XXX_Size() int {
+func (m *IngestPredicate) XXX_Size() int {
 	return m.Size()
 }
-func (m *TxnStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_TxnStatus.DiscardUnknown(m)
+func (m *IngestPredicate) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestPredicate.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TxnStatus proto.InternalMessageInfo
+var xxx_messageInfo_IngestPredicate proto.InternalMessageInfo
 
-func (m *TxnStatus) GetStartTs() uint64 {
+func (m *IngestPredicate) GetPredicate() string {
 	if m != nil {
-		return m.StartTs
+		return m.Predicate
 	}
-	return 0
+	return ""
 }
 
-func (m *TxnStatus) GetCommitTs() uint64 {
+func (m *IngestPredicate) GetTs() uint64 {
 	if m != nil {
-		return m.CommitTs
+		return m.Ts
 	}
 	return 0
 }
 
-type OracleDelta struct {
-	Txns                 []*TxnStatus      `protobuf:"bytes,1,rep,name=txns,proto3" json:"txns,omitempty"`
-	MaxAssigned          uint64            `protobuf:"varint,2,opt,name=max_assigned,json=maxAssigned,proto3" json:"max_assigned,omitempty"`
-	GroupChecksums       map[uint32]uint64 `protobuf:"bytes,3,rep,name=group_checksums,json=groupChecksums,proto3" json:"group_checksums,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *IngestPredicate) GetOp() IngestPredicate_Op {
+	if m != nil {
+		return m.Op
+	}
+	return IngestPredicate_START
 }
 
-func (m *OracleDelta) Reset()         { *m = OracleDelta{} }
-func (m *OracleDelta) String() string { return proto.CompactTextString(m) }
-func (*OracleDelta) ProtoMessage()    {}
-func (*OracleDelta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{39}
+type IngestRequest struct {
+	Predicates           []string `protobuf:"bytes,1,rep,name=predicates,proto3" json:"predicates,omitempty"`
+	Ts                   uint64   `protobuf:"varint,2,opt,name=ts,proto3" json:"ts,omitempty"`
+	Abort                bool     `protobuf:"varint,3,opt,name=abort,proto3" json:"abort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *OracleDelta) XXX_Unmarshal(b []byte) error {
+
+func (m *IngestRequest) Reset()         { *m = IngestRequest{} }
+func (m *IngestRequest) String() string { return proto.CompactTextString(m) }
+func (*IngestRequest) ProtoMessage()    {}
+func (*IngestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{40}
+}
+func (m *IngestRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *OracleDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *IngestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_OracleDelta.Marshal(b, m, deterministic)
+		return xxx_messageInfo_IngestRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3088,58 +3247,60 @@ func (m *OracleDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *OracleDelta) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OracleDelta.Merge(m, src)
+func (m *IngestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestRequest.Merge(m, src)
 }
-func (m *OracleDelta) XXX_Size() int {
+func (m *IngestRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *OracleDelta) XXX_DiscardUnknown() {
-	xxx_messageInfo_OracleDelta.DiscardUnknown(m)
+func (m *IngestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OracleDelta proto.InternalMessageInfo
+var xxx_messageInfo_IngestRequest proto.InternalMessageInfo
 
-func (m *OracleDelta) GetTxns() []*TxnStatus {
+func (m *IngestRequest) GetPredicates() []string {
 	if m != nil {
-		return m.Txns
+		return m.Predicates
 	}
 	return nil
 }
 
-func (m *OracleDelta) GetMaxAssigned() uint64 {
+func (m *IngestRequest) GetTs() uint64 {
 	if m != nil {
-		return m.MaxAssigned
+		return m.Ts
 	}
 	return 0
 }
 
-func (m *OracleDelta) GetGroupChecksums() map[uint32]uint64 {
+func (m *IngestRequest) GetAbort() bool {
 	if m != nil {
-		return m.GroupChecksums
+		return m.Abort
 	}
-	return nil
+	return false
 }
 
-type TxnTimestamps struct {
-	Ts                   []uint64 `protobuf:"varint,1,rep,packed,name=ts,proto3" json:"ts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type IngestState struct {
+	Ts                   uint64            `protobuf:"varint,1,opt,name=ts,proto3" json:"ts,omitempty"`
+	Tablets              map[string]uint32 `protobuf:"bytes,2,rep,name=tablets,proto3" json:"tablets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Leaders              map[uint32]string `protobuf:"bytes,3,rep,name=leaders,proto3" json:"leaders,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *TxnTimestamps) Reset()         { *m = TxnTimestamps{} }
-func (m *TxnTimestamps) String() string { return proto.CompactTextString(m) }
-func (*TxnTimestamps) ProtoMessage()    {}
-func (*TxnTimestamps) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{40}
+func (m *IngestState) Reset()         { *m = IngestState{} }
+func (m *IngestState) String() string { return proto.CompactTextString(m) }
+func (*IngestState) ProtoMessage()    {}
+func (*IngestState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{41}
 }
-func (m *TxnTimestamps) XXX_Unmarshal(b []byte) error {
+func (m *IngestState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TxnTimestamps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *IngestState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TxnTimestamps.Marshal(b, m, deterministic)
+		return xxx_messageInfo_IngestState.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3149,37 +3310,216 @@ func (m *TxnTimestamps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *TxnTimestamps) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TxnTimestamps.Merge(m, src)
+func (m *IngestState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestState.Merge(m, src)
 }
-func (m *TxnTimestamps) XXX_Size() int {
+func (m *IngestState) XXX_Size() int {
 	return m.Size()
 }
-func (m *TxnTimestamps) XXX_DiscardUnknown() {
-	xxx_messageInfo_TxnTimestamps.DiscardUnknown(m)
+func (m *IngestState) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestState.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TxnTimestamps proto.InternalMessageInfo
+var xxx_messageInfo_IngestState proto.InternalMessageInfo
 
-func (m *TxnTimestamps) GetTs() []uint64 {
+func (m *IngestState) GetTs() uint64 {
 	if m != nil {
 		return m.Ts
 	}
+	return 0
+}
+
+func (m *IngestState) GetTablets() map[string]uint32 {
+	if m != nil {
+		return m.Tablets
+	}
 	return nil
 }
 
-type PeerResponse struct {
-	Status               bool     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+func (m *IngestState) GetLeaders() map[uint32]string {
+	if m != nil {
+		return m.Leaders
+	}
+	return nil
+}
+
+type TxnStatus struct {
+	StartTs              uint64   `protobuf:"varint,1,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
+	CommitTs             uint64   `protobuf:"varint,2,opt,name=commit_ts,json=commitTs,proto3" json:"commit_ts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PeerResponse) Reset()         { *m = PeerResponse{} }
+func (m *TxnStatus) Reset()         { *m = TxnStatus{} }
+func (m *TxnStatus) String() string { return proto.CompactTextString(m) }
+func (*TxnStatus) ProtoMessage()    {}
+func (*TxnStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{42}
+}
+func (m *TxnStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxnStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxnStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxnStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnStatus.Merge(m, src)
+}
+func (m *TxnStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxnStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxnStatus proto.InternalMessageInfo
+
+func (m *TxnStatus) GetStartTs() uint64 {
+	if m != nil {
+		return m.StartTs
+	}
+	return 0
+}
+
+func (m *TxnStatus) GetCommitTs() uint64 {
+	if m != nil {
+		return m.CommitTs
+	}
+	return 0
+}
+
+type OracleDelta struct {
+	Txns                 []*TxnStatus      `protobuf:"bytes,1,rep,name=txns,proto3" json:"txns,omitempty"`
+	MaxAssigned          uint64            `protobuf:"varint,2,opt,name=max_assigned,json=maxAssigned,proto3" json:"max_assigned,omitempty"`
+	GroupChecksums       map[uint32]uint64 `protobuf:"bytes,3,rep,name=group_checksums,json=groupChecksums,proto3" json:"group_checksums,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *OracleDelta) Reset()         { *m = OracleDelta{} }
+func (m *OracleDelta) String() string { return proto.CompactTextString(m) }
+func (*OracleDelta) ProtoMessage()    {}
+func (*OracleDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{43}
+}
+func (m *OracleDelta) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OracleDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OracleDelta.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OracleDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OracleDelta.Merge(m, src)
+}
+func (m *OracleDelta) XXX_Size() int {
+	return m.Size()
+}
+func (m *OracleDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_OracleDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OracleDelta proto.InternalMessageInfo
+
+func (m *OracleDelta) GetTxns() []*TxnStatus {
+	if m != nil {
+		return m.Txns
+	}
+	return nil
+}
+
+func (m *OracleDelta) GetMaxAssigned() uint64 {
+	if m != nil {
+		return m.MaxAssigned
+	}
+	return 0
+}
+
+func (m *OracleDelta) GetGroupChecksums() map[uint32]uint64 {
+	if m != nil {
+		return m.GroupChecksums
+	}
+	return nil
+}
+
+type TxnTimestamps struct {
+	Ts                   []uint64 `protobuf:"varint,1,rep,packed,name=ts,proto3" json:"ts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxnTimestamps) Reset()         { *m = TxnTimestamps{} }
+func (m *TxnTimestamps) String() string { return proto.CompactTextString(m) }
+func (*TxnTimestamps) ProtoMessage()    {}
+func (*TxnTimestamps) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{44}
+}
+func (m *TxnTimestamps) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxnTimestamps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxnTimestamps.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxnTimestamps) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnTimestamps.Merge(m, src)
+}
+func (m *TxnTimestamps) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxnTimestamps) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnTimestamps.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxnTimestamps proto.InternalMessageInfo
+
+func (m *TxnTimestamps) GetTs() []uint64 {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+type PeerResponse struct {
+	Status               bool     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerResponse) Reset()         { *m = PeerResponse{} }
 func (m *PeerResponse) String() string { return proto.CompactTextString(m) }
 func (*PeerResponse) ProtoMessage()    {}
 func (*PeerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{41}
+	return fileDescriptor_f80abaa17e25ccc8, []int{45}
 }
 func (m *PeerResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3227,7 +3567,7 @@ func (m *RaftBatch) Reset()         { *m = RaftBatch{} }
 func (m *RaftBatch) String() string { return proto.CompactTextString(m) }
 func (*RaftBatch) ProtoMessage()    {}
 func (*RaftBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{42}
+	return fileDescriptor_f80abaa17e25ccc8, []int{46}
 }
 func (m *RaftBatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3283,7 +3623,7 @@ func (m *Num) Reset()         { *m = Num{} }
 func (m *Num) String() string { return proto.CompactTextString(m) }
 func (*Num) ProtoMessage()    {}
 func (*Num) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{43}
+	return fileDescriptor_f80abaa17e25ccc8, []int{47}
 }
 func (m *Num) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3347,7 +3687,7 @@ func (m *AssignedIds) Reset()         { *m = AssignedIds{} }
 func (m *AssignedIds) String() string { return proto.CompactTextString(m) }
 func (*AssignedIds) ProtoMessage()    {}
 func (*AssignedIds) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{44}
+	return fileDescriptor_f80abaa17e25ccc8, []int{48}
 }
 func (m *AssignedIds) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3409,7 +3749,7 @@ func (m *SnapshotMeta) Reset()         { *m = SnapshotMeta{} }
 func (m *SnapshotMeta) String() string { return proto.CompactTextString(m) }
 func (*SnapshotMeta) ProtoMessage()    {}
 func (*SnapshotMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{45}
+	return fileDescriptor_f80abaa17e25ccc8, []int{49}
 }
 func (m *SnapshotMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3466,7 +3806,7 @@ func (m *Status) Reset()         { *m = Status{} }
 func (m *Status) String() string { return proto.CompactTextString(m) }
 func (*Status) ProtoMessage()    {}
 func (*Status) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{46}
+	return fileDescriptor_f80abaa17e25ccc8, []int{50}
 }
 func (m *Status) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3521,7 +3861,13 @@ type BackupRequest struct {
 	SessionToken string `protobuf:"bytes,9,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
 	// True if no credentials should be used to access the S3 or minio bucket.
 	// For example, when using a bucket with a public policy.
-	Anonymous            bool     `protobuf:"varint,10,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	Anonymous bool `protobuf:"varint,10,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	// Type of backup, "full" or "incremental". If empty, the backup is incremental if there
+	// are previous backups at the location, and full otherwise.
+	Type string `protobuf:"bytes,11,opt,name=type,proto3" json:"type,omitempty"`
+	// Read timestamp of the backup an incremental backup is taken against. If zero, the
+	// latest backup at the location is used.
+	BaseReadTs           uint64   `protobuf:"varint,12,opt,name=base_read_ts,json=baseReadTs,proto3" json:"base_read_ts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3531,7 +3877,7 @@ func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
 func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
 func (*BackupRequest) ProtoMessage()    {}
 func (*BackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{47}
+	return fileDescriptor_f80abaa17e25ccc8, []int{51}
 }
 func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3630,10 +3976,32 @@ func (m *BackupRequest) GetAnonymous() bool {
 	return false
 }
 
+func (m *BackupRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *BackupRequest) GetBaseReadTs() uint64 {
+	if m != nil {
+		return m.BaseReadTs
+	}
+	return 0
+}
+
 type ExportRequest struct {
-	GroupId              uint32   `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	ReadTs               uint64   `protobuf:"varint,2,opt,name=read_ts,json=readTs,proto3" json:"read_ts,omitempty"`
-	UnixTs               int64    `protobuf:"varint,3,opt,name=unix_ts,json=unixTs,proto3" json:"unix_ts,omitempty"`
+	GroupId uint32 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	ReadTs  uint64 `protobuf:"varint,2,opt,name=read_ts,json=readTs,proto3" json:"read_ts,omitempty"`
+	UnixTs  int64  `protobuf:"varint,3,opt,name=unix_ts,json=unixTs,proto3" json:"unix_ts,omitempty"`
+	Format  string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	// Destination URI of the export, in any of the schemes supported by backups. If empty, the
+	// export is written to the export directory of the Alpha.
+	Destination          string   `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	AccessKey            string   `protobuf:"bytes,6,opt,name=access_key,json=accessKey,proto3" json:"access_key,omitempty"`
+	SecretKey            string   `protobuf:"bytes,7,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	SessionToken         string   `protobuf:"bytes,8,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	Anonymous            bool     `protobuf:"varint,9,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3643,7 +4011,7 @@ func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
 func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
 func (*ExportRequest) ProtoMessage()    {}
 func (*ExportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{48}
+	return fileDescriptor_f80abaa17e25ccc8, []int{52}
 }
 func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3693,294 +4061,589 @@ func (m *ExportRequest) GetUnixTs() int64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterEnum("pb.DirectedEdge_Op", DirectedEdge_Op_name, DirectedEdge_Op_value)
-	proto.RegisterEnum("pb.Mutations_DropOp", Mutations_DropOp_name, Mutations_DropOp_value)
-	proto.RegisterEnum("pb.Posting_ValType", Posting_ValType_name, Posting_ValType_value)
-	proto.RegisterEnum("pb.Posting_PostingType", Posting_PostingType_name, Posting_PostingType_value)
-	proto.RegisterEnum("pb.SchemaUpdate_Directive", SchemaUpdate_Directive_name, SchemaUpdate_Directive_value)
-	proto.RegisterType((*List)(nil), "pb.List")
-	proto.RegisterType((*TaskValue)(nil), "pb.TaskValue")
-	proto.RegisterType((*SrcFunction)(nil), "pb.SrcFunction")
-	proto.RegisterType((*Query)(nil), "pb.Query")
-	proto.RegisterType((*ValueList)(nil), "pb.ValueList")
-	proto.RegisterType((*LangList)(nil), "pb.LangList")
-	proto.RegisterType((*Result)(nil), "pb.Result")
-	proto.RegisterType((*Order)(nil), "pb.Order")
-	proto.RegisterType((*SortMessage)(nil), "pb.SortMessage")
-	proto.RegisterType((*SortResult)(nil), "pb.SortResult")
-	proto.RegisterType((*RaftContext)(nil), "pb.RaftContext")
-	proto.RegisterType((*Member)(nil), "pb.Member")
-	proto.RegisterType((*Group)(nil), "pb.Group")
-	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.Group.MembersEntry")
-	proto.RegisterMapType((map[string]*Tablet)(nil), "pb.Group.TabletsEntry")
-	proto.RegisterType((*ZeroProposal)(nil), "pb.ZeroProposal")
-	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.ZeroProposal.SnapshotTsEntry")
-	proto.RegisterType((*MembershipState)(nil), "pb.MembershipState")
-	proto.RegisterMapType((map[uint32]*Group)(nil), "pb.MembershipState.GroupsEntry")
-	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.MembershipState.ZerosEntry")
-	proto.RegisterType((*ConnectionState)(nil), "pb.ConnectionState")
-	proto.RegisterType((*Tablet)(nil), "pb.Tablet")
-	proto.RegisterType((*DirectedEdge)(nil), "pb.DirectedEdge")
-	proto.RegisterType((*Mutations)(nil), "pb.Mutations")
-	proto.RegisterType((*Snapshot)(nil), "pb.Snapshot")
-	proto.RegisterType((*Proposal)(nil), "pb.Proposal")
-	proto.RegisterType((*KVS)(nil), "pb.KVS")
-	proto.RegisterType((*Posting)(nil), "pb.Posting")
-	proto.RegisterType((*UidBlock)(nil), "pb.UidBlock")
-	proto.RegisterType((*UidPack)(nil), "pb.UidPack")
-	proto.RegisterType((*PostingList)(nil), "pb.PostingList")
-	proto.RegisterType((*FacetParam)(nil), "pb.FacetParam")
-	proto.RegisterType((*FacetParams)(nil), "pb.FacetParams")
-	proto.RegisterType((*Facets)(nil), "pb.Facets")
-	proto.RegisterType((*FacetsList)(nil), "pb.FacetsList")
-	proto.RegisterType((*Function)(nil), "pb.Function")
-	proto.RegisterType((*FilterTree)(nil), "pb.FilterTree")
-	proto.RegisterType((*SchemaRequest)(nil), "pb.SchemaRequest")
-	proto.RegisterType((*SchemaResult)(nil), "pb.SchemaResult")
-	proto.RegisterType((*SchemaUpdate)(nil), "pb.SchemaUpdate")
-	proto.RegisterType((*TypeUpdate)(nil), "pb.TypeUpdate")
-	proto.RegisterType((*MapEntry)(nil), "pb.MapEntry")
-	proto.RegisterType((*MovePredicatePayload)(nil), "pb.MovePredicatePayload")
-	proto.RegisterType((*TxnStatus)(nil), "pb.TxnStatus")
-	proto.RegisterType((*OracleDelta)(nil), "pb.OracleDelta")
-	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.OracleDelta.GroupChecksumsEntry")
-	proto.RegisterType((*TxnTimestamps)(nil), "pb.TxnTimestamps")
-	proto.RegisterType((*PeerResponse)(nil), "pb.PeerResponse")
-	proto.RegisterType((*RaftBatch)(nil), "pb.RaftBatch")
-	proto.RegisterType((*Num)(nil), "pb.Num")
-	proto.RegisterType((*AssignedIds)(nil), "pb.AssignedIds")
-	proto.RegisterType((*SnapshotMeta)(nil), "pb.SnapshotMeta")
-	proto.RegisterType((*Status)(nil), "pb.Status")
-	proto.RegisterType((*BackupRequest)(nil), "pb.BackupRequest")
-	proto.RegisterType((*ExportRequest)(nil), "pb.ExportRequest")
+func (m *ExportRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
-
-var fileDescriptor_f80abaa17e25ccc8 = []byte{
-	// 3518 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0xcd, 0x6f, 0x23, 0x47,
-	0x76, 0x9f, 0x6e, 0x92, 0xcd, 0xee, 0x47, 0x4a, 0x43, 0x97, 0xbd, 0x63, 0x5a, 0xbb, 0x9e, 0x91,
-	0xdb, 0x1f, 0x23, 0x8f, 0xd7, 0x9a, 0xb1, 0xbc, 0x49, 0xd6, 0x1b, 0xe4, 0xa0, 0x91, 0x38, 0x13,
-	0xd9, 0xfa, 0x4a, 0x91, 0x9a, 0xcd, 0x1a, 0x41, 0x88, 0x52, 0x77, 0x89, 0xea, 0x55, 0xb3, 0xbb,
-	0xd3, 0xd5, 0x54, 0x28, 0xdf, 0x72, 0x48, 0x80, 0x00, 0xf9, 0x03, 0xf6, 0x10, 0xe4, 0xb0, 0xc7,
-	0x5c, 0x92, 0xe3, 0x9e, 0x03, 0x04, 0xc8, 0x31, 0xc8, 0x5f, 0x10, 0x38, 0x39, 0xe6, 0x1c, 0x20,
-	0xb7, 0xe0, 0xbd, 0xaa, 0x66, 0x37, 0x39, 0x9a, 0xf1, 0x3a, 0x40, 0x4e, 0xac, 0xf7, 0x51, 0x5f,
-	0xbf, 0x7a, 0xef, 0xd5, 0xab, 0xd7, 0x04, 0x37, 0x3b, 0xdf, 0xce, 0xf2, 0xb4, 0x48, 0x99, 0x9d,
-	0x9d, 0x6f, 0x78, 0x22, 0x8b, 0x34, 0xb9, 0xf1, 0x70, 0x12, 0x15, 0x97, 0xb3, 0xf3, 0xed, 0x20,
-	0x9d, 0x3e, 0x0e, 0x27, 0xb9, 0xc8, 0x2e, 0x3f, 0x8d, 0xd2, 0xc7, 0xe7, 0x22, 0x9c, 0xc8, 0xfc,
-	0x71, 0x76, 0xfe, 0xb8, 0xec, 0xe7, 0x6f, 0x40, 0xf3, 0x30, 0x52, 0x05, 0x63, 0xd0, 0x9c, 0x45,
-	0xa1, 0xea, 0x5b, 0x9b, 0x8d, 0x2d, 0x87, 0x53, 0xdb, 0x3f, 0x02, 0x6f, 0x24, 0xd4, 0xd5, 0x0b,
-	0x11, 0xcf, 0x24, 0xeb, 0x41, 0xe3, 0x5a, 0xc4, 0x7d, 0x6b, 0xd3, 0xda, 0xea, 0x72, 0x6c, 0xb2,
-	0x6d, 0x70, 0xaf, 0x45, 0x3c, 0x2e, 0x6e, 0x32, 0xd9, 0xb7, 0x37, 0xad, 0xad, 0xf5, 0x9d, 0x37,
-	0xb7, 0xb3, 0xf3, 0xed, 0xd3, 0x54, 0x15, 0x51, 0x32, 0xd9, 0x7e, 0x21, 0xe2, 0xd1, 0x4d, 0x26,
-	0x79, 0xfb, 0x5a, 0x37, 0xfc, 0x13, 0xe8, 0x0c, 0xf3, 0xe0, 0xd9, 0x2c, 0x09, 0x8a, 0x28, 0x4d,
-	0x70, 0xc6, 0x44, 0x4c, 0x25, 0x8d, 0xe8, 0x71, 0x6a, 0x23, 0x4f, 0xe4, 0x13, 0xd5, 0x6f, 0x6c,
-	0x36, 0x90, 0x87, 0x6d, 0xd6, 0x87, 0x76, 0xa4, 0xf6, 0xd2, 0x59, 0x52, 0xf4, 0x9b, 0x9b, 0xd6,
-	0x96, 0xcb, 0x4b, 0xd2, 0xff, 0xeb, 0x06, 0xb4, 0xfe, 0x68, 0x26, 0xf3, 0x1b, 0xea, 0x57, 0x14,
-	0x79, 0x39, 0x16, 0xb6, 0xd9, 0x5b, 0xd0, 0x8a, 0x45, 0x32, 0x51, 0x7d, 0x9b, 0x06, 0xd3, 0x04,
-	0xfb, 0x21, 0x78, 0xe2, 0xa2, 0x90, 0xf9, 0x78, 0x16, 0x85, 0xfd, 0xc6, 0xa6, 0xb5, 0xe5, 0x70,
-	0x97, 0x18, 0x67, 0x51, 0xc8, 0xde, 0x01, 0x37, 0x4c, 0xc7, 0x41, 0x7d, 0xae, 0x30, 0xa5, 0xb9,
-	0xd8, 0xfb, 0xe0, 0xce, 0xa2, 0x70, 0x1c, 0x47, 0xaa, 0xe8, 0xb7, 0x36, 0xad, 0xad, 0xce, 0x8e,
-	0x8b, 0x9b, 0x45, 0xec, 0x78, 0x7b, 0x16, 0x85, 0x04, 0xe2, 0x23, 0x70, 0x55, 0x1e, 0x8c, 0x2f,
-	0x66, 0x49, 0xd0, 0x77, 0x48, 0xe9, 0x2e, 0x2a, 0xd5, 0x76, 0xcd, 0xdb, 0x4a, 0x13, 0xb8, 0xad,
-	0x5c, 0x5e, 0xcb, 0x5c, 0xc9, 0x7e, 0x5b, 0x4f, 0x65, 0x48, 0xf6, 0x04, 0x3a, 0x17, 0x22, 0x90,
-	0xc5, 0x38, 0x13, 0xb9, 0x98, 0xf6, 0xdd, 0x6a, 0xa0, 0x67, 0xc8, 0x3e, 0x45, 0xae, 0xe2, 0x70,
-	0xb1, 0x20, 0xd8, 0xe7, 0xb0, 0x46, 0x94, 0x1a, 0x5f, 0x44, 0x71, 0x21, 0xf3, 0xbe, 0x47, 0x7d,
-	0xd6, 0xa9, 0x0f, 0x71, 0x46, 0xb9, 0x94, 0xbc, 0xab, 0x95, 0x34, 0x87, 0xbd, 0x0b, 0x20, 0xe7,
-	0x99, 0x48, 0xc2, 0xb1, 0x88, 0xe3, 0x3e, 0xd0, 0x1a, 0x3c, 0xcd, 0xd9, 0x8d, 0x63, 0xf6, 0x36,
-	0xae, 0x4f, 0x84, 0xe3, 0x42, 0xf5, 0xd7, 0x36, 0xad, 0xad, 0x26, 0x77, 0x90, 0x1c, 0x29, 0xc4,
-	0x35, 0x10, 0xc1, 0xa5, 0xec, 0xaf, 0x6f, 0x5a, 0x5b, 0x2d, 0xae, 0x09, 0x7f, 0x07, 0x3c, 0xb2,
-	0x13, 0xc2, 0xe1, 0x43, 0x70, 0xae, 0x91, 0xd0, 0xe6, 0xd4, 0xd9, 0x59, 0xc3, 0x85, 0x2c, 0x4c,
-	0x89, 0x1b, 0xa1, 0x7f, 0x1f, 0xdc, 0x43, 0x91, 0x4c, 0x4a, 0xfb, 0xc3, 0x03, 0xa2, 0x0e, 0x1e,
-	0xa7, 0xb6, 0xff, 0x2b, 0x1b, 0x1c, 0x2e, 0xd5, 0x2c, 0x2e, 0xd8, 0x43, 0x00, 0x84, 0x7f, 0x2a,
-	0x8a, 0x3c, 0x9a, 0x9b, 0x51, 0xab, 0x03, 0xf0, 0x66, 0x51, 0x78, 0x44, 0x22, 0xf6, 0x04, 0xba,
-	0x34, 0x7a, 0xa9, 0x6a, 0x57, 0x0b, 0x58, 0xac, 0x8f, 0x77, 0x48, 0xc5, 0xf4, 0xb8, 0x07, 0x0e,
-	0x9d, 0xb8, 0xb6, 0xba, 0x35, 0x6e, 0x28, 0xf6, 0x21, 0xac, 0x47, 0x49, 0x81, 0x27, 0x12, 0x14,
-	0xe3, 0x50, 0xaa, 0xd2, 0x24, 0xd6, 0x16, 0xdc, 0x7d, 0xa9, 0x0a, 0xf6, 0x19, 0x68, 0x58, 0xcb,
-	0x09, 0x5b, 0x34, 0xe1, 0xfa, 0xe2, 0xb8, 0x94, 0x9e, 0x91, 0x74, 0xcc, 0x8c, 0x9f, 0x42, 0x07,
-	0xf7, 0x57, 0xf6, 0x70, 0xa8, 0x47, 0x97, 0x76, 0x63, 0xe0, 0xe0, 0x80, 0x0a, 0x46, 0x1d, 0xa1,
-	0x41, 0xb3, 0xd3, 0x66, 0x42, 0x6d, 0x7f, 0x00, 0xad, 0x93, 0x3c, 0x94, 0xf9, 0xad, 0x96, 0xcf,
-	0xa0, 0x19, 0x4a, 0x15, 0x90, 0x53, 0xba, 0x9c, 0xda, 0x95, 0x37, 0x34, 0x6a, 0xde, 0xe0, 0xff,
-	0x9d, 0x05, 0x9d, 0x61, 0x9a, 0x17, 0x47, 0x52, 0x29, 0x31, 0x91, 0xec, 0x01, 0xb4, 0x52, 0x1c,
-	0xd6, 0x20, 0xec, 0xe1, 0x9a, 0x68, 0x1e, 0xae, 0xf9, 0x2b, 0xe7, 0x60, 0xbf, 0xfa, 0x1c, 0xd0,
-	0x4a, 0xc8, 0x8f, 0x1a, 0xc6, 0x4a, 0xc8, 0x8b, 0xee, 0x81, 0x93, 0x5e, 0x5c, 0x28, 0xa9, 0xb1,
-	0x6c, 0x71, 0x43, 0xbd, 0xd2, 0xd8, 0xfc, 0xdf, 0x01, 0xc0, 0xf5, 0x7d, 0x4f, 0x2b, 0xf0, 0x2f,
-	0xa1, 0xc3, 0xc5, 0x45, 0xb1, 0x97, 0x26, 0x85, 0x9c, 0x17, 0x6c, 0x1d, 0xec, 0x28, 0x24, 0x88,
-	0x1c, 0x6e, 0x47, 0x21, 0x2e, 0x6e, 0x92, 0xa7, 0xb3, 0x8c, 0x10, 0x5a, 0xe3, 0x9a, 0x20, 0x28,
-	0xc3, 0x30, 0xa7, 0x15, 0x23, 0x94, 0x61, 0x98, 0xb3, 0x07, 0xd0, 0x51, 0x89, 0xc8, 0xd4, 0x65,
-	0x5a, 0xe0, 0xe2, 0x9a, 0xb4, 0x38, 0x28, 0x59, 0x23, 0xe5, 0xff, 0xb3, 0x05, 0xce, 0x91, 0x9c,
-	0x9e, 0xcb, 0xfc, 0xa5, 0x59, 0xde, 0x01, 0x97, 0x06, 0x1e, 0x47, 0xa1, 0x99, 0xa8, 0x4d, 0xf4,
-	0x41, 0x78, 0xeb, 0x54, 0xf7, 0xc0, 0x89, 0xa5, 0x40, 0xf0, 0xb5, 0x9d, 0x19, 0x0a, 0xb1, 0x11,
-	0xd3, 0x71, 0x28, 0x45, 0x48, 0x81, 0xc7, 0xe5, 0x8e, 0x98, 0xee, 0x4b, 0x11, 0xe2, 0xda, 0x62,
-	0xa1, 0x8a, 0xf1, 0x2c, 0x0b, 0x45, 0x21, 0x29, 0xe0, 0x34, 0xd1, 0x70, 0x54, 0x71, 0x46, 0x1c,
-	0xf6, 0x08, 0xde, 0x08, 0xe2, 0x99, 0xc2, 0x68, 0x17, 0x25, 0x17, 0xe9, 0x38, 0x4d, 0xe2, 0x1b,
-	0xc2, 0xd7, 0xe5, 0x77, 0x8d, 0xe0, 0x20, 0xb9, 0x48, 0x4f, 0x92, 0xf8, 0xc6, 0xff, 0x8d, 0x0d,
-	0xad, 0xe7, 0x04, 0xc3, 0x13, 0x68, 0x4f, 0x69, 0x43, 0xa5, 0xf7, 0xde, 0x43, 0x84, 0x49, 0xb6,
-	0xad, 0x77, 0xaa, 0x06, 0x49, 0x91, 0xdf, 0xf0, 0x52, 0x0d, 0x7b, 0x14, 0xe2, 0x3c, 0x96, 0x85,
-	0x32, 0x16, 0x51, 0xeb, 0x31, 0xd2, 0x02, 0xd3, 0xc3, 0xa8, 0xad, 0xc2, 0xda, 0x58, 0x85, 0x95,
-	0x6d, 0x80, 0x1b, 0x5c, 0xca, 0xe0, 0x4a, 0xcd, 0xa6, 0x06, 0xf4, 0x05, 0xbd, 0xf1, 0x0c, 0xba,
-	0xf5, 0x75, 0xe0, 0xcd, 0x74, 0x25, 0x6f, 0x08, 0xf8, 0x26, 0xc7, 0x26, 0xdb, 0x84, 0x16, 0x79,
-	0x38, 0xc1, 0xde, 0xd9, 0x01, 0x5c, 0x8e, 0xee, 0xc2, 0xb5, 0xe0, 0x67, 0xf6, 0x4f, 0x2d, 0x1c,
-	0xa7, 0xbe, 0xba, 0xfa, 0x38, 0xde, 0xab, 0xc7, 0xd1, 0x5d, 0x6a, 0xe3, 0xf8, 0xff, 0x63, 0x43,
-	0xf7, 0x6b, 0x99, 0xa7, 0xa7, 0x79, 0x9a, 0xa5, 0x4a, 0xc4, 0x6c, 0x77, 0x79, 0x77, 0x1a, 0xc5,
-	0x4d, 0xec, 0x5c, 0x57, 0xdb, 0x1e, 0x2e, 0xb6, 0xab, 0xd1, 0xa9, 0xef, 0xdf, 0x07, 0x47, 0xa3,
-	0x7b, 0xcb, 0x16, 0x8c, 0x04, 0x75, 0x34, 0x9e, 0x84, 0xdf, 0xf2, 0xf2, 0x8c, 0x84, 0xdd, 0x07,
-	0x98, 0x8a, 0xf9, 0xa1, 0x14, 0x4a, 0x1e, 0x84, 0xa5, 0xf9, 0x56, 0x1c, 0xc4, 0x79, 0x2a, 0xe6,
-	0xa3, 0x79, 0x32, 0x52, 0x64, 0x5d, 0x4d, 0xbe, 0xa0, 0xd9, 0x8f, 0xc0, 0x9b, 0x8a, 0x39, 0xfa,
-	0xd1, 0x41, 0x68, 0xac, 0xab, 0x62, 0xb0, 0xf7, 0xa0, 0x51, 0xcc, 0x13, 0x0a, 0x4a, 0x78, 0x3b,
-	0x61, 0xea, 0x31, 0x9a, 0x27, 0xc6, 0xe3, 0x38, 0xca, 0x4a, 0x40, 0xdd, 0x0a, 0xd0, 0x1e, 0x34,
-	0x82, 0x28, 0xa4, 0xeb, 0xc9, 0xe3, 0xd8, 0xdc, 0xf8, 0x03, 0xb8, 0xbb, 0x82, 0x43, 0xfd, 0x1c,
-	0xd6, 0x74, 0xb7, 0xb7, 0xea, 0xe7, 0xd0, 0xac, 0x63, 0xff, 0x9b, 0x06, 0xdc, 0x35, 0xc6, 0x70,
-	0x19, 0x65, 0xc3, 0x02, 0xcd, 0xbe, 0x0f, 0x6d, 0x8a, 0x36, 0x32, 0x37, 0x36, 0x51, 0x92, 0xec,
-	0xf7, 0xc0, 0x21, 0x0f, 0x2c, 0xed, 0xf4, 0x41, 0x85, 0xea, 0xa2, 0xbb, 0xb6, 0x5b, 0x73, 0x24,
-	0x46, 0x9d, 0xfd, 0x04, 0x5a, 0xdf, 0xc8, 0x3c, 0xd5, 0xd1, 0xb3, 0xb3, 0x73, 0xff, 0xb6, 0x7e,
-	0x78, 0xb6, 0xa6, 0x9b, 0x56, 0xfe, 0x7f, 0x04, 0xff, 0x03, 0x8c, 0x97, 0xd3, 0xf4, 0x5a, 0x86,
-	0xfd, 0x36, 0xad, 0xa8, 0x6e, 0x1f, 0xa5, 0xa8, 0x44, 0xdb, 0xad, 0xd0, 0xde, 0x87, 0x4e, 0x6d,
-	0x7b, 0xb7, 0x20, 0xfd, 0x60, 0xd9, 0xe2, 0xbd, 0x85, 0x23, 0xd7, 0x1d, 0x67, 0x1f, 0xa0, 0xda,
-	0xec, 0xff, 0xd5, 0xfd, 0xfc, 0xbf, 0xb0, 0xe0, 0xee, 0x5e, 0x9a, 0x24, 0x92, 0x12, 0x23, 0x7d,
-	0x74, 0x95, 0xd9, 0x5b, 0xaf, 0x34, 0xfb, 0x8f, 0xa1, 0xa5, 0x50, 0xd9, 0x8c, 0xfe, 0xe6, 0x2d,
-	0x67, 0xc1, 0xb5, 0x06, 0x86, 0x99, 0xa9, 0x98, 0x8f, 0x33, 0x99, 0x84, 0x51, 0x32, 0x29, 0xc3,
-	0xcc, 0x54, 0xcc, 0x4f, 0x35, 0xc7, 0xff, 0xb5, 0x05, 0x8e, 0xf6, 0x98, 0xa5, 0x68, 0x6d, 0x2d,
-	0x47, 0xeb, 0x1f, 0x81, 0x97, 0xe5, 0x32, 0x8c, 0x82, 0x72, 0x56, 0x8f, 0x57, 0x0c, 0x34, 0xce,
-	0x8b, 0x34, 0x0f, 0x24, 0x0d, 0xef, 0x72, 0x4d, 0x20, 0x57, 0x65, 0x22, 0xd0, 0xc9, 0x5d, 0x83,
-	0x6b, 0x02, 0x63, 0xbc, 0x3e, 0x1c, 0x3a, 0x14, 0x97, 0x1b, 0x0a, 0xb3, 0x52, 0xba, 0xff, 0x28,
-	0x42, 0x7b, 0x24, 0x72, 0x91, 0x41, 0xa1, 0xf9, 0xef, 0x6d, 0xe8, 0xee, 0x47, 0xb9, 0x0c, 0x0a,
-	0x19, 0x0e, 0xc2, 0x09, 0x8d, 0x22, 0x93, 0x22, 0x2a, 0x6e, 0xcc, 0x65, 0x63, 0xa8, 0x45, 0x2e,
-	0x60, 0x2f, 0x67, 0xc1, 0xfa, 0x2c, 0x1a, 0x94, 0xb8, 0x6b, 0x82, 0xed, 0x00, 0xe8, 0x2c, 0x89,
-	0x92, 0xf7, 0xe6, 0xab, 0x93, 0x77, 0x8f, 0xd4, 0xb0, 0x89, 0x00, 0xe9, 0x3e, 0x91, 0xbe, 0x88,
-	0x1c, 0xca, 0xec, 0x67, 0x68, 0xc8, 0x94, 0x5c, 0x9c, 0xcb, 0x98, 0x0c, 0x95, 0x92, 0x8b, 0x73,
-	0x19, 0x2f, 0x52, 0xba, 0xb6, 0x5e, 0x0e, 0xb6, 0xd9, 0xfb, 0x60, 0xa7, 0x19, 0x6d, 0xde, 0x4c,
-	0x58, 0xdf, 0xd8, 0xf6, 0x49, 0xc6, 0xed, 0x34, 0x43, 0x2b, 0xd0, 0x99, 0x6a, 0xdf, 0x33, 0xc6,
-	0x8d, 0xd1, 0x85, 0xb2, 0x29, 0x6e, 0x24, 0xfe, 0x3d, 0xb0, 0x4f, 0x32, 0xd6, 0x86, 0xc6, 0x70,
-	0x30, 0xea, 0xdd, 0xc1, 0xc6, 0xfe, 0xe0, 0xb0, 0x67, 0xf9, 0xff, 0x65, 0x83, 0x77, 0x34, 0x2b,
-	0x04, 0xda, 0x94, 0x7a, 0xdd, 0xa1, 0xbe, 0x03, 0xae, 0x2a, 0x44, 0x4e, 0x11, 0x5a, 0x87, 0x95,
-	0x36, 0xd1, 0x23, 0xc5, 0x3e, 0x82, 0x96, 0x0c, 0x27, 0xb2, 0xf4, 0xf6, 0xde, 0xea, 0x3a, 0xb9,
-	0x16, 0xb3, 0x2d, 0x70, 0x54, 0x70, 0x29, 0xa7, 0xa2, 0xdf, 0xac, 0x14, 0x87, 0xc4, 0xd1, 0x37,
-	0x30, 0x37, 0x72, 0xb6, 0x03, 0x3f, 0x88, 0x26, 0x49, 0x9a, 0xcb, 0x71, 0x94, 0x84, 0x72, 0x3e,
-	0x0e, 0xd2, 0xe4, 0x22, 0x8e, 0x82, 0xc2, 0xdc, 0xe8, 0x6f, 0x6a, 0xe1, 0x01, 0xca, 0xf6, 0x8c,
-	0x88, 0x7d, 0x00, 0x2d, 0x3c, 0x1d, 0x65, 0xf2, 0x43, 0xca, 0x28, 0xf1, 0x20, 0xcc, 0xd0, 0x5a,
-	0xc8, 0x3e, 0x85, 0x76, 0x98, 0xa7, 0xd9, 0x38, 0xcd, 0x08, 0xe7, 0xf5, 0x9d, 0xb7, 0xc8, 0x1f,
-	0x4a, 0x04, 0xb6, 0xf7, 0xf3, 0x34, 0x3b, 0xc9, 0xb8, 0x13, 0xd2, 0x2f, 0x26, 0xfd, 0xa4, 0xae,
-	0x6d, 0x42, 0x47, 0x06, 0x0f, 0x39, 0x94, 0x1c, 0xfb, 0x8f, 0xc1, 0xd1, 0x1d, 0x98, 0x0b, 0xcd,
-	0xe3, 0x93, 0xe3, 0x81, 0x86, 0x76, 0xf7, 0xf0, 0xb0, 0x67, 0x21, 0x6b, 0x7f, 0x77, 0xb4, 0xdb,
-	0xb3, 0xb1, 0x35, 0xfa, 0xc5, 0xe9, 0xa0, 0xd7, 0xf0, 0xe7, 0xe0, 0x96, 0xe1, 0x9b, 0x7d, 0x8c,
-	0x71, 0x97, 0xc2, 0xbf, 0xf1, 0x5e, 0x7a, 0xb3, 0xd4, 0xf2, 0x30, 0x5e, 0xca, 0xd1, 0x60, 0x08,
-	0x88, 0x32, 0xa0, 0x13, 0x51, 0xcf, 0x02, 0x1b, 0x4b, 0x4f, 0x0e, 0x4c, 0x68, 0xd3, 0x44, 0x9a,
-	0xc4, 0x88, 0xda, 0xfe, 0xdf, 0xda, 0xe0, 0x2e, 0x6e, 0xdc, 0x4f, 0xc0, 0x9b, 0x96, 0x5b, 0x36,
-	0x71, 0x61, 0x6d, 0x09, 0x07, 0x5e, 0xc9, 0xd9, 0x3d, 0xb0, 0xaf, 0xae, 0xcd, 0x91, 0x39, 0xa8,
-	0xf5, 0xd5, 0x0b, 0x6e, 0x5f, 0x5d, 0x57, 0x81, 0xa5, 0xf5, 0x9d, 0x81, 0xe5, 0x21, 0xdc, 0x0d,
-	0x62, 0x29, 0x92, 0x71, 0x15, 0x17, 0xb4, 0xe9, 0xaf, 0x13, 0xfb, 0x74, 0x11, 0x1c, 0x4c, 0x70,
-	0x6c, 0x57, 0x57, 0xe0, 0x87, 0xd0, 0x0a, 0x65, 0x5c, 0x88, 0xfa, 0xbb, 0xee, 0x24, 0x17, 0x41,
-	0x2c, 0xf7, 0x91, 0xcd, 0xb5, 0x94, 0x6d, 0x81, 0x5b, 0xa6, 0x03, 0xe6, 0x35, 0x47, 0x0f, 0x84,
-	0x12, 0x6c, 0xbe, 0x90, 0x56, 0x58, 0x42, 0x0d, 0x4b, 0xff, 0x33, 0x68, 0x7c, 0xf5, 0x62, 0x68,
-	0xf6, 0x6a, 0xbd, 0xb4, 0xd7, 0x12, 0x51, 0xbb, 0x86, 0xe8, 0x7f, 0x37, 0xa0, 0x6d, 0xfc, 0x1f,
-	0xd7, 0x3d, 0x5b, 0x24, 0xb3, 0xd8, 0x5c, 0xbe, 0x83, 0x17, 0x81, 0xa4, 0x5e, 0x03, 0x68, 0x7c,
-	0x77, 0x0d, 0x80, 0xfd, 0x0c, 0xba, 0x99, 0x96, 0xd5, 0x43, 0xcf, 0xdb, 0xf5, 0x3e, 0xe6, 0x97,
-	0xfa, 0x75, 0xb2, 0x8a, 0x40, 0x8f, 0xa5, 0x67, 0x53, 0x21, 0x26, 0x74, 0x44, 0x5d, 0xde, 0x46,
-	0x7a, 0x24, 0x26, 0xaf, 0x08, 0x40, 0xbf, 0x45, 0x1c, 0xc1, 0xa4, 0x3d, 0xcd, 0xfa, 0x5d, 0x8a,
-	0x0d, 0x18, 0x7b, 0xea, 0x61, 0x61, 0x6d, 0x39, 0x2c, 0xfc, 0x10, 0xbc, 0x20, 0x9d, 0x4e, 0x23,
-	0x92, 0xad, 0x9b, 0xa4, 0x94, 0x18, 0x23, 0xe5, 0xff, 0x95, 0x05, 0x6d, 0xb3, 0x5b, 0xd6, 0x81,
-	0xf6, 0xfe, 0xe0, 0xd9, 0xee, 0xd9, 0x21, 0x46, 0x26, 0x00, 0xe7, 0xe9, 0xc1, 0xf1, 0x2e, 0xff,
-	0x45, 0xcf, 0x42, 0x57, 0x3a, 0x38, 0x1e, 0xf5, 0x6c, 0xe6, 0x41, 0xeb, 0xd9, 0xe1, 0xc9, 0xee,
-	0xa8, 0xd7, 0x40, 0x5f, 0x7a, 0x7a, 0x72, 0x72, 0xd8, 0x6b, 0xb2, 0x2e, 0xb8, 0xfb, 0xbb, 0xa3,
-	0xc1, 0xe8, 0xe0, 0x68, 0xd0, 0x6b, 0xa1, 0xee, 0xf3, 0xc1, 0x49, 0xcf, 0xc1, 0xc6, 0xd9, 0xc1,
-	0x7e, 0xaf, 0x8d, 0xf2, 0xd3, 0xdd, 0xe1, 0xf0, 0xe7, 0x27, 0x7c, 0xbf, 0xe7, 0xe2, 0xb8, 0xc3,
-	0x11, 0x3f, 0x38, 0x7e, 0xde, 0xf3, 0xb0, 0x7d, 0xf2, 0xf4, 0xcb, 0xc1, 0xde, 0xa8, 0x07, 0xfe,
-	0x67, 0xd0, 0xa9, 0x21, 0x88, 0xbd, 0xf9, 0xe0, 0x59, 0xef, 0x0e, 0x4e, 0xf9, 0x62, 0xf7, 0xf0,
-	0x6c, 0xd0, 0xb3, 0xd8, 0x3a, 0x00, 0x35, 0xc7, 0x87, 0xbb, 0xc7, 0xcf, 0x7b, 0xb6, 0xff, 0xbb,
-	0xe0, 0x9e, 0x45, 0xe1, 0xd3, 0x38, 0x0d, 0xae, 0xd0, 0x30, 0xce, 0x85, 0x92, 0xe6, 0x3a, 0xa7,
-	0x36, 0xde, 0x37, 0x64, 0x94, 0xca, 0x9c, 0xbd, 0xa1, 0xfc, 0x63, 0x68, 0x9f, 0x45, 0xe1, 0xa9,
-	0x08, 0xae, 0x30, 0xae, 0x9c, 0x63, 0xff, 0xb1, 0x8a, 0xbe, 0x91, 0x26, 0xd4, 0x7a, 0xc4, 0x19,
-	0x46, 0xdf, 0x48, 0xf6, 0x01, 0x38, 0x44, 0x94, 0x89, 0x17, 0xd9, 0x72, 0x39, 0x27, 0x37, 0x32,
-	0xff, 0x6f, 0xac, 0xc5, 0xda, 0xa9, 0x26, 0xf0, 0x00, 0x9a, 0x99, 0x08, 0xae, 0x4c, 0x34, 0xe9,
-	0x98, 0x3e, 0x38, 0x1f, 0x27, 0x01, 0x7b, 0x08, 0xae, 0x31, 0x90, 0x72, 0xe0, 0x4e, 0xcd, 0x92,
-	0xf8, 0x42, 0xb8, 0x7c, 0x74, 0x8d, 0xe5, 0xa3, 0xc3, 0xed, 0xa9, 0x2c, 0x8e, 0xe8, 0x79, 0xd7,
-	0xc0, 0xa8, 0xa3, 0x29, 0xff, 0x27, 0x00, 0x55, 0xc1, 0xe5, 0x96, 0xd7, 0xc1, 0x5b, 0xd0, 0x12,
-	0x71, 0x64, 0x50, 0xf1, 0xb8, 0x26, 0xfc, 0x63, 0xe8, 0xd4, 0xca, 0x34, 0x68, 0x4f, 0x22, 0x8e,
-	0xc7, 0x57, 0xf2, 0x46, 0x51, 0x5f, 0x97, 0xb7, 0x45, 0x1c, 0x7f, 0x25, 0x6f, 0x14, 0x06, 0x78,
-	0x5d, 0xe1, 0xb1, 0x57, 0x4a, 0x06, 0xd4, 0x95, 0x6b, 0xa1, 0xff, 0x63, 0x70, 0x74, 0x1d, 0xa1,
-	0x66, 0xce, 0xd6, 0x2b, 0xaf, 0xc5, 0x2f, 0xcc, 0x9a, 0xa9, 0xea, 0xc0, 0x3e, 0x31, 0x95, 0x24,
-	0xa5, 0xeb, 0x56, 0x56, 0x95, 0x2a, 0x6a, 0x25, 0x53, 0x44, 0x22, 0x65, 0x7f, 0x1f, 0xdc, 0xd7,
-	0xd6, 0xe6, 0x0c, 0x00, 0x76, 0x05, 0xc0, 0x2d, 0xd5, 0x3a, 0xff, 0x97, 0x00, 0x55, 0xc5, 0xc9,
-	0x78, 0x97, 0x1e, 0x05, 0xbd, 0xeb, 0x11, 0x3e, 0xeb, 0xa2, 0x38, 0xcc, 0x65, 0xb2, 0xb4, 0xeb,
-	0xaa, 0x46, 0xb5, 0x90, 0xb3, 0x4d, 0x68, 0x52, 0x21, 0xad, 0x51, 0x45, 0xbf, 0x45, 0x15, 0x8d,
-	0x24, 0xfe, 0x1c, 0xd6, 0xf4, 0x6d, 0xcb, 0xe5, 0x9f, 0xcd, 0xa4, 0x7a, 0x6d, 0x0e, 0x77, 0x1f,
-	0x60, 0x11, 0xab, 0xcb, 0x92, 0x60, 0x8d, 0x83, 0x46, 0x70, 0x11, 0xc9, 0x38, 0x2c, 0x77, 0x63,
-	0x28, 0x3c, 0x64, 0x7d, 0x0b, 0x37, 0x75, 0xdd, 0x84, 0x08, 0xff, 0xf7, 0xa1, 0x5b, 0xce, 0x4c,
-	0x85, 0x89, 0x4f, 0x16, 0x99, 0x80, 0xc6, 0x58, 0xbf, 0x87, 0xb4, 0xca, 0x71, 0x1a, 0xca, 0xa7,
-	0x76, 0xdf, 0x2a, 0x93, 0x01, 0xff, 0xdf, 0x1a, 0x65, 0x6f, 0xf3, 0x4e, 0x5f, 0xca, 0x2f, 0xad,
-	0xd5, 0xfc, 0x72, 0x39, 0x57, 0xb3, 0x7f, 0xab, 0x5c, 0xed, 0xa7, 0xe0, 0x85, 0x94, 0xb0, 0x44,
-	0xd7, 0x65, 0x5c, 0xde, 0x58, 0x4d, 0x4e, 0x4c, 0x4a, 0x13, 0x5d, 0x4b, 0x5e, 0x29, 0xe3, 0x5a,
-	0x8a, 0xf4, 0x4a, 0x26, 0xd1, 0x37, 0x54, 0x88, 0xc0, 0x3d, 0x57, 0x8c, 0xaa, 0xaa, 0xa3, 0xf3,
-	0x16, 0x53, 0xd5, 0x29, 0x0b, 0x54, 0x4e, 0x55, 0xa0, 0x42, 0x3c, 0x67, 0x99, 0x92, 0x79, 0x51,
-	0x66, 0xba, 0x9a, 0x5a, 0x24, 0x85, 0x9e, 0xd1, 0xc5, 0xa4, 0xf0, 0x3d, 0xe8, 0x26, 0x69, 0x32,
-	0x4e, 0x66, 0x71, 0x8c, 0xb9, 0xb8, 0xa9, 0x45, 0x76, 0x92, 0x34, 0x39, 0x36, 0x2c, 0xf6, 0x08,
-	0xde, 0xa8, 0xab, 0x68, 0x7b, 0xee, 0xe8, 0x52, 0x46, 0x4d, 0x8f, 0xac, 0x7e, 0x0b, 0x7a, 0xe9,
-	0xf9, 0x2f, 0x65, 0x50, 0x10, 0x62, 0x63, 0x32, 0xe4, 0xae, 0xbe, 0x9d, 0x35, 0x1f, 0x21, 0x3a,
-	0x16, 0x53, 0xe9, 0x7f, 0x01, 0xde, 0x02, 0x84, 0x5a, 0xc6, 0xe3, 0x41, 0xeb, 0xe0, 0x78, 0x7f,
-	0xf0, 0xc7, 0x3d, 0x0b, 0x43, 0x39, 0x1f, 0xbc, 0x18, 0xf0, 0xe1, 0xa0, 0x67, 0x63, 0x98, 0xdd,
-	0x1f, 0x1c, 0x0e, 0x46, 0x83, 0x5e, 0xe3, 0xcb, 0xa6, 0xdb, 0xee, 0xb9, 0xdc, 0x95, 0xf3, 0x2c,
-	0x8e, 0x82, 0xa8, 0xf0, 0x87, 0x00, 0x55, 0x72, 0x86, 0xf1, 0xa6, 0x9a, 0x5b, 0x9f, 0xa8, 0x5b,
-	0x98, 0x59, 0x31, 0x6d, 0x34, 0xa6, 0x66, 0xbf, 0x2a, 0x6d, 0xd4, 0x72, 0xff, 0x0c, 0xdc, 0x23,
-	0x91, 0xbd, 0xf4, 0xcc, 0xea, 0x2e, 0x1e, 0xd3, 0x33, 0x53, 0x5a, 0x32, 0x77, 0xf4, 0x87, 0xd0,
-	0x36, 0x21, 0xcf, 0x78, 0xcd, 0x52, 0x38, 0x2c, 0x65, 0xfe, 0x5f, 0x5a, 0xf0, 0xd6, 0x51, 0x7a,
-	0x2d, 0x17, 0x69, 0xca, 0xa9, 0xb8, 0x89, 0x53, 0x11, 0x7e, 0x87, 0x21, 0xbe, 0x0b, 0xa0, 0xd2,
-	0x59, 0x1e, 0xc8, 0xf1, 0x64, 0x51, 0xd1, 0xf2, 0x34, 0xe7, 0xb9, 0x29, 0x9e, 0x4b, 0x55, 0x90,
-	0xb0, 0xa1, 0x9d, 0x0f, 0x69, 0x14, 0xfd, 0x00, 0x9c, 0x62, 0x9e, 0x54, 0x05, 0xb4, 0x56, 0x81,
-	0x6f, 0x5c, 0x7f, 0x0f, 0xbc, 0xd1, 0x9c, 0x5e, 0x7e, 0x33, 0xb5, 0x74, 0xf1, 0x5a, 0xaf, 0xb9,
-	0x78, 0xed, 0x95, 0x8b, 0xf7, 0x3f, 0x2d, 0xe8, 0xd4, 0xf2, 0x27, 0xf6, 0x1e, 0x34, 0x8b, 0x79,
-	0xb2, 0x5c, 0x79, 0x2e, 0x27, 0xe1, 0x24, 0x42, 0x7b, 0xc3, 0x67, 0xa1, 0x50, 0x2a, 0x9a, 0x24,
-	0x32, 0x34, 0x43, 0xe2, 0x53, 0x71, 0xd7, 0xb0, 0xd8, 0x21, 0xdc, 0xd5, 0x91, 0xa4, 0xac, 0x3a,
-	0x95, 0x8f, 0x81, 0xf7, 0x57, 0xf2, 0x35, 0xfd, 0x3a, 0xde, 0x2b, 0xb5, 0xf4, 0xfb, 0x7f, 0x7d,
-	0xb2, 0xc4, 0xdc, 0xd8, 0x85, 0x37, 0x6f, 0x51, 0xfb, 0x5e, 0x85, 0x8e, 0x07, 0xb0, 0x36, 0x9a,
-	0x27, 0xa3, 0x68, 0x2a, 0x55, 0x21, 0xa6, 0x19, 0x25, 0x2e, 0xe6, 0x26, 0x68, 0x72, 0xbb, 0x50,
-	0xfe, 0x47, 0xd0, 0x3d, 0x95, 0x32, 0xe7, 0x52, 0x65, 0x69, 0xa2, 0x2f, 0x6d, 0x45, 0x9b, 0x36,
-	0xd7, 0x8e, 0xa1, 0xfc, 0x3f, 0x05, 0x0f, 0x53, 0xf2, 0xa7, 0xa2, 0x08, 0x2e, 0xbf, 0x4f, 0xca,
-	0xfe, 0x11, 0xb4, 0x33, 0x6d, 0x26, 0x26, 0xc1, 0xee, 0x52, 0x8c, 0x33, 0xa6, 0xc3, 0x4b, 0xa1,
-	0xcf, 0xa1, 0x71, 0x3c, 0x9b, 0xd6, 0x3f, 0x17, 0x35, 0xf5, 0xe7, 0xa2, 0xa5, 0x37, 0xae, 0xbd,
-	0xfc, 0xc6, 0x45, 0xcb, 0xbb, 0x48, 0xf3, 0x3f, 0x17, 0x79, 0x28, 0x43, 0xf3, 0x90, 0xae, 0x18,
-	0xfe, 0xd7, 0xd0, 0x29, 0x4f, 0xe6, 0x20, 0xa4, 0x2f, 0x42, 0x64, 0x1a, 0x07, 0xe1, 0x92, 0xa5,
-	0xe8, 0x87, 0xa8, 0x4c, 0xc2, 0x83, 0xf2, 0x48, 0x35, 0xb1, 0x3c, 0xb3, 0x29, 0xb4, 0x2c, 0x5e,
-	0xd7, 0xcf, 0xa0, 0x5b, 0x26, 0xd5, 0x47, 0xb2, 0x10, 0x64, 0x6c, 0x71, 0x24, 0x93, 0x9a, 0x21,
-	0xba, 0x9a, 0x31, 0x52, 0xaf, 0x29, 0xe9, 0xfa, 0xdb, 0xe0, 0x18, 0x4b, 0x66, 0xd0, 0x0c, 0xd2,
-	0x50, 0x3b, 0x50, 0x8b, 0x53, 0x1b, 0xe1, 0x98, 0xaa, 0x49, 0x79, 0x79, 0x4e, 0xd5, 0xc4, 0xff,
-	0x47, 0x1b, 0xd6, 0x9e, 0x8a, 0xe0, 0x6a, 0x96, 0x95, 0xb7, 0x57, 0xed, 0xf9, 0x63, 0x2d, 0x3d,
-	0x7f, 0x5e, 0x53, 0x48, 0x7e, 0x1b, 0xda, 0xb3, 0x24, 0x9a, 0x97, 0x69, 0x8d, 0xc7, 0x1d, 0x24,
-	0x75, 0x01, 0x35, 0x4e, 0x03, 0x7a, 0xf1, 0x90, 0xd3, 0x79, 0x7c, 0x41, 0x53, 0x6d, 0x22, 0x4a,
-	0x02, 0x69, 0xb0, 0xd0, 0xc4, 0x6a, 0x4d, 0xd6, 0x79, 0xa9, 0x26, 0xfb, 0x2e, 0x80, 0x08, 0x02,
-	0xa9, 0xd4, 0xb8, 0x7a, 0xd2, 0x78, 0x9a, 0xf3, 0x95, 0xbc, 0xa1, 0xf0, 0x20, 0x83, 0x5c, 0x16,
-	0xe3, 0xaa, 0xe8, 0xe7, 0x69, 0x0e, 0x8a, 0xdf, 0x87, 0x35, 0x25, 0x95, 0x8a, 0xd2, 0x64, 0x4c,
-	0xf7, 0x89, 0x29, 0x02, 0x76, 0x0d, 0x73, 0x84, 0x3c, 0x34, 0x03, 0x91, 0xa4, 0xc9, 0xcd, 0x34,
-	0x9d, 0xa9, 0xf2, 0x93, 0xd4, 0x82, 0xe1, 0xff, 0x09, 0xac, 0x0d, 0xe6, 0x19, 0x7d, 0x0e, 0xf8,
-	0xce, 0xfb, 0xbe, 0x06, 0xa6, 0xbd, 0x04, 0xe6, 0x0a, 0x62, 0x8d, 0x12, 0xb1, 0x9d, 0x7f, 0xb2,
-	0xa0, 0x89, 0x96, 0x8f, 0x8f, 0xc9, 0x3f, 0x94, 0x22, 0x2f, 0xce, 0xa5, 0x28, 0xd8, 0x92, 0x95,
-	0x6f, 0x2c, 0x51, 0xfe, 0x9d, 0x27, 0x16, 0xdb, 0xd6, 0x5f, 0x1a, 0xca, 0x0f, 0x28, 0x6b, 0xa5,
-	0xff, 0x90, 0x7f, 0xad, 0xea, 0x6f, 0x91, 0xfe, 0x97, 0x69, 0x94, 0xec, 0xe9, 0xf2, 0x3b, 0x5b,
-	0xf5, 0xb7, 0xd5, 0x1e, 0xec, 0x53, 0x70, 0x0e, 0x14, 0x3a, 0xf6, 0xcb, 0xaa, 0x74, 0x6f, 0xd4,
-	0x7d, 0xde, 0xbf, 0xb3, 0xf3, 0x0f, 0x0d, 0x68, 0x7e, 0x2d, 0xf3, 0x94, 0xfd, 0x18, 0xda, 0xa6,
-	0xb8, 0xc6, 0x6a, 0x45, 0xb4, 0x0d, 0x4a, 0x1c, 0x56, 0xaa, 0x6e, 0x34, 0x4b, 0x4f, 0x5f, 0x3d,
-	0xd5, 0x7b, 0x97, 0x55, 0xb5, 0xbf, 0x97, 0x16, 0xf5, 0x05, 0xf4, 0x86, 0x45, 0x2e, 0xc5, 0xb4,
-	0xa6, 0xbe, 0x0c, 0xd4, 0x6d, 0x8f, 0x67, 0xc2, 0xeb, 0x13, 0x70, 0x74, 0xf4, 0x5c, 0xe9, 0xb0,
-	0xfa, 0x0e, 0x26, 0xe5, 0x87, 0xd0, 0x19, 0x5e, 0xa6, 0xb3, 0x38, 0x1c, 0xca, 0xfc, 0x5a, 0xb2,
-	0x5a, 0x81, 0x7b, 0xa3, 0xd6, 0xf6, 0xef, 0xb0, 0x2d, 0x00, 0x1d, 0x20, 0xce, 0xa2, 0x50, 0xb1,
-	0x36, 0xca, 0x8e, 0x67, 0x53, 0x3d, 0x68, 0x2d, 0x72, 0x68, 0xcd, 0x5a, 0x10, 0x7d, 0x9d, 0xe6,
-	0xe7, 0xb0, 0xb6, 0x47, 0x97, 0xcc, 0x49, 0xbe, 0x7b, 0x9e, 0xe6, 0x05, 0x5b, 0x2d, 0x72, 0x6f,
-	0xac, 0x32, 0xfc, 0x3b, 0xec, 0x09, 0xb8, 0xa3, 0xfc, 0x46, 0xeb, 0xbf, 0x61, 0xee, 0x9e, 0x6a,
-	0xbe, 0x5b, 0x76, 0xb9, 0xf3, 0xeb, 0x06, 0x38, 0x3f, 0x4f, 0xf3, 0x2b, 0x99, 0xb3, 0x47, 0xe0,
-	0x50, 0xc1, 0xc2, 0x98, 0xd1, 0xa2, 0x78, 0x71, 0xdb, 0x44, 0x1f, 0x80, 0x47, 0xa0, 0x8c, 0x84,
-	0xba, 0xd2, 0x47, 0x45, 0x5f, 0xc2, 0x35, 0x2e, 0x3a, 0x2b, 0xa5, 0x73, 0x5d, 0xd7, 0x07, 0xb5,
-	0x28, 0xd2, 0x2c, 0x55, 0x11, 0x36, 0xda, 0xba, 0x24, 0x30, 0x44, 0xd3, 0x7c, 0x62, 0xb1, 0x8f,
-	0xa1, 0x39, 0xd4, 0x3b, 0x45, 0xa5, 0xea, 0xbb, 0xe0, 0xc6, 0x7a, 0xc9, 0x58, 0x8c, 0xfc, 0x18,
-	0x1c, 0x9d, 0xb2, 0xe8, 0x6d, 0x2e, 0xe5, 0xe1, 0x1b, 0xbd, 0x3a, 0xcb, 0x74, 0xf8, 0x08, 0x1c,
-	0x1d, 0xee, 0x74, 0x87, 0xa5, 0xd0, 0xb7, 0x51, 0x9e, 0x83, 0x7f, 0x87, 0x7d, 0x0c, 0x8e, 0x76,
-	0x72, 0xad, 0xb7, 0xe4, 0xf0, 0x7a, 0x77, 0x3a, 0xcc, 0x6a, 0xab, 0xe5, 0x32, 0x90, 0x51, 0x2d,
-	0x93, 0x61, 0xe5, 0x8e, 0x6e, 0x71, 0xbd, 0x2f, 0x60, 0x6d, 0x29, 0xeb, 0x61, 0x7d, 0x42, 0xf9,
-	0x96, 0x44, 0x68, 0xb5, 0xf3, 0xd3, 0xde, 0xbf, 0x7c, 0x7b, 0xdf, 0xfa, 0xd7, 0x6f, 0xef, 0x5b,
-	0xff, 0xfe, 0xed, 0x7d, 0xeb, 0x57, 0xff, 0x71, 0xff, 0xce, 0xb9, 0x43, 0x7f, 0x9f, 0xf8, 0xfc,
-	0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x05, 0x97, 0x08, 0xea, 0x82, 0x21, 0x00, 0x00,
-}
+func (m *ExportRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *ExportRequest) GetAccessKey() string {
+	if m != nil {
+		return m.AccessKey
+	}
+	return ""
+}
+
+func (m *ExportRequest) GetSecretKey() string {
+	if m != nil {
+		return m.SecretKey
+	}
+	return ""
+}
+
+func (m *ExportRequest) GetSessionToken() string {
+	if m != nil {
+		return m.SessionToken
+	}
+	return ""
+}
+
+func (m *ExportRequest) GetAnonymous() bool {
+	if m != nil {
+		return m.Anonymous
+	}
+	return false
+}
+
+// Request is a query request of the client API, with the options beyond it.
+type Request struct {
+	Request *api.Request `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Returns the plan of the query in the response, without running the query.
+	Explain bool `protobuf:"varint,2,opt,name=explain,proto3" json:"explain,omitempty"`
+	// Runs the query, and returns its plan with the time spent at every node in the response.
+	Profile bool `protobuf:"varint,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	// Cancels the query if it runs for longer than this duration, like "30s". It's capped by the
+	// timeout set on the server.
+	Timeout string `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Reads the data as it was at this time, an RFC 3339 time or a duration before now, like
+	// "1h". Only for read-only queries without a start_ts.
+	ReadAt               string   `protobuf:"bytes,5,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Request) Reset()         { *m = Request{} }
+func (m *Request) String() string { return proto.CompactTextString(m) }
+func (*Request) ProtoMessage()    {}
+func (*Request) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{53}
+}
+func (m *Request) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Request) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Request.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Request) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Request.Merge(m, src)
+}
+func (m *Request) XXX_Size() int {
+	return m.Size()
+}
+func (m *Request) XXX_DiscardUnknown() {
+	xxx_messageInfo_Request.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Request proto.InternalMessageInfo
+
+func (m *Request) GetRequest() *api.Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *Request) GetExplain() bool {
+	if m != nil {
+		return m.Explain
+	}
+	return false
+}
+
+func (m *Request) GetProfile() bool {
+	if m != nil {
+		return m.Profile
+	}
+	return false
+}
+
+func (m *Request) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+func (m *Request) GetReadAt() string {
+	if m != nil {
+		return m.ReadAt
+	}
+	return ""
+}
+
+type Response struct {
+	Response *api.Response `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// The JSON encoded plan of the query, for explain and profile requests.
+	Plan                 []byte   `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()    {}
+func (*Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{54}
+}
+func (m *Response) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Response.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response.Merge(m, src)
+}
+func (m *Response) XXX_Size() int {
+	return m.Size()
+}
+func (m *Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_Response.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Response proto.InternalMessageInfo
+
+func (m *Response) GetResponse() *api.Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *Response) GetPlan() []byte {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+// Mutation is a mutation of the client API, with the options beyond it.
+type Mutation struct {
+	Mutation *api.Mutation `protobuf:"bytes,1,opt,name=mutation,proto3" json:"mutation,omitempty"`
+	// The condition of the mutation of an upsert block, like "@if(eq(len(v), 0))". The
+	// mutation is only run if it holds.
+	Cond string `protobuf:"bytes,2,opt,name=cond,proto3" json:"cond,omitempty"`
+	// The query of an upsert block. Its variables can be used in the N-Quads of the mutation,
+	// like uid(v), and in its condition.
+	Query                string   `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Mutation) Reset()         { *m = Mutation{} }
+func (m *Mutation) String() string { return proto.CompactTextString(m) }
+func (*Mutation) ProtoMessage()    {}
+func (*Mutation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{55}
+}
+func (m *Mutation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Mutation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Mutation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Mutation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mutation.Merge(m, src)
+}
+func (m *Mutation) XXX_Size() int {
+	return m.Size()
+}
+func (m *Mutation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mutation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Mutation proto.InternalMessageInfo
+
+func (m *Mutation) GetMutation() *api.Mutation {
+	if m != nil {
+		return m.Mutation
+	}
+	return nil
+}
+
+func (m *Mutation) GetCond() string {
+	if m != nil {
+		return m.Cond
+	}
+	return ""
+}
+
+func (m *Mutation) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("pb.DirectedEdge_Op", DirectedEdge_Op_name, DirectedEdge_Op_value)
+	proto.RegisterEnum("pb.Mutations_DropOp", Mutations_DropOp_name, Mutations_DropOp_value)
+	proto.RegisterEnum("pb.Posting_ValType", Posting_ValType_name, Posting_ValType_value)
+	proto.RegisterEnum("pb.Posting_PostingType", Posting_PostingType_name, Posting_PostingType_value)
+	proto.RegisterEnum("pb.SchemaUpdate_Directive", SchemaUpdate_Directive_name, SchemaUpdate_Directive_value)
+	proto.RegisterEnum("pb.IngestPredicate_Op", IngestPredicate_Op_name, IngestPredicate_Op_value)
+	proto.RegisterType((*List)(nil), "pb.List")
+	proto.RegisterType((*TaskValue)(nil), "pb.TaskValue")
+	proto.RegisterType((*SrcFunction)(nil), "pb.SrcFunction")
+	proto.RegisterType((*Query)(nil), "pb.Query")
+	proto.RegisterType((*ValueList)(nil), "pb.ValueList")
+	proto.RegisterType((*LangList)(nil), "pb.LangList")
+	proto.RegisterType((*Result)(nil), "pb.Result")
+	proto.RegisterType((*Order)(nil), "pb.Order")
+	proto.RegisterType((*SortMessage)(nil), "pb.SortMessage")
+	proto.RegisterType((*SortResult)(nil), "pb.SortResult")
+	proto.RegisterType((*RaftContext)(nil), "pb.RaftContext")
+	proto.RegisterType((*Member)(nil), "pb.Member")
+	proto.RegisterType((*Group)(nil), "pb.Group")
+	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.Group.MembersEntry")
+	proto.RegisterMapType((map[string]*Tablet)(nil), "pb.Group.TabletsEntry")
+	proto.RegisterType((*ZeroProposal)(nil), "pb.ZeroProposal")
+	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.ZeroProposal.SnapshotTsEntry")
+	proto.RegisterType((*MembershipState)(nil), "pb.MembershipState")
+	proto.RegisterMapType((map[uint32]*Group)(nil), "pb.MembershipState.GroupsEntry")
+	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.MembershipState.ZerosEntry")
+	proto.RegisterType((*TimestampAt)(nil), "pb.TimestampAt")
+	proto.RegisterType((*ConnectionState)(nil), "pb.ConnectionState")
+	proto.RegisterType((*Tablet)(nil), "pb.Tablet")
+	proto.RegisterType((*DirectedEdge)(nil), "pb.DirectedEdge")
+	proto.RegisterType((*Mutations)(nil), "pb.Mutations")
+	proto.RegisterType((*Snapshot)(nil), "pb.Snapshot")
+	proto.RegisterType((*Proposal)(nil), "pb.Proposal")
+	proto.RegisterType((*KVS)(nil), "pb.KVS")
+	proto.RegisterType((*Posting)(nil), "pb.Posting")
+	proto.RegisterType((*UidBlock)(nil), "pb.UidBlock")
+	proto.RegisterType((*UidPack)(nil), "pb.UidPack")
+	proto.RegisterType((*PostingList)(nil), "pb.PostingList")
+	proto.RegisterType((*FacetParam)(nil), "pb.FacetParam")
+	proto.RegisterType((*FacetParams)(nil), "pb.FacetParams")
+	proto.RegisterType((*Facets)(nil), "pb.Facets")
+	proto.RegisterType((*FacetsList)(nil), "pb.FacetsList")
+	proto.RegisterType((*Function)(nil), "pb.Function")
+	proto.RegisterType((*FilterTree)(nil), "pb.FilterTree")
+	proto.RegisterType((*SchemaRequest)(nil), "pb.SchemaRequest")
+	proto.RegisterType((*SchemaResult)(nil), "pb.SchemaResult")
+	proto.RegisterType((*SchemaUpdate)(nil), "pb.SchemaUpdate")
+	proto.RegisterType((*TypeUpdate)(nil), "pb.TypeUpdate")
+	proto.RegisterType((*MapEntry)(nil), "pb.MapEntry")
+	proto.RegisterType((*MovePredicatePayload)(nil), "pb.MovePredicatePayload")
+	proto.RegisterType((*IngestPredicate)(nil), "pb.IngestPredicate")
+	proto.RegisterType((*IngestRequest)(nil), "pb.IngestRequest")
+	proto.RegisterType((*IngestState)(nil), "pb.IngestState")
+	proto.RegisterMapType((map[uint32]string)(nil), "pb.IngestState.LeadersEntry")
+	proto.RegisterMapType((map[string]uint32)(nil), "pb.IngestState.TabletsEntry")
+	proto.RegisterType((*TxnStatus)(nil), "pb.TxnStatus")
+	proto.RegisterType((*OracleDelta)(nil), "pb.OracleDelta")
+	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.OracleDelta.GroupChecksumsEntry")
+	proto.RegisterType((*TxnTimestamps)(nil), "pb.TxnTimestamps")
+	proto.RegisterType((*PeerResponse)(nil), "pb.PeerResponse")
+	proto.RegisterType((*RaftBatch)(nil), "pb.RaftBatch")
+	proto.RegisterType((*Num)(nil), "pb.Num")
+	proto.RegisterType((*AssignedIds)(nil), "pb.AssignedIds")
+	proto.RegisterType((*SnapshotMeta)(nil), "pb.SnapshotMeta")
+	proto.RegisterType((*Status)(nil), "pb.Status")
+	proto.RegisterType((*BackupRequest)(nil), "pb.BackupRequest")
+	proto.RegisterType((*ExportRequest)(nil), "pb.ExportRequest")
+	proto.RegisterType((*Request)(nil), "pb.Request")
+	proto.RegisterType((*Response)(nil), "pb.Response")
+	proto.RegisterType((*Mutation)(nil), "pb.Mutation")
+}
+
+func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
+
+var fileDescriptor_f80abaa17e25ccc8 = []byte{
+	// 4046 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x4d, 0x73, 0xe3, 0x56,
+	0x72, 0x02, 0x3f, 0x40, 0xa0, 0x49, 0x6a, 0xb8, 0xb0, 0x77, 0x96, 0xe6, 0x7a, 0x67, 0x64, 0xd8,
+	0x9e, 0x91, 0x3d, 0x1e, 0xcd, 0x58, 0xde, 0x38, 0x6b, 0xa7, 0x72, 0xe0, 0x8c, 0x38, 0x13, 0xd9,
+	0x1a, 0x49, 0x79, 0xa2, 0xc6, 0xd9, 0x3d, 0x2c, 0x0b, 0x02, 0x9e, 0x28, 0xac, 0x40, 0x00, 0x8b,
+	0x07, 0x2a, 0x94, 0x6f, 0xa9, 0xca, 0x26, 0x95, 0xaa, 0xdc, 0xd7, 0xa7, 0xa4, 0x2a, 0xc7, 0xfc,
+	0x82, 0x9c, 0x73, 0x4a, 0x72, 0x4a, 0xe5, 0x0f, 0x64, 0xcb, 0x49, 0xe5, 0x94, 0x73, 0xce, 0xa9,
+	0xee, 0xf7, 0x1e, 0x00, 0x72, 0xa4, 0x91, 0x9d, 0xaa, 0x9c, 0xf8, 0xfa, 0xeb, 0x7d, 0x74, 0xf7,
+	0xeb, 0xee, 0xd7, 0x20, 0x58, 0xe9, 0xc9, 0x56, 0x9a, 0x25, 0x79, 0xe2, 0xd4, 0xd2, 0x93, 0x81,
+	0xed, 0xa5, 0xa1, 0x04, 0x07, 0xf7, 0xa7, 0x61, 0x7e, 0x36, 0x3f, 0xd9, 0xf2, 0x93, 0xd9, 0xa3,
+	0x60, 0x9a, 0x79, 0xe9, 0xd9, 0xc3, 0x30, 0x79, 0x74, 0xe2, 0x05, 0x53, 0x9e, 0x3d, 0x4a, 0x4f,
+	0x1e, 0x69, 0x39, 0x77, 0x00, 0x8d, 0xbd, 0x50, 0xe4, 0x8e, 0x03, 0x8d, 0x79, 0x18, 0x88, 0xbe,
+	0xb1, 0x51, 0xdf, 0x34, 0x19, 0x8d, 0xdd, 0x17, 0x60, 0x8f, 0x3d, 0x71, 0xfe, 0xd2, 0x8b, 0xe6,
+	0xdc, 0xe9, 0x41, 0xfd, 0xc2, 0x8b, 0xfa, 0xc6, 0x86, 0xb1, 0xd9, 0x61, 0x38, 0x74, 0xb6, 0xc0,
+	0xba, 0xf0, 0xa2, 0x49, 0x7e, 0x99, 0xf2, 0x7e, 0x6d, 0xc3, 0xd8, 0x5c, 0xdf, 0x7e, 0x63, 0x2b,
+	0x3d, 0xd9, 0x3a, 0x4c, 0x44, 0x1e, 0xc6, 0xd3, 0xad, 0x97, 0x5e, 0x34, 0xbe, 0x4c, 0x39, 0x6b,
+	0x5d, 0xc8, 0x81, 0x7b, 0x00, 0xed, 0xa3, 0xcc, 0x7f, 0x36, 0x8f, 0xfd, 0x3c, 0x4c, 0x62, 0x5c,
+	0x31, 0xf6, 0x66, 0x9c, 0x66, 0xb4, 0x19, 0x8d, 0x11, 0xe7, 0x65, 0x53, 0xd1, 0xaf, 0x6f, 0xd4,
+	0x11, 0x87, 0x63, 0xa7, 0x0f, 0xad, 0x50, 0x3c, 0x4d, 0xe6, 0x71, 0xde, 0x6f, 0x6c, 0x18, 0x9b,
+	0x16, 0xd3, 0xa0, 0xfb, 0x57, 0x75, 0x68, 0xfe, 0xf1, 0x9c, 0x67, 0x97, 0x24, 0x97, 0xe7, 0x99,
+	0x9e, 0x0b, 0xc7, 0xce, 0x9b, 0xd0, 0x8c, 0xbc, 0x78, 0x2a, 0xfa, 0x35, 0x9a, 0x4c, 0x02, 0xce,
+	0x8f, 0xc1, 0xf6, 0x4e, 0x73, 0x9e, 0x4d, 0xe6, 0x61, 0xd0, 0xaf, 0x6f, 0x18, 0x9b, 0x26, 0xb3,
+	0x08, 0x71, 0x1c, 0x06, 0xce, 0x5b, 0x60, 0x05, 0xc9, 0xc4, 0xaf, 0xae, 0x15, 0x24, 0xb4, 0x96,
+	0xf3, 0x2e, 0x58, 0xf3, 0x30, 0x98, 0x44, 0xa1, 0xc8, 0xfb, 0xcd, 0x0d, 0x63, 0xb3, 0xbd, 0x6d,
+	0xe1, 0x61, 0x51, 0x77, 0xac, 0x35, 0x0f, 0x03, 0x1c, 0x38, 0x1f, 0x82, 0x25, 0x32, 0x7f, 0x72,
+	0x3a, 0x8f, 0xfd, 0xbe, 0x49, 0x4c, 0xb7, 0x90, 0xa9, 0x72, 0x6a, 0xd6, 0x12, 0x12, 0xc0, 0x63,
+	0x65, 0xfc, 0x82, 0x67, 0x82, 0xf7, 0x5b, 0x72, 0x29, 0x05, 0x3a, 0x8f, 0xa1, 0x7d, 0xea, 0xf9,
+	0x3c, 0x9f, 0xa4, 0x5e, 0xe6, 0xcd, 0xfa, 0x56, 0x39, 0xd1, 0x33, 0x44, 0x1f, 0x22, 0x56, 0x30,
+	0x38, 0x2d, 0x00, 0xe7, 0x13, 0xe8, 0x12, 0x24, 0x26, 0xa7, 0x61, 0x94, 0xf3, 0xac, 0x6f, 0x93,
+	0xcc, 0x3a, 0xc9, 0x10, 0x66, 0x9c, 0x71, 0xce, 0x3a, 0x92, 0x49, 0x62, 0x9c, 0x9f, 0x00, 0xf0,
+	0x45, 0xea, 0xc5, 0xc1, 0xc4, 0x8b, 0xa2, 0x3e, 0xd0, 0x1e, 0x6c, 0x89, 0x19, 0x46, 0x91, 0xf3,
+	0x23, 0xdc, 0x9f, 0x17, 0x4c, 0x72, 0xd1, 0xef, 0x6e, 0x18, 0x9b, 0x0d, 0x66, 0x22, 0x38, 0x16,
+	0xa8, 0x57, 0xdf, 0xf3, 0xcf, 0x78, 0x7f, 0x7d, 0xc3, 0xd8, 0x6c, 0x32, 0x09, 0xb8, 0xdb, 0x60,
+	0x93, 0x9f, 0x90, 0x1e, 0xde, 0x07, 0xf3, 0x02, 0x01, 0xe9, 0x4e, 0xed, 0xed, 0x2e, 0x6e, 0xa4,
+	0x70, 0x25, 0xa6, 0x88, 0xee, 0x1d, 0xb0, 0xf6, 0xbc, 0x78, 0xaa, 0xfd, 0x0f, 0x0d, 0x44, 0x02,
+	0x36, 0xa3, 0xb1, 0xfb, 0x4d, 0x0d, 0x4c, 0xc6, 0xc5, 0x3c, 0xca, 0x9d, 0xfb, 0x00, 0xa8, 0xfe,
+	0x99, 0x97, 0x67, 0xe1, 0x42, 0xcd, 0x5a, 0x1a, 0xc0, 0x9e, 0x87, 0xc1, 0x0b, 0x22, 0x39, 0x8f,
+	0xa1, 0x43, 0xb3, 0x6b, 0xd6, 0x5a, 0xb9, 0x81, 0x62, 0x7f, 0xac, 0x4d, 0x2c, 0x4a, 0xe2, 0x36,
+	0x98, 0x64, 0x71, 0xe9, 0x75, 0x5d, 0xa6, 0x20, 0xe7, 0x7d, 0x58, 0x0f, 0xe3, 0x1c, 0x2d, 0xe2,
+	0xe7, 0x93, 0x80, 0x0b, 0xed, 0x12, 0xdd, 0x02, 0xbb, 0xc3, 0x45, 0xee, 0x7c, 0x0c, 0x52, 0xad,
+	0x7a, 0xc1, 0xe6, 0x46, 0xbd, 0x50, 0x3d, 0xa9, 0x5b, 0xae, 0x48, 0x3c, 0x6a, 0xc5, 0x87, 0xd0,
+	0xc6, 0xf3, 0x69, 0x09, 0x93, 0x24, 0x3a, 0x74, 0x1a, 0xa5, 0x0e, 0x06, 0xc8, 0xa0, 0xd8, 0x51,
+	0x35, 0xe8, 0x76, 0xd2, 0x4d, 0x68, 0xec, 0x8e, 0xa0, 0x79, 0x90, 0x05, 0x3c, 0xbb, 0xd2, 0xf3,
+	0x1d, 0x68, 0x04, 0x5c, 0xf8, 0x74, 0x29, 0x2d, 0x46, 0xe3, 0xf2, 0x36, 0xd4, 0x2b, 0xb7, 0xc1,
+	0xfd, 0x1b, 0x03, 0xda, 0x47, 0x49, 0x96, 0xbf, 0xe0, 0x42, 0x78, 0x53, 0xee, 0xdc, 0x85, 0x66,
+	0x82, 0xd3, 0x2a, 0x0d, 0xdb, 0xb8, 0x27, 0x5a, 0x87, 0x49, 0xfc, 0x8a, 0x1d, 0x6a, 0xd7, 0xdb,
+	0x01, 0xbd, 0x84, 0xee, 0x51, 0x5d, 0x79, 0x09, 0x02, 0xa8, 0xeb, 0xe4, 0xf4, 0x54, 0x70, 0xa9,
+	0xcb, 0x26, 0x53, 0xd0, 0xb5, 0xce, 0xe6, 0xfe, 0x1e, 0x00, 0xee, 0xef, 0x7b, 0x7a, 0x81, 0xfb,
+	0x97, 0x06, 0xb4, 0x99, 0x77, 0x9a, 0x3f, 0x4d, 0xe2, 0x9c, 0x2f, 0x72, 0x67, 0x1d, 0x6a, 0x61,
+	0x40, 0x3a, 0x32, 0x59, 0x2d, 0x0c, 0x70, 0x77, 0xd3, 0x2c, 0x99, 0xa7, 0xa4, 0xa2, 0x2e, 0x93,
+	0x00, 0xe9, 0x32, 0x08, 0xb2, 0x7e, 0x5d, 0xe9, 0x32, 0x08, 0x32, 0xe7, 0x2e, 0xb4, 0x45, 0xec,
+	0xa5, 0xe2, 0x2c, 0xc9, 0x71, 0x77, 0x0d, 0xda, 0x1d, 0x68, 0xd4, 0x58, 0xe0, 0x35, 0x0a, 0xc5,
+	0x24, 0xe2, 0x5e, 0x16, 0xf3, 0x8c, 0x42, 0x83, 0xc5, 0xec, 0x50, 0xec, 0x49, 0x84, 0xfb, 0xef,
+	0x06, 0x98, 0x2f, 0xf8, 0xec, 0x84, 0x67, 0xaf, 0x6c, 0xe2, 0x2d, 0xb0, 0x68, 0xdd, 0x49, 0x18,
+	0xa8, 0x7d, 0xb4, 0x08, 0xde, 0x0d, 0xae, 0xdc, 0xc9, 0x6d, 0x30, 0x23, 0xee, 0xa1, 0x71, 0xa4,
+	0x1f, 0x2a, 0x08, 0x75, 0xe7, 0xcd, 0x26, 0x01, 0xf7, 0x02, 0xb5, 0xba, 0xe9, 0xcd, 0x76, 0xb8,
+	0x17, 0xe0, 0xd6, 0x23, 0x4f, 0xe4, 0x93, 0x79, 0x1a, 0x78, 0x39, 0xa7, 0x80, 0xd4, 0x40, 0xc7,
+	0x12, 0xf9, 0x31, 0x61, 0x9c, 0x0f, 0xe1, 0x07, 0x7e, 0x34, 0x17, 0x18, 0x0d, 0xc3, 0xf8, 0x34,
+	0x99, 0x24, 0x71, 0x74, 0x49, 0xfa, 0xb7, 0xd8, 0x2d, 0x45, 0xd8, 0x8d, 0x4f, 0x93, 0x83, 0x38,
+	0xba, 0xc4, 0x70, 0xa5, 0xcf, 0xb8, 0x2e, 0xc3, 0x95, 0x02, 0xdd, 0x7f, 0xa8, 0x41, 0xf3, 0x39,
+	0xe9, 0xef, 0x31, 0xb4, 0x66, 0x74, 0x54, 0x7d, 0xef, 0x6f, 0xa3, 0x6d, 0x88, 0xb6, 0x25, 0x75,
+	0x20, 0x46, 0x71, 0x9e, 0x5d, 0x32, 0xcd, 0x86, 0x12, 0xb9, 0x77, 0x12, 0xf1, 0x5c, 0xf4, 0x6b,
+	0xab, 0x12, 0x63, 0x49, 0x50, 0x12, 0x8a, 0x6d, 0xd5, 0x1e, 0xf5, 0x57, 0xec, 0x31, 0x00, 0xcb,
+	0x3f, 0xe3, 0xfe, 0xb9, 0x98, 0xcf, 0x94, 0xb5, 0x0a, 0x78, 0xf0, 0x0c, 0x3a, 0xd5, 0x7d, 0x60,
+	0x4e, 0x3b, 0xe7, 0x97, 0x64, 0x92, 0x06, 0xc3, 0xa1, 0xb3, 0x01, 0x4d, 0x8a, 0x0d, 0x64, 0x90,
+	0xf6, 0x36, 0xe0, 0x76, 0xa4, 0x08, 0x93, 0x84, 0xcf, 0x6b, 0x3f, 0x33, 0x70, 0x9e, 0xea, 0xee,
+	0xaa, 0xf3, 0xd8, 0xd7, 0xcf, 0x23, 0x45, 0x2a, 0xf3, 0xb8, 0x7f, 0x5b, 0x87, 0xce, 0x2f, 0x78,
+	0x96, 0x1c, 0x66, 0x49, 0x9a, 0x08, 0x2f, 0x72, 0x86, 0xcb, 0xa7, 0x93, 0x5a, 0xdc, 0x40, 0xe1,
+	0x2a, 0xdb, 0xd6, 0x51, 0x71, 0x5c, 0xa9, 0x9d, 0xea, 0xf9, 0x5d, 0x30, 0xa5, 0x76, 0xaf, 0x38,
+	0x82, 0xa2, 0x20, 0x8f, 0xd4, 0x67, 0xbf, 0x5e, 0xf2, 0xa8, 0xed, 0x29, 0x8a, 0x73, 0x07, 0x60,
+	0xe6, 0x2d, 0xf6, 0xb8, 0x27, 0xf8, 0x6e, 0xa0, 0xfd, 0xbe, 0xc4, 0xa0, 0x9e, 0x67, 0xde, 0x62,
+	0xbc, 0x88, 0xc7, 0x82, 0xfc, 0xae, 0xc1, 0x0a, 0xd8, 0x79, 0x1b, 0xec, 0x99, 0xb7, 0xc0, 0x0b,
+	0xb8, 0x1b, 0x28, 0xbf, 0x2b, 0x11, 0xce, 0x3b, 0x50, 0xcf, 0x17, 0x71, 0xbf, 0xa5, 0xf2, 0x1a,
+	0x16, 0x2d, 0xe3, 0x45, 0xac, 0xae, 0x2a, 0x43, 0x9a, 0x56, 0xa8, 0x55, 0x2a, 0xb4, 0x07, 0x75,
+	0x3f, 0x0c, 0x28, 0xb1, 0xd9, 0x0c, 0x87, 0xce, 0x7b, 0xd0, 0xcc, 0xc5, 0xc4, 0xcb, 0xfb, 0xa0,
+	0x26, 0xc2, 0x33, 0x84, 0x33, 0x2e, 0x72, 0x6f, 0x96, 0x0e, 0x73, 0xd6, 0xc8, 0xc5, 0x30, 0x1f,
+	0xfc, 0x21, 0xdc, 0x5a, 0xd1, 0x56, 0xd5, 0x5a, 0x5d, 0x39, 0xf9, 0x9b, 0x55, 0x6b, 0x35, 0xaa,
+	0x16, 0xfa, 0x5d, 0x1d, 0x6e, 0x29, 0x97, 0x39, 0x0b, 0xd3, 0xa3, 0x1c, 0xaf, 0x4d, 0x1f, 0x5a,
+	0x14, 0xcd, 0x78, 0xa6, 0x3c, 0x47, 0x83, 0xce, 0xef, 0x83, 0x49, 0x37, 0x58, 0x7b, 0xf3, 0xdd,
+	0x52, 0xf7, 0x85, 0xb8, 0xf4, 0x6e, 0x65, 0x38, 0xc5, 0xee, 0xfc, 0x14, 0x9a, 0x5f, 0xf3, 0x2c,
+	0x91, 0xd1, 0xb9, 0xbd, 0x7d, 0xe7, 0x2a, 0x39, 0xf4, 0x00, 0x25, 0x26, 0x99, 0xff, 0x1f, 0x4d,
+	0xf4, 0x1e, 0xc6, 0xe3, 0x59, 0x72, 0xc1, 0x83, 0x7e, 0x6b, 0xa3, 0xae, 0x3d, 0x44, 0x79, 0x91,
+	0x26, 0x69, 0x9b, 0x58, 0xa5, 0x4d, 0xee, 0x81, 0x99, 0x8b, 0x49, 0x94, 0x4c, 0xfb, 0xf6, 0x46,
+	0xfd, 0x2a, 0xa3, 0x34, 0x73, 0xb1, 0x97, 0x4c, 0x07, 0x3b, 0xd0, 0xae, 0xa8, 0xe1, 0x0a, 0x8b,
+	0xdc, 0x5d, 0xbe, 0x3f, 0x76, 0x11, 0x16, 0xaa, 0xd7, 0x70, 0x07, 0xa0, 0x54, 0xca, 0xff, 0xf5,
+	0x32, 0xbb, 0x0f, 0xa1, 0x5d, 0xd9, 0x21, 0x46, 0x69, 0x2f, 0xa7, 0x59, 0xea, 0xac, 0xe6, 0x11,
+	0x4c, 0xd1, 0x09, 0x67, 0xad, 0xe5, 0xc2, 0xfd, 0x33, 0x03, 0x6e, 0x3d, 0x4d, 0xe2, 0x98, 0x53,
+	0x3d, 0x27, 0x3d, 0xa2, 0xbc, 0x73, 0xc6, 0xb5, 0x77, 0xee, 0x03, 0x68, 0x0a, 0x64, 0x56, 0x9b,
+	0x79, 0xe3, 0x0a, 0x13, 0x33, 0xc9, 0x81, 0x31, 0x6e, 0xe6, 0x2d, 0x26, 0x29, 0x8f, 0x83, 0x30,
+	0x9e, 0xea, 0x18, 0x37, 0xf3, 0x16, 0x87, 0x12, 0xe3, 0xfe, 0x9d, 0x01, 0xa6, 0xbc, 0xae, 0x4b,
+	0x49, 0xc4, 0x58, 0x4e, 0x22, 0x6f, 0x83, 0x9d, 0x66, 0x3c, 0x08, 0x7d, 0xbd, 0xaa, 0xcd, 0x4a,
+	0x04, 0xfa, 0xfc, 0x69, 0x92, 0xf9, 0x9c, 0xa6, 0xb7, 0x98, 0x04, 0x10, 0x2b, 0x52, 0xcf, 0x97,
+	0x35, 0x69, 0x9d, 0x49, 0x00, 0x53, 0x8f, 0xb4, 0x39, 0xd9, 0xda, 0x62, 0x0a, 0xc2, 0x62, 0x9a,
+	0xd2, 0x36, 0x25, 0x0e, 0x9b, 0x48, 0x16, 0x22, 0x30, 0x63, 0xb8, 0x7f, 0x5f, 0x83, 0xce, 0x4e,
+	0x98, 0x71, 0x3f, 0xe7, 0xc1, 0x28, 0x98, 0xd2, 0x2c, 0x3c, 0xce, 0xc3, 0xfc, 0x52, 0xe5, 0x40,
+	0x05, 0x15, 0x25, 0x4c, 0x6d, 0xb9, 0x78, 0x97, 0xa6, 0xab, 0xd3, 0x7b, 0x43, 0x02, 0xce, 0x36,
+	0x00, 0x0d, 0xe4, 0x9b, 0xa3, 0x71, 0xfd, 0x9b, 0xc3, 0x26, 0x36, 0x1c, 0xa2, 0x82, 0xa4, 0x4c,
+	0x28, 0xf3, 0xa3, 0x49, 0x0f, 0x92, 0x39, 0xde, 0x0f, 0xaa, 0x89, 0x4e, 0x78, 0x44, 0xfe, 0x4f,
+	0x35, 0xd1, 0x09, 0x8f, 0x8a, 0x4a, 0xb4, 0x25, 0xb7, 0x83, 0x63, 0xe7, 0x5d, 0xa8, 0x25, 0x69,
+	0xdf, 0x2a, 0x17, 0xac, 0x1e, 0x6c, 0xeb, 0x20, 0x65, 0xb5, 0x24, 0x45, 0x2f, 0x90, 0x05, 0xb6,
+	0x72, 0x7e, 0xa0, 0xd0, 0x46, 0x45, 0x20, 0x53, 0x14, 0xf7, 0x36, 0xd4, 0x0e, 0x52, 0xa7, 0x05,
+	0xf5, 0xa3, 0xd1, 0xb8, 0xb7, 0x86, 0x83, 0x9d, 0xd1, 0x5e, 0xcf, 0x70, 0xff, 0xbb, 0x06, 0xf6,
+	0x8b, 0x79, 0xee, 0xa1, 0x4f, 0x89, 0xd7, 0x19, 0xf5, 0x2d, 0xb0, 0x44, 0xee, 0x65, 0x94, 0x1e,
+	0xa4, 0x53, 0xb6, 0x08, 0x1e, 0x0b, 0xe7, 0x1e, 0x34, 0x79, 0x30, 0xe5, 0x3a, 0x88, 0xf4, 0x56,
+	0xf7, 0xc9, 0x24, 0xd9, 0xd9, 0x04, 0x53, 0xf8, 0x67, 0x7c, 0xe6, 0xf5, 0x1b, 0x25, 0xe3, 0x11,
+	0x61, 0x64, 0x61, 0xc0, 0x14, 0xdd, 0xd9, 0x86, 0x1f, 0x86, 0xd3, 0x38, 0xc9, 0xf8, 0x24, 0x8c,
+	0x03, 0xbe, 0x98, 0xf8, 0x49, 0x7c, 0x1a, 0x85, 0x7e, 0xae, 0x0a, 0x8d, 0x37, 0x24, 0x71, 0x17,
+	0x69, 0x4f, 0x15, 0x89, 0xc2, 0xf2, 0x65, 0xca, 0x45, 0xdf, 0x2c, 0x0b, 0x61, 0x34, 0x84, 0x9a,
+	0x5a, 0x12, 0x9d, 0x87, 0xd0, 0x0a, 0xb2, 0x24, 0x9d, 0x24, 0x29, 0xe9, 0x79, 0x7d, 0xfb, 0x4d,
+	0xba, 0x0f, 0x5a, 0x03, 0x5b, 0x3b, 0x59, 0x92, 0x1e, 0xa4, 0xcc, 0x0c, 0xe8, 0x17, 0x8b, 0x2c,
+	0x62, 0x97, 0x3e, 0x21, 0x03, 0x8e, 0x8d, 0x18, 0xaa, 0xe9, 0xdd, 0x47, 0x60, 0x4a, 0x01, 0xc7,
+	0x82, 0xc6, 0xfe, 0xc1, 0xfe, 0x48, 0xaa, 0x76, 0xb8, 0xb7, 0xd7, 0x33, 0x10, 0xb5, 0x33, 0x1c,
+	0x0f, 0x7b, 0x35, 0x1c, 0x8d, 0x7f, 0x7e, 0x38, 0xea, 0xd5, 0xdd, 0x05, 0x58, 0x3a, 0x2b, 0x38,
+	0x1f, 0x60, 0x38, 0xa7, 0xdc, 0xa3, 0x6e, 0x2f, 0x05, 0xad, 0x4a, 0xf5, 0xc8, 0x34, 0x1d, 0x1d,
+	0x86, 0x14, 0xa1, 0xf3, 0x04, 0x01, 0xd5, 0xe2, 0xb5, 0xbe, 0xf4, 0x52, 0xc2, 0x3a, 0x3c, 0x89,
+	0xb9, 0xaa, 0xd7, 0x68, 0xec, 0xfe, 0x73, 0x0d, 0xac, 0x22, 0xdd, 0x3f, 0x00, 0x7b, 0xa6, 0x8f,
+	0xac, 0xe2, 0x42, 0x77, 0x49, 0x0f, 0xac, 0xa4, 0x3b, 0xb7, 0xa1, 0x76, 0x7e, 0xa1, 0x4c, 0x66,
+	0x22, 0xd7, 0x97, 0x2f, 0x59, 0xed, 0xfc, 0xa2, 0x0c, 0x2c, 0xcd, 0x1b, 0x03, 0xcb, 0x7d, 0xb8,
+	0xe5, 0x47, 0xdc, 0x8b, 0x27, 0x65, 0x5c, 0x90, 0xae, 0xbf, 0x4e, 0xe8, 0x43, 0x8d, 0xd5, 0xb1,
+	0xb4, 0x55, 0xe6, 0xdf, 0xf7, 0xa1, 0x19, 0xf0, 0x28, 0xf7, 0xaa, 0xcf, 0xd1, 0x83, 0xcc, 0xf3,
+	0x23, 0xbe, 0x83, 0x68, 0x26, 0xa9, 0xce, 0x26, 0x58, 0xba, 0x16, 0x51, 0x8f, 0x50, 0x7a, 0xd7,
+	0x68, 0x65, 0xb3, 0x82, 0x5a, 0xea, 0x12, 0xaa, 0xba, 0x7c, 0x00, 0x66, 0x18, 0x4f, 0xf1, 0xb1,
+	0xd5, 0x2e, 0x4f, 0xb3, 0x4b, 0x98, 0x62, 0x77, 0x4c, 0xb1, 0xb8, 0xbf, 0x84, 0xfa, 0x97, 0x2f,
+	0x8f, 0x94, 0x62, 0x8c, 0x57, 0x14, 0xa3, 0xd5, 0x5f, 0x2b, 0xd5, 0x5f, 0x99, 0xbf, 0x7e, 0xf3,
+	0xfc, 0xff, 0x53, 0x87, 0x96, 0x8a, 0x2c, 0xa8, 0x91, 0x79, 0x51, 0xbd, 0xe3, 0x70, 0xb9, 0x68,
+	0x28, 0x42, 0x54, 0xb5, 0x29, 0x52, 0xbf, 0xb9, 0x29, 0xe2, 0x7c, 0x0e, 0x9d, 0x54, 0xd2, 0xaa,
+	0x41, 0xed, 0x47, 0x55, 0x19, 0xf5, 0x4b, 0x72, 0xed, 0xb4, 0x04, 0x30, 0x16, 0xd0, 0x3b, 0x32,
+	0xf7, 0xa6, 0x64, 0xfc, 0x0e, 0x6b, 0x21, 0x3c, 0xf6, 0xa6, 0xd7, 0x84, 0xb6, 0xef, 0x10, 0xa1,
+	0x30, 0xdf, 0x25, 0x69, 0xbf, 0x43, 0x51, 0x07, 0xa3, 0x5a, 0x35, 0xe0, 0x74, 0x97, 0x03, 0xce,
+	0x8f, 0xc1, 0xf6, 0x93, 0xd9, 0x2c, 0x24, 0xda, 0xba, 0xaa, 0xb5, 0x09, 0x31, 0x16, 0xee, 0x5f,
+	0x18, 0xd0, 0x52, 0xa7, 0x75, 0xda, 0xd0, 0xda, 0x19, 0x3d, 0x1b, 0x1e, 0xef, 0x61, 0xcc, 0x03,
+	0x30, 0x9f, 0xec, 0xee, 0x0f, 0xd9, 0xcf, 0x7b, 0x06, 0x5e, 0xd2, 0xdd, 0xfd, 0x71, 0xaf, 0xe6,
+	0xd8, 0xd0, 0x7c, 0xb6, 0x77, 0x30, 0x1c, 0xf7, 0xea, 0x78, 0x4b, 0x9f, 0x1c, 0x1c, 0xec, 0xf5,
+	0x1a, 0x4e, 0x07, 0xac, 0x9d, 0xe1, 0x78, 0x34, 0xde, 0x7d, 0x31, 0xea, 0x35, 0x91, 0xf7, 0xf9,
+	0xe8, 0xa0, 0x67, 0xe2, 0xe0, 0x78, 0x77, 0xa7, 0xd7, 0x42, 0xfa, 0xe1, 0xf0, 0xe8, 0xe8, 0xab,
+	0x03, 0xb6, 0xd3, 0xb3, 0x70, 0xde, 0xa3, 0x31, 0xdb, 0xdd, 0x7f, 0xde, 0xb3, 0x71, 0x7c, 0xf0,
+	0xe4, 0x8b, 0xd1, 0xd3, 0x71, 0x0f, 0xdc, 0x8f, 0xa1, 0x5d, 0xd1, 0x20, 0x4a, 0xb3, 0xd1, 0xb3,
+	0xde, 0x1a, 0x2e, 0xf9, 0x72, 0xb8, 0x77, 0x3c, 0xea, 0x19, 0xce, 0x3a, 0x00, 0x0d, 0x27, 0x7b,
+	0xc3, 0xfd, 0xe7, 0xbd, 0x9a, 0xfb, 0x29, 0x58, 0xc7, 0x61, 0xf0, 0x24, 0x4a, 0xfc, 0x73, 0xf4,
+	0xa2, 0x13, 0x4f, 0x70, 0x55, 0x57, 0xd0, 0x18, 0x33, 0x19, 0xb9, 0xbb, 0x50, 0xb6, 0x57, 0x90,
+	0xbb, 0x0f, 0xad, 0xe3, 0x30, 0x38, 0xf4, 0xfc, 0x73, 0x8c, 0x58, 0x27, 0x28, 0x3f, 0x11, 0xe1,
+	0xd7, 0x5c, 0x05, 0x71, 0x9b, 0x30, 0x47, 0xe1, 0xd7, 0xdc, 0x79, 0x0f, 0x4c, 0x02, 0x74, 0xa5,
+	0x48, 0xb7, 0x44, 0xaf, 0xc9, 0x14, 0xcd, 0xfd, 0x6b, 0xa3, 0xd8, 0x3b, 0x35, 0x49, 0xee, 0x42,
+	0x23, 0xf5, 0xfc, 0x73, 0x15, 0xa7, 0xda, 0x4a, 0x06, 0xd7, 0x63, 0x44, 0x70, 0xee, 0x83, 0xa5,
+	0x1c, 0x44, 0x4f, 0xdc, 0xae, 0x78, 0x12, 0x2b, 0x88, 0xcb, 0xa6, 0xab, 0x2f, 0x9b, 0x0e, 0x8f,
+	0x27, 0xd2, 0x28, 0xa4, 0xe7, 0x6e, 0x1d, 0xe3, 0x99, 0x84, 0xdc, 0x9f, 0x02, 0x94, 0x1d, 0xa8,
+	0x2b, 0x1e, 0x3d, 0x6f, 0x42, 0xd3, 0x8b, 0x42, 0xa5, 0x15, 0x9b, 0x49, 0xc0, 0xdd, 0x87, 0x76,
+	0x29, 0x45, 0xb9, 0xcd, 0x8b, 0xa2, 0xc9, 0x39, 0xbf, 0x14, 0x24, 0x6b, 0xb1, 0x96, 0x17, 0x45,
+	0x5f, 0xf2, 0x4b, 0x81, 0xa9, 0x43, 0xb6, 0xbc, 0x6a, 0x2b, 0x3d, 0x14, 0x12, 0x65, 0x92, 0xe8,
+	0x7e, 0x04, 0xe6, 0x33, 0xe9, 0xaa, 0xa5, 0x3b, 0x1b, 0xd7, 0x26, 0xdc, 0xcf, 0x00, 0xca, 0x36,
+	0x8c, 0xf3, 0x40, 0xb5, 0xd6, 0x84, 0x6c, 0xe4, 0x19, 0x65, 0x6d, 0x2b, 0x99, 0x54, 0x57, 0x8d,
+	0x98, 0xdd, 0x1d, 0xb0, 0x5e, 0xdb, 0xac, 0x54, 0x0a, 0xa8, 0x95, 0x0a, 0xb8, 0xa2, 0x7d, 0xe9,
+	0xfe, 0x0a, 0xa0, 0x6c, 0xc1, 0xa9, 0xdb, 0x25, 0x67, 0xc1, 0xdb, 0xf5, 0x21, 0xbe, 0x56, 0xc3,
+	0x28, 0xc8, 0x78, 0xbc, 0x74, 0xea, 0x42, 0x82, 0x15, 0x74, 0x67, 0x03, 0x1a, 0xd4, 0x59, 0xac,
+	0x97, 0x71, 0x55, 0xef, 0x8f, 0x11, 0xc5, 0x5d, 0x40, 0x57, 0xe6, 0x71, 0xc6, 0x7f, 0x3d, 0xe7,
+	0xe2, 0xb5, 0xd5, 0xe1, 0x1d, 0x80, 0x22, 0x0b, 0xe8, 0x1e, 0x69, 0x05, 0x83, 0x4e, 0x70, 0x1a,
+	0xf2, 0x28, 0xd0, 0xa7, 0x51, 0x10, 0x1a, 0x59, 0xe6, 0xf7, 0x06, 0xa1, 0x25, 0xe0, 0xfe, 0x01,
+	0x74, 0xf4, 0xca, 0xd4, 0xa9, 0x79, 0x50, 0xd4, 0x18, 0x86, 0x7a, 0x08, 0xa0, 0x69, 0x24, 0xcb,
+	0x7e, 0x12, 0xf0, 0x27, 0xb5, 0xbe, 0xa1, 0xcb, 0x0c, 0xf7, 0xdf, 0xea, 0x5a, 0x5a, 0x35, 0x26,
+	0x96, 0x2a, 0x57, 0x63, 0xb5, 0x72, 0x5d, 0xae, 0x02, 0x6b, 0xdf, 0xa9, 0x0a, 0xfc, 0x19, 0xd8,
+	0x01, 0x95, 0x42, 0xe1, 0x85, 0x8e, 0xcb, 0x83, 0xd5, 0xb2, 0x47, 0x15, 0x4b, 0xe1, 0x05, 0x67,
+	0x25, 0x33, 0xee, 0x25, 0x4f, 0xce, 0x79, 0x1c, 0x7e, 0xcd, 0x33, 0x75, 0xe6, 0x12, 0x51, 0xb6,
+	0xb9, 0x64, 0x45, 0x24, 0x81, 0xa2, 0x63, 0x67, 0x96, 0x1d, 0x3b, 0xd4, 0xe7, 0x3c, 0x15, 0x3c,
+	0xcb, 0x75, 0x0d, 0x2d, 0xa1, 0xa2, 0xdc, 0xb4, 0x15, 0x2f, 0x96, 0x9b, 0xef, 0x40, 0x27, 0x4e,
+	0xe2, 0x49, 0x3c, 0x8f, 0x22, 0xac, 0xf2, 0x55, 0x73, 0xb6, 0x1d, 0x27, 0xf1, 0xbe, 0x42, 0x61,
+	0xef, 0xa6, 0xca, 0x22, 0xfd, 0xb9, 0x2d, 0x7b, 0x37, 0x15, 0x3e, 0xf2, 0xfa, 0x4d, 0xe8, 0x25,
+	0x27, 0xbf, 0xc2, 0x36, 0x26, 0x6a, 0x6c, 0x42, 0x8e, 0xdc, 0x91, 0x79, 0x5f, 0xe2, 0x51, 0x45,
+	0xfb, 0xde, 0x8c, 0xbb, 0x9f, 0x81, 0x5d, 0x28, 0xa1, 0x52, 0x4b, 0xd9, 0xd0, 0xdc, 0xdd, 0xdf,
+	0x19, 0xfd, 0x49, 0xcf, 0xc0, 0x50, 0xce, 0x46, 0x2f, 0x47, 0xec, 0x68, 0xd4, 0xab, 0x61, 0x98,
+	0xdd, 0x19, 0xed, 0x8d, 0xc6, 0xa3, 0x5e, 0xfd, 0x8b, 0x86, 0xd5, 0xea, 0x59, 0xcc, 0xe2, 0x8b,
+	0x34, 0x0a, 0xfd, 0x30, 0x77, 0xcf, 0x01, 0xca, 0xb2, 0x0f, 0xe3, 0x4d, 0xb9, 0xb6, 0xb4, 0xa8,
+	0x95, 0xab, 0x55, 0xb1, 0x20, 0x55, 0xae, 0x56, 0xbb, 0xae, 0x20, 0x55, 0xce, 0x87, 0x91, 0x29,
+	0xcf, 0xb0, 0x02, 0x95, 0xaf, 0x16, 0x05, 0xb9, 0xc7, 0x60, 0xbd, 0xf0, 0xd2, 0x57, 0xde, 0x81,
+	0x9d, 0xa2, 0x77, 0x30, 0x57, 0x3d, 0x36, 0x95, 0xbb, 0xdf, 0x87, 0x96, 0x0a, 0x85, 0xea, 0x36,
+	0x2d, 0x85, 0x49, 0x4d, 0x73, 0x7f, 0x63, 0xc0, 0x9b, 0x2f, 0x92, 0x0b, 0x5e, 0x94, 0x06, 0x87,
+	0xde, 0x65, 0x94, 0x78, 0xc1, 0x0d, 0x0e, 0xfa, 0x13, 0x00, 0x91, 0xcc, 0x33, 0x9f, 0x4f, 0xa6,
+	0x45, 0x6b, 0xcf, 0x96, 0x98, 0xe7, 0xea, 0x2b, 0x03, 0x17, 0x39, 0x11, 0xeb, 0xf2, 0x52, 0x22,
+	0x8c, 0xa4, 0x1f, 0x82, 0x99, 0x2f, 0xe2, 0xb2, 0xd1, 0xd8, 0xcc, 0xf1, 0xb1, 0xee, 0xfe, 0xd6,
+	0x80, 0x5b, 0x2b, 0x45, 0xca, 0x0d, 0x5b, 0x58, 0x79, 0xb5, 0x3a, 0xf7, 0x28, 0xee, 0x48, 0xc7,
+	0xbf, 0x7d, 0x45, 0xcd, 0xa3, 0xde, 0x30, 0xee, 0x16, 0xbd, 0x4f, 0x6c, 0x68, 0x1e, 0x8d, 0x87,
+	0x0c, 0xb3, 0xb5, 0xae, 0x9e, 0x65, 0x1d, 0x8d, 0xee, 0x40, 0xc9, 0x7a, 0xf8, 0xe4, 0x80, 0x8d,
+	0x7b, 0x75, 0xf7, 0x18, 0xba, 0x72, 0x26, 0x1d, 0x71, 0x96, 0xc3, 0x8a, 0xf1, 0x4a, 0x58, 0x59,
+	0xdd, 0x18, 0xe6, 0x8c, 0x93, 0x24, 0xd3, 0x06, 0x95, 0x80, 0xfb, 0x9b, 0x1a, 0xb4, 0xe5, 0xbc,
+	0xf2, 0x81, 0x2d, 0xa5, 0x8c, 0x42, 0xea, 0xd3, 0xd5, 0xbe, 0xe1, 0xdb, 0xe5, 0x99, 0x48, 0xe2,
+	0x9a, 0xee, 0xe1, 0xa7, 0xd4, 0xc5, 0x0c, 0x78, 0x26, 0xa3, 0xda, 0x15, 0x72, 0x7b, 0x92, 0xac,
+	0xe4, 0x14, 0xf3, 0xe0, 0xf3, 0x1b, 0x1b, 0x7e, 0x4b, 0xd5, 0x60, 0xb7, 0xda, 0xa5, 0xf8, 0x1c,
+	0x3a, 0xd5, 0x49, 0x6f, 0x6a, 0x3f, 0xd9, 0x15, 0x59, 0xf7, 0x29, 0xd8, 0xe3, 0x05, 0x35, 0x19,
+	0xe6, 0x62, 0xa9, 0x12, 0x33, 0x5e, 0x53, 0x89, 0xd5, 0x56, 0x2a, 0xb1, 0xff, 0x34, 0xa0, 0x5d,
+	0x29, 0xd5, 0x9d, 0x77, 0xa0, 0x91, 0x2f, 0xe2, 0xe5, 0x6f, 0x33, 0x7a, 0x11, 0x46, 0x24, 0x0c,
+	0x40, 0xd8, 0x81, 0xf0, 0x84, 0x08, 0xa7, 0x31, 0x0f, 0xd4, 0x94, 0xd8, 0x95, 0x18, 0x2a, 0x94,
+	0xb3, 0x07, 0xb7, 0x64, 0x6a, 0xd1, 0xdd, 0x55, 0xad, 0xd2, 0x77, 0x57, 0x9e, 0x06, 0xb2, 0x6f,
+	0xf3, 0x54, 0x73, 0x49, 0xcd, 0xae, 0x4f, 0x97, 0x90, 0x83, 0x21, 0xbc, 0x71, 0x05, 0xdb, 0xf7,
+	0x6a, 0xd5, 0xdd, 0x85, 0x2e, 0xb6, 0xb6, 0x74, 0x2b, 0x47, 0x14, 0x4e, 0x53, 0x57, 0x9d, 0x9b,
+	0x7b, 0xd0, 0x39, 0xe4, 0x3c, 0x63, 0x5c, 0xa4, 0x49, 0x2c, 0xab, 0x38, 0x41, 0x87, 0x56, 0x75,
+	0x88, 0x82, 0xdc, 0x5f, 0x82, 0x8d, 0xaf, 0xbf, 0x27, 0x5e, 0xee, 0x9f, 0x7d, 0x9f, 0xd7, 0xe1,
+	0x3d, 0x68, 0xa5, 0x32, 0x3e, 0xa8, 0xb7, 0x5c, 0x87, 0x92, 0x9e, 0x8a, 0x19, 0x4c, 0x13, 0x5d,
+	0x06, 0xf5, 0xfd, 0xf9, 0xac, 0xfa, 0x41, 0xb5, 0x21, 0x3f, 0xa8, 0x2e, 0xb5, 0x53, 0x6a, 0xcb,
+	0xed, 0x14, 0xbc, 0xef, 0xa7, 0x49, 0xf6, 0xa7, 0x5e, 0x16, 0xf0, 0x40, 0x5d, 0x96, 0x12, 0xe1,
+	0xfe, 0x02, 0xda, 0xda, 0x32, 0xbb, 0x01, 0x7d, 0x33, 0x25, 0xd7, 0xd8, 0x0d, 0x96, 0x3c, 0x45,
+	0xf6, 0x3c, 0x78, 0x1c, 0xec, 0x6a, 0x93, 0x4a, 0x60, 0x79, 0x65, 0xd5, 0x2a, 0x2c, 0x1a, 0x39,
+	0xcf, 0xa0, 0xa3, 0xdf, 0x6f, 0x2f, 0x78, 0xee, 0x91, 0xb3, 0x45, 0x21, 0x8f, 0x2b, 0x8e, 0x68,
+	0x49, 0xc4, 0x58, 0xbc, 0xe6, 0xa3, 0x86, 0xbb, 0x05, 0xa6, 0xf2, 0x64, 0x07, 0x1a, 0x7e, 0x12,
+	0xc8, 0xb0, 0xd5, 0x64, 0x34, 0x46, 0x75, 0xcc, 0xc4, 0x54, 0x57, 0x53, 0x33, 0x31, 0x75, 0xff,
+	0xab, 0x06, 0xdd, 0x27, 0x9e, 0x7f, 0x3e, 0x4f, 0x75, 0x70, 0xa9, 0xbc, 0xb4, 0x8d, 0xa5, 0x97,
+	0xf6, 0xf5, 0xab, 0xa2, 0xcc, 0x3c, 0x0e, 0x17, 0xba, 0xce, 0xb5, 0x99, 0x89, 0xa0, 0xfc, 0x50,
+	0x10, 0x25, 0x3e, 0x3d, 0xae, 0x29, 0xda, 0xda, 0xac, 0x80, 0xa9, 0x0d, 0x16, 0xc6, 0x3e, 0x57,
+	0xba, 0x90, 0xc0, 0xea, 0xb7, 0x07, 0xf3, 0xaa, 0x6f, 0x41, 0x9e, 0xef, 0x73, 0x21, 0x26, 0xe5,
+	0xeb, 0xd9, 0x96, 0x98, 0x2f, 0xf9, 0x25, 0x92, 0x05, 0xf7, 0x33, 0x9e, 0x4f, 0xca, 0xe6, 0xb6,
+	0x2d, 0x31, 0x48, 0x7e, 0x17, 0xba, 0x82, 0x0b, 0x11, 0x26, 0xf1, 0x84, 0x0a, 0x0c, 0xd5, 0xec,
+	0xee, 0x28, 0xe4, 0x18, 0x71, 0xe8, 0x06, 0x5e, 0x9c, 0xc4, 0x97, 0xb3, 0x64, 0x2e, 0xf4, 0x47,
+	0xdb, 0x02, 0x81, 0x8a, 0xa5, 0xa2, 0xa8, 0x4d, 0x92, 0x34, 0x76, 0x36, 0xa0, 0x83, 0x8f, 0x96,
+	0x89, 0xd6, 0x5c, 0x47, 0x6e, 0x1b, 0x71, 0x4c, 0x7e, 0x64, 0xfb, 0x6d, 0x0d, 0xba, 0xa3, 0x45,
+	0x4a, 0xdf, 0xd9, 0x6e, 0xac, 0x1b, 0x2b, 0x36, 0xa8, 0x2d, 0xd9, 0x60, 0x45, 0xd1, 0xf5, 0x42,
+	0xd1, 0x58, 0x49, 0x26, 0xd9, 0xcc, 0xcb, 0x95, 0x9a, 0x15, 0xe4, 0x6c, 0x40, 0x1b, 0xf3, 0x5e,
+	0x18, 0x4b, 0x1b, 0x34, 0x89, 0x58, 0x45, 0xad, 0xe8, 0xd3, 0x7c, 0xbd, 0x3e, 0x5b, 0x37, 0xea,
+	0xd3, 0xba, 0x49, 0x9f, 0xf6, 0x8a, 0x3e, 0xdd, 0x6f, 0x0c, 0x68, 0x69, 0x9d, 0xdc, 0xc3, 0x83,
+	0xd3, 0xb0, 0x6f, 0x54, 0xae, 0xb7, 0x22, 0x33, 0x4d, 0xc4, 0xbb, 0x87, 0x45, 0x90, 0x17, 0xc6,
+	0xea, 0x0e, 0x6b, 0x10, 0x29, 0x69, 0x96, 0x9c, 0x86, 0x91, 0x6e, 0xba, 0x6a, 0x10, 0x29, 0x79,
+	0x38, 0xe3, 0xc9, 0x5c, 0xeb, 0x48, 0x83, 0x85, 0xba, 0xbd, 0x5c, 0x29, 0x88, 0xd4, 0x3d, 0xcc,
+	0xdd, 0x5d, 0xb0, 0x8a, 0x48, 0xf6, 0x01, 0x58, 0x99, 0x1a, 0xab, 0xbd, 0x75, 0xd5, 0xde, 0x24,
+	0x92, 0x15, 0x64, 0xf4, 0x90, 0x34, 0xf2, 0x62, 0xf5, 0x70, 0xa5, 0xb1, 0x3b, 0x01, 0x4b, 0x77,
+	0x9c, 0x70, 0x2a, 0xdd, 0x72, 0x5a, 0x9a, 0x4a, 0x33, 0xb0, 0x82, 0x2c, 0x6f, 0x71, 0x1c, 0xe8,
+	0xbe, 0x2d, 0x8e, 0xf1, 0xe2, 0xfc, 0x1a, 0xff, 0x91, 0xa1, 0xee, 0x9a, 0x04, 0xb6, 0xff, 0xd1,
+	0x80, 0x06, 0x86, 0x4c, 0x6c, 0x78, 0xfd, 0x11, 0xf7, 0xb2, 0xfc, 0x84, 0x7b, 0xb9, 0xb3, 0x14,
+	0x1e, 0x07, 0x4b, 0x90, 0xbb, 0xf6, 0xd8, 0x70, 0xb6, 0xe4, 0x37, 0x5c, 0xfd, 0x6d, 0xba, 0xab,
+	0x03, 0x2f, 0x05, 0xe6, 0x55, 0xfe, 0x4d, 0xe2, 0xff, 0x22, 0x09, 0xe3, 0xa7, 0xf2, 0xcb, 0xa5,
+	0xb3, 0x1a, 0xa8, 0x57, 0x25, 0x9c, 0x87, 0x60, 0xee, 0x8a, 0x43, 0x7e, 0x15, 0x2b, 0x55, 0xa0,
+	0xd5, 0x64, 0xe1, 0xae, 0x6d, 0xff, 0x4b, 0x03, 0x1a, 0xf8, 0xb9, 0xc1, 0xf9, 0x08, 0x5a, 0xea,
+	0x03, 0x80, 0x53, 0x69, 0xf4, 0x0f, 0xe8, 0x09, 0xb2, 0xf2, 0x65, 0x80, 0x56, 0xe9, 0xc9, 0x22,
+	0xb6, 0xec, 0xc9, 0x39, 0xe5, 0xe7, 0x8c, 0x57, 0x36, 0xf5, 0x19, 0xf4, 0x8e, 0xf2, 0x8c, 0x7b,
+	0xb3, 0x0a, 0xfb, 0xb2, 0xa2, 0xae, 0x6a, 0xf0, 0x91, 0xbe, 0x1e, 0x80, 0x29, 0xd3, 0xee, 0x8a,
+	0xc0, 0x6a, 0xaf, 0x8e, 0x98, 0xef, 0x43, 0xfb, 0xe8, 0x2c, 0x99, 0x47, 0xc1, 0x11, 0xcf, 0x2e,
+	0xb8, 0x53, 0xf9, 0x02, 0x38, 0xa8, 0x8c, 0xdd, 0x35, 0x67, 0x13, 0x40, 0x66, 0x96, 0xe3, 0x30,
+	0x10, 0x4e, 0x0b, 0x69, 0xfb, 0xf3, 0x99, 0x9c, 0xb4, 0x92, 0x72, 0x24, 0x67, 0x25, 0xfb, 0xbe,
+	0x8e, 0xf3, 0x13, 0xe8, 0x3e, 0xa5, 0xea, 0xe4, 0x20, 0x1b, 0x62, 0xbd, 0xe7, 0xac, 0x7e, 0x05,
+	0x1c, 0xac, 0x22, 0xdc, 0x35, 0xe7, 0x31, 0x58, 0xe3, 0xec, 0x52, 0xf2, 0xff, 0x40, 0x15, 0x2d,
+	0xe5, 0x7a, 0x57, 0x9c, 0xd2, 0xf9, 0x04, 0xda, 0x47, 0x94, 0xf6, 0xa8, 0xbe, 0x93, 0x42, 0x4b,
+	0xd5, 0xea, 0xe0, 0x56, 0x89, 0xd2, 0xf6, 0xfa, 0x18, 0x3a, 0xcf, 0xc2, 0x38, 0x14, 0x67, 0xd7,
+	0x4b, 0xad, 0xda, 0xec, 0xe3, 0xe5, 0x2f, 0x48, 0xab, 0x1f, 0xbd, 0x06, 0xab, 0x08, 0x77, 0x6d,
+	0xfb, 0xcf, 0x1b, 0x60, 0x7e, 0x95, 0x64, 0xe7, 0x3c, 0x73, 0x3e, 0x04, 0x93, 0x2e, 0x97, 0xf2,
+	0xf0, 0xa2, 0xf7, 0x7b, 0x95, 0x0e, 0xde, 0x03, 0x9b, 0xec, 0x85, 0xff, 0xa5, 0x91, 0x5e, 0x44,
+	0xff, 0x7f, 0x92, 0x26, 0x93, 0x4f, 0x6f, 0x72, 0xb9, 0x75, 0xe9, 0x43, 0x45, 0x8f, 0x7b, 0xa9,
+	0x09, 0x3b, 0x68, 0xc9, 0x26, 0xe9, 0x11, 0xde, 0x9a, 0xc7, 0x86, 0xf3, 0x01, 0x34, 0x8e, 0xa4,
+	0x11, 0x90, 0xa9, 0xfc, 0x37, 0xc8, 0x60, 0x5d, 0x23, 0x8a, 0x99, 0x1f, 0x81, 0x29, 0xdf, 0x65,
+	0x52, 0x2d, 0x4b, 0xcd, 0x86, 0x41, 0xaf, 0x8a, 0x52, 0x02, 0xf7, 0xc0, 0x94, 0x29, 0x5c, 0x0a,
+	0x2c, 0xa5, 0xf3, 0x81, 0x76, 0x11, 0x77, 0xcd, 0xf9, 0x00, 0x4c, 0x99, 0x81, 0x24, 0xdf, 0x52,
+	0x36, 0x92, 0xa7, 0x93, 0xa5, 0x83, 0xbc, 0x50, 0x8c, 0xfb, 0x3c, 0xac, 0x3c, 0xcb, 0x1c, 0x7d,
+	0xa2, 0x2b, 0xa2, 0xc2, 0x67, 0xd0, 0x5d, 0x7a, 0xc2, 0x39, 0x7d, 0xd2, 0xf2, 0x15, 0xaf, 0xba,
+	0x57, 0xec, 0xfa, 0xd1, 0xab, 0xaf, 0xae, 0xd7, 0x2c, 0xb4, 0x0d, 0xdd, 0xc3, 0x8c, 0xa7, 0x5e,
+	0xc6, 0xa5, 0xd0, 0x77, 0xf0, 0x9c, 0xed, 0xaf, 0xc0, 0xdc, 0xa1, 0x7f, 0xe7, 0x61, 0xef, 0x8b,
+	0x4c, 0xe9, 0xb4, 0xa5, 0x29, 0x35, 0x3f, 0x01, 0x3a, 0x06, 0x39, 0xf7, 0x0b, 0x5f, 0xe9, 0x54,
+	0x7d, 0x65, 0x20, 0x63, 0xb4, 0xbe, 0x64, 0xee, 0xda, 0x93, 0xde, 0x3f, 0x7d, 0x7b, 0xc7, 0xf8,
+	0xd7, 0x6f, 0xef, 0x18, 0xbf, 0xfb, 0xf6, 0x8e, 0xf1, 0xcd, 0x7f, 0xdc, 0x59, 0x3b, 0x31, 0xe9,
+	0xff, 0x7e, 0x9f, 0xfc, 0xef, 0x00, 0x93, 0xba, 0xc0, 0xbe, 0x33, 0x28, 0x00, 0x00,
+}
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
@@ -4229,6 +4892,9 @@ type ZeroClient interface {
 	Timestamps(ctx context.Context, in *Num, opts ...grpc.CallOption) (*AssignedIds, error)
 	CommitOrAbort(ctx context.Context, in *api.TxnContext, opts ...grpc.CallOption) (*api.TxnContext, error)
 	TryAbort(ctx context.Context, in *TxnTimestamps, opts ...grpc.CallOption) (*OracleDelta, error)
+	StartIngest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*IngestState, error)
+	FinishIngest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*api.Payload, error)
+	TimestampAt(ctx context.Context, in *TimestampAt, opts ...grpc.CallOption) (*TimestampAt, error)
 }
 
 type zeroClient struct {
@@ -4366,6 +5032,33 @@ func (c *zeroClient) TryAbort(ctx context.Context, in *TxnTimestamps, opts ...gr
 	return out, nil
 }
 
+func (c *zeroClient) StartIngest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*IngestState, error) {
+	out := new(IngestState)
+	err := c.cc.Invoke(ctx, "/pb.Zero/StartIngest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zeroClient) FinishIngest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*api.Payload, error) {
+	out := new(api.Payload)
+	err := c.cc.Invoke(ctx, "/pb.Zero/FinishIngest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zeroClient) TimestampAt(ctx context.Context, in *TimestampAt, opts ...grpc.CallOption) (*TimestampAt, error) {
+	out := new(TimestampAt)
+	err := c.cc.Invoke(ctx, "/pb.Zero/TimestampAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ZeroServer is the server API for Zero service.
 type ZeroServer interface {
 	// These 3 endpoints are for handling membership.
@@ -4378,6 +5071,9 @@ type ZeroServer interface {
 	Timestamps(context.Context, *Num) (*AssignedIds, error)
 	CommitOrAbort(context.Context, *api.TxnContext) (*api.TxnContext, error)
 	TryAbort(context.Context, *TxnTimestamps) (*OracleDelta, error)
+	StartIngest(context.Context, *IngestRequest) (*IngestState, error)
+	FinishIngest(context.Context, *IngestRequest) (*api.Payload, error)
+	TimestampAt(context.Context, *TimestampAt) (*TimestampAt, error)
 }
 
 func RegisterZeroServer(s *grpc.Server, srv ZeroServer) {
@@ -4552,25 +5248,79 @@ func _Zero_TryAbort_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Zero_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.Zero",
-	HandlerType: (*ZeroServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Connect",
-			Handler:    _Zero_Connect_Handler,
-		},
-		{
-			MethodName: "UpdateMembership",
-			Handler:    _Zero_UpdateMembership_Handler,
-		},
-		{
-			MethodName: "ShouldServe",
-			Handler:    _Zero_ShouldServe_Handler,
-		},
-		{
-			MethodName: "AssignUids",
-			Handler:    _Zero_AssignUids_Handler,
+func _Zero_StartIngest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZeroServer).StartIngest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Zero/StartIngest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZeroServer).StartIngest(ctx, req.(*IngestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zero_FinishIngest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZeroServer).FinishIngest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Zero/FinishIngest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZeroServer).FinishIngest(ctx, req.(*IngestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zero_TimestampAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimestampAt)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZeroServer).TimestampAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Zero/TimestampAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZeroServer).TimestampAt(ctx, req.(*TimestampAt))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Zero_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Zero",
+	HandlerType: (*ZeroServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    _Zero_Connect_Handler,
+		},
+		{
+			MethodName: "UpdateMembership",
+			Handler:    _Zero_UpdateMembership_Handler,
+		},
+		{
+			MethodName: "ShouldServe",
+			Handler:    _Zero_ShouldServe_Handler,
+		},
+		{
+			MethodName: "AssignUids",
+			Handler:    _Zero_AssignUids_Handler,
 		},
 		{
 			MethodName: "Timestamps",
@@ -4584,6 +5334,18 @@ var _Zero_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TryAbort",
 			Handler:    _Zero_TryAbort_Handler,
 		},
+		{
+			MethodName: "StartIngest",
+			Handler:    _Zero_StartIngest_Handler,
+		},
+		{
+			MethodName: "FinishIngest",
+			Handler:    _Zero_FinishIngest_Handler,
+		},
+		{
+			MethodName: "TimestampAt",
+			Handler:    _Zero_TimestampAt_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4614,6 +5376,8 @@ type WorkerClient interface {
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*Status, error)
 	ReceivePredicate(ctx context.Context, opts ...grpc.CallOption) (Worker_ReceivePredicateClient, error)
 	MovePredicate(ctx context.Context, in *MovePredicatePayload, opts ...grpc.CallOption) (*api.Payload, error)
+	IngestPredicate(ctx context.Context, opts ...grpc.CallOption) (Worker_IngestPredicateClient, error)
+	PrepareIngest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*api.Payload, error)
 }
 
 type workerClient struct {
@@ -4752,6 +5516,49 @@ func (c *workerClient) MovePredicate(ctx context.Context, in *MovePredicatePaylo
 	return out, nil
 }
 
+func (c *workerClient) IngestPredicate(ctx context.Context, opts ...grpc.CallOption) (Worker_IngestPredicateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker_serviceDesc.Streams[2], "/pb.Worker/IngestPredicate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerIngestPredicateClient{stream}
+	return x, nil
+}
+
+type Worker_IngestPredicateClient interface {
+	Send(*KVS) error
+	CloseAndRecv() (*api.Payload, error)
+	grpc.ClientStream
+}
+
+type workerIngestPredicateClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerIngestPredicateClient) Send(m *KVS) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *workerIngestPredicateClient) CloseAndRecv() (*api.Payload, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(api.Payload)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *workerClient) PrepareIngest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*api.Payload, error) {
+	out := new(api.Payload)
+	err := c.cc.Invoke(ctx, "/pb.Worker/PrepareIngest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkerServer is the server API for Worker service.
 type WorkerServer interface {
 	// Data serving RPCs.
@@ -4764,6 +5571,8 @@ type WorkerServer interface {
 	Export(context.Context, *ExportRequest) (*Status, error)
 	ReceivePredicate(Worker_ReceivePredicateServer) error
 	MovePredicate(context.Context, *MovePredicatePayload) (*api.Payload, error)
+	IngestPredicate(Worker_IngestPredicateServer) error
+	PrepareIngest(context.Context, *IngestRequest) (*api.Payload, error)
 }
 
 func RegisterWorkerServer(s *grpc.Server, srv WorkerServer) {
@@ -4948,6 +5757,50 @@ func _Worker_MovePredicate_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Worker_IngestPredicate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WorkerServer).IngestPredicate(&workerIngestPredicateServer{stream})
+}
+
+type Worker_IngestPredicateServer interface {
+	SendAndClose(*api.Payload) error
+	Recv() (*KVS, error)
+	grpc.ServerStream
+}
+
+type workerIngestPredicateServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerIngestPredicateServer) SendAndClose(m *api.Payload) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *workerIngestPredicateServer) Recv() (*KVS, error) {
+	m := new(KVS)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Worker_PrepareIngest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).PrepareIngest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Worker/PrepareIngest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).PrepareIngest(ctx, req.(*IngestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Worker_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.Worker",
 	HandlerType: (*WorkerServer)(nil),
@@ -4980,6 +5833,10 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MovePredicate",
 			Handler:    _Worker_MovePredicate_Handler,
 		},
+		{
+			MethodName: "PrepareIngest",
+			Handler:    _Worker_PrepareIngest_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4993,7 +5850,109 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Worker_ReceivePredicate_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "IngestPredicate",
+			Handler:       _Worker_IngestPredicate_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pb.proto",
+}
+
+// DgraphClient is the client API for Dgraph service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DgraphClient interface {
+	Query(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	Mutate(ctx context.Context, in *Mutation, opts ...grpc.CallOption) (*api.Assigned, error)
+}
+
+type dgraphClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDgraphClient(cc *grpc.ClientConn) DgraphClient {
+	return &dgraphClient{cc}
+}
+
+func (c *dgraphClient) Query(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/pb.Dgraph/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dgraphClient) Mutate(ctx context.Context, in *Mutation, opts ...grpc.CallOption) (*api.Assigned, error) {
+	out := new(api.Assigned)
+	err := c.cc.Invoke(ctx, "/pb.Dgraph/Mutate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DgraphServer is the server API for Dgraph service.
+type DgraphServer interface {
+	Query(context.Context, *Request) (*Response, error)
+	Mutate(context.Context, *Mutation) (*api.Assigned, error)
+}
+
+func RegisterDgraphServer(s *grpc.Server, srv DgraphServer) {
+	s.RegisterService(&_Dgraph_serviceDesc, srv)
+}
+
+func _Dgraph_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DgraphServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Dgraph/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DgraphServer).Query(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dgraph_Mutate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Mutation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DgraphServer).Mutate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Dgraph/Mutate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DgraphServer).Mutate(ctx, req.(*Mutation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Dgraph_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Dgraph",
+	HandlerType: (*DgraphServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Query",
+			Handler:    _Dgraph_Query_Handler,
+		},
+		{
+			MethodName: "Mutate",
+			Handler:    _Dgraph_Mutate_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb.proto",
 }
 
@@ -5167,9 +6126,9 @@ func (m *Query) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.UidList.Size()))
-		n1, err1 := m.UidList.MarshalTo(dAtA[i:])
-		if err1 != nil {
-			return 0, err1
+		n1, err := m.UidList.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
 		i += n1
 	}
@@ -5177,9 +6136,9 @@ func (m *Query) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x32
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.SrcFunc.Size()))
-		n2, err2 := m.SrcFunc.MarshalTo(dAtA[i:])
-		if err2 != nil {
-			return 0, err2
+		n2, err := m.SrcFunc.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
 		i += n2
 	}
@@ -5197,9 +6156,9 @@ func (m *Query) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x42
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.FacetParam.Size()))
-		n3, err3 := m.FacetParam.MarshalTo(dAtA[i:])
-		if err3 != nil {
-			return 0, err3
+		n3, err := m.FacetParam.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
 		i += n3
 	}
@@ -5207,9 +6166,9 @@ func (m *Query) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.FacetsFilter.Size()))
-		n4, err4 := m.FacetsFilter.MarshalTo(dAtA[i:])
-		if err4 != nil {
-			return 0, err4
+		n4, err := m.FacetsFilter.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
 		i += n4
 	}
@@ -5596,6 +6555,16 @@ func (m *RaftContext) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.SnapshotTs))
 	}
+	if m.IsLearner {
+		dAtA[i] = 0x28
+		i++
+		if m.IsLearner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -5669,6 +6638,16 @@ func (m *Member) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.Learner {
+		dAtA[i] = 0x70
+		i++
+		if m.Learner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -5709,9 +6688,9 @@ func (m *Group) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintPb(dAtA, i, uint64(v.Size()))
-				n7, err7 := v.MarshalTo(dAtA[i:])
-				if err7 != nil {
-					return 0, err7
+				n7, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
 				}
 				i += n7
 			}
@@ -5737,9 +6716,9 @@ func (m *Group) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintPb(dAtA, i, uint64(v.Size()))
-				n8, err8 := v.MarshalTo(dAtA[i:])
-				if err8 != nil {
-					return 0, err8
+				n8, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
 				}
 				i += n8
 			}
@@ -5795,9 +6774,9 @@ func (m *ZeroProposal) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Member.Size()))
-		n9, err9 := m.Member.MarshalTo(dAtA[i:])
-		if err9 != nil {
-			return 0, err9
+		n9, err := m.Member.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
 		i += n9
 	}
@@ -5805,9 +6784,9 @@ func (m *ZeroProposal) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Tablet.Size()))
-		n10, err10 := m.Tablet.MarshalTo(dAtA[i:])
-		if err10 != nil {
-			return 0, err10
+		n10, err := m.Tablet.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
 		i += n10
 	}
@@ -5830,9 +6809,9 @@ func (m *ZeroProposal) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Txn.Size()))
-		n11, err11 := m.Txn.MarshalTo(dAtA[i:])
-		if err11 != nil {
-			return 0, err11
+		n11, err := m.Txn.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
 		i += n11
 	}
@@ -5848,6 +6827,16 @@ func (m *ZeroProposal) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPb(dAtA, i, uint64(len(m.Cid)))
 		i += copy(dAtA[i:], m.Cid)
 	}
+	if m.TsAt != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.TsAt.Size()))
+		n12, err := m.TsAt.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -5893,11 +6882,11 @@ func (m *MembershipState) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintPb(dAtA, i, uint64(v.Size()))
-				n12, err12 := v.MarshalTo(dAtA[i:])
-				if err12 != nil {
-					return 0, err12
+				n13, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
 				}
-				i += n12
+				i += n13
 			}
 		}
 	}
@@ -5920,11 +6909,11 @@ func (m *MembershipState) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintPb(dAtA, i, uint64(v.Size()))
-				n13, err13 := v.MarshalTo(dAtA[i:])
-				if err13 != nil {
-					return 0, err13
+				n14, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
 				}
-				i += n13
+				i += n14
 			}
 		}
 	}
@@ -5961,13 +6950,25 @@ func (m *MembershipState) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPb(dAtA, i, uint64(len(m.Cid)))
 		i += copy(dAtA[i:], m.Cid)
 	}
+	if len(m.TsLog) > 0 {
+		for _, msg := range m.TsLog {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintPb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ConnectionState) Marshal() (dAtA []byte, err error) {
+func (m *TimestampAt) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5977,31 +6978,62 @@ func (m *ConnectionState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConnectionState) MarshalTo(dAtA []byte) (int, error) {
+func (m *TimestampAt) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Member != nil {
-		dAtA[i] = 0xa
+	if m.At != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintPb(dAtA, i, uint64(m.Member.Size()))
-		n14, err14 := m.Member.MarshalTo(dAtA[i:])
-		if err14 != nil {
-			return 0, err14
-		}
-		i += n14
+		i = encodeVarintPb(dAtA, i, uint64(m.At))
 	}
-	if m.State != nil {
-		dAtA[i] = 0x12
+	if m.Ts != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintPb(dAtA, i, uint64(m.State.Size()))
-		n15, err15 := m.State.MarshalTo(dAtA[i:])
-		if err15 != nil {
-			return 0, err15
-		}
+		i = encodeVarintPb(dAtA, i, uint64(m.Ts))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ConnectionState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Member != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Member.Size()))
+		n15, err := m.Member.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
 		i += n15
 	}
+	if m.State != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.State.Size()))
+		n16, err := m.State.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
 	if m.MaxPending != 0 {
 		dAtA[i] = 0x18
 		i++
@@ -6266,11 +7298,11 @@ func (m *Snapshot) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Context.Size()))
-		n16, err16 := m.Context.MarshalTo(dAtA[i:])
-		if err16 != nil {
-			return 0, err16
+		n17, err := m.Context.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n16
+		i += n17
 	}
 	if m.Index != 0 {
 		dAtA[i] = 0x10
@@ -6317,11 +7349,11 @@ func (m *Proposal) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Mutations.Size()))
-		n17, err17 := m.Mutations.MarshalTo(dAtA[i:])
-		if err17 != nil {
-			return 0, err17
+		n18, err := m.Mutations.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n17
+		i += n18
 	}
 	if len(m.Kv) > 0 {
 		for _, msg := range m.Kv {
@@ -6339,11 +7371,11 @@ func (m *Proposal) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.State.Size()))
-		n18, err18 := m.State.MarshalTo(dAtA[i:])
-		if err18 != nil {
-			return 0, err18
+		n19, err := m.State.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n18
+		i += n19
 	}
 	if len(m.CleanPredicate) > 0 {
 		dAtA[i] = 0x32
@@ -6361,27 +7393,37 @@ func (m *Proposal) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x42
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Delta.Size()))
-		n19, err19 := m.Delta.MarshalTo(dAtA[i:])
-		if err19 != nil {
-			return 0, err19
+		n20, err := m.Delta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n19
+		i += n20
 	}
 	if m.Snapshot != nil {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Snapshot.Size()))
-		n20, err20 := m.Snapshot.MarshalTo(dAtA[i:])
-		if err20 != nil {
-			return 0, err20
+		n21, err := m.Snapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n20
+		i += n21
 	}
 	if m.Index != 0 {
 		dAtA[i] = 0x50
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Index))
 	}
+	if m.Ingest != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Ingest.Size()))
+		n22, err := m.Ingest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -6425,6 +7467,16 @@ func (m *KVS) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.Ingest != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Ingest.Size()))
+		n23, err := m.Ingest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -6602,11 +7654,11 @@ func (m *PostingList) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Pack.Size()))
-		n21, err21 := m.Pack.MarshalTo(dAtA[i:])
-		if err21 != nil {
-			return 0, err21
+		n24, err := m.Pack.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n21
+		i += n24
 	}
 	if len(m.Postings) > 0 {
 		for _, msg := range m.Postings {
@@ -6626,21 +7678,21 @@ func (m *PostingList) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPb(dAtA, i, uint64(m.CommitTs))
 	}
 	if len(m.Splits) > 0 {
-		dAtA23 := make([]byte, len(m.Splits)*10)
-		var j22 int
+		dAtA26 := make([]byte, len(m.Splits)*10)
+		var j25 int
 		for _, num := range m.Splits {
 			for num >= 1<<7 {
-				dAtA23[j22] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA26[j25] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j22++
+				j25++
 			}
-			dAtA23[j22] = uint8(num)
-			j22++
+			dAtA26[j25] = uint8(num)
+			j25++
 		}
 		dAtA[i] = 0x22
 		i++
-		i = encodeVarintPb(dAtA, i, uint64(j22))
-		i += copy(dAtA[i:], dAtA23[:j22])
+		i = encodeVarintPb(dAtA, i, uint64(j25))
+		i += copy(dAtA[i:], dAtA26[:j25])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6875,11 +7927,11 @@ func (m *FilterTree) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Func.Size()))
-		n24, err24 := m.Func.MarshalTo(dAtA[i:])
-		if err24 != nil {
-			return 0, err24
+		n27, err := m.Func.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n24
+		i += n27
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -7142,6 +8194,16 @@ func (m *TypeUpdate) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Strict {
+		dAtA[i] = 0x18
+		i++
+		if m.Strict {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -7179,11 +8241,11 @@ func (m *MapEntry) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Posting.Size()))
-		n25, err25 := m.Posting.MarshalTo(dAtA[i:])
-		if err25 != nil {
-			return 0, err25
+		n28, err := m.Posting.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n25
+		i += n28
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -7233,6 +8295,152 @@ func (m *MovePredicatePayload) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *IngestPredicate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IngestPredicate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Predicate) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Predicate)))
+		i += copy(dAtA[i:], m.Predicate)
+	}
+	if m.Ts != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Ts))
+	}
+	if m.Op != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Op))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *IngestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IngestRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Predicates) > 0 {
+		for _, s := range m.Predicates {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Ts != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Ts))
+	}
+	if m.Abort {
+		dAtA[i] = 0x18
+		i++
+		if m.Abort {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *IngestState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IngestState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Ts != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Ts))
+	}
+	if len(m.Tablets) > 0 {
+		for k, _ := range m.Tablets {
+			dAtA[i] = 0x12
+			i++
+			v := m.Tablets[k]
+			mapSize := 1 + len(k) + sovPb(uint64(len(k))) + 1 + sovPb(uint64(v))
+			i = encodeVarintPb(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPb(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x10
+			i++
+			i = encodeVarintPb(dAtA, i, uint64(v))
+		}
+	}
+	if len(m.Leaders) > 0 {
+		for k, _ := range m.Leaders {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Leaders[k]
+			mapSize := 1 + sovPb(uint64(k)) + 1 + len(v) + sovPb(uint64(len(v)))
+			i = encodeVarintPb(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0x8
+			i++
+			i = encodeVarintPb(dAtA, i, uint64(k))
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPb(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *TxnStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -7333,21 +8541,21 @@ func (m *TxnTimestamps) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Ts) > 0 {
-		dAtA27 := make([]byte, len(m.Ts)*10)
-		var j26 int
+		dAtA30 := make([]byte, len(m.Ts)*10)
+		var j29 int
 		for _, num := range m.Ts {
 			for num >= 1<<7 {
-				dAtA27[j26] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA30[j29] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j26++
+				j29++
 			}
-			dAtA27[j26] = uint8(num)
-			j26++
+			dAtA30[j29] = uint8(num)
+			j29++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPb(dAtA, i, uint64(j26))
-		i += copy(dAtA[i:], dAtA27[:j26])
+		i = encodeVarintPb(dAtA, i, uint64(j29))
+		i += copy(dAtA[i:], dAtA30[:j29])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -7405,21 +8613,21 @@ func (m *RaftBatch) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Context.Size()))
-		n28, err28 := m.Context.MarshalTo(dAtA[i:])
-		if err28 != nil {
-			return 0, err28
+		n31, err := m.Context.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n28
+		i += n31
 	}
 	if m.Payload != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.Payload.Size()))
-		n29, err29 := m.Payload.MarshalTo(dAtA[i:])
-		if err29 != nil {
-			return 0, err29
+		n32, err := m.Payload.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += n29
+		i += n32
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -7647,6 +8855,17 @@ func (m *BackupRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.Type) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if m.BaseReadTs != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.BaseReadTs))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -7683,22 +8902,205 @@ func (m *ExportRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPb(dAtA, i, uint64(m.UnixTs))
 	}
+	if len(m.Format) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Format)))
+		i += copy(dAtA[i:], m.Format)
+	}
+	if len(m.Destination) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Destination)))
+		i += copy(dAtA[i:], m.Destination)
+	}
+	if len(m.AccessKey) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.AccessKey)))
+		i += copy(dAtA[i:], m.AccessKey)
+	}
+	if len(m.SecretKey) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.SecretKey)))
+		i += copy(dAtA[i:], m.SecretKey)
+	}
+	if len(m.SessionToken) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.SessionToken)))
+		i += copy(dAtA[i:], m.SessionToken)
+	}
+	if m.Anonymous {
+		dAtA[i] = 0x48
+		i++
+		if m.Anonymous {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func encodeVarintPb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Request) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *List) Size() (n int) {
+
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Request != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Request.Size()))
+		n33, err := m.Request.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n33
+	}
+	if m.Explain {
+		dAtA[i] = 0x10
+		i++
+		if m.Explain {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Profile {
+		dAtA[i] = 0x18
+		i++
+		if m.Profile {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Timeout) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Timeout)))
+		i += copy(dAtA[i:], m.Timeout)
+	}
+	if len(m.ReadAt) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.ReadAt)))
+		i += copy(dAtA[i:], m.ReadAt)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Response != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Response.Size()))
+		n34, err := m.Response.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n34
+	}
+	if len(m.Plan) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Plan)))
+		i += copy(dAtA[i:], m.Plan)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Mutation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Mutation) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Mutation != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(m.Mutation.Size()))
+		n35, err := m.Mutation.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n35
+	}
+	if len(m.Cond) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Cond)))
+		i += copy(dAtA[i:], m.Cond)
+	}
+	if len(m.Query) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Query)))
+		i += copy(dAtA[i:], m.Query)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintPb(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *List) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -7993,6 +9395,9 @@ func (m *RaftContext) Size() (n int) {
 	if m.SnapshotTs != 0 {
 		n += 1 + sovPb(uint64(m.SnapshotTs))
 	}
+	if m.IsLearner {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8027,6 +9432,9 @@ func (m *Member) Size() (n int) {
 	if m.ClusterInfoOnly {
 		n += 2
 	}
+	if m.Learner {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8120,6 +9528,10 @@ func (m *ZeroProposal) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPb(uint64(l))
 	}
+	if m.TsAt != nil {
+		l = m.TsAt.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8180,6 +9592,30 @@ func (m *MembershipState) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPb(uint64(l))
 	}
+	if len(m.TsLog) > 0 {
+		for _, e := range m.TsLog {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TimestampAt) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.At != 0 {
+		n += 1 + sovPb(uint64(m.At))
+	}
+	if m.Ts != 0 {
+		n += 1 + sovPb(uint64(m.Ts))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8396,6 +9832,10 @@ func (m *Proposal) Size() (n int) {
 	if m.Index != 0 {
 		n += 1 + sovPb(uint64(m.Index))
 	}
+	if m.Ingest != nil {
+		l = m.Ingest.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8417,6 +9857,10 @@ func (m *KVS) Size() (n int) {
 	if m.Done {
 		n += 2
 	}
+	if m.Ingest != nil {
+		l = m.Ingest.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8789,6 +10233,9 @@ func (m *TypeUpdate) Size() (n int) {
 			n += 1 + l + sovPb(uint64(l))
 		}
 	}
+	if m.Strict {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8843,6 +10290,83 @@ func (m *MovePredicatePayload) Size() (n int) {
 	return n
 }
 
+func (m *IngestPredicate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Predicate)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Ts != 0 {
+		n += 1 + sovPb(uint64(m.Ts))
+	}
+	if m.Op != 0 {
+		n += 1 + sovPb(uint64(m.Op))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IngestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Predicates) > 0 {
+		for _, s := range m.Predicates {
+			l = len(s)
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if m.Ts != 0 {
+		n += 1 + sovPb(uint64(m.Ts))
+	}
+	if m.Abort {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IngestState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Ts != 0 {
+		n += 1 + sovPb(uint64(m.Ts))
+	}
+	if len(m.Tablets) > 0 {
+		for k, v := range m.Tablets {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPb(uint64(len(k))) + 1 + sovPb(uint64(v))
+			n += mapEntrySize + 1 + sovPb(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Leaders) > 0 {
+		for k, v := range m.Leaders {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + sovPb(uint64(k)) + 1 + len(v) + sovPb(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPb(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *TxnStatus) Size() (n int) {
 	if m == nil {
 		return 0
@@ -9064,6 +10588,13 @@ func (m *BackupRequest) Size() (n int) {
 	if m.Anonymous {
 		n += 2
 	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.BaseReadTs != 0 {
+		n += 1 + sovPb(uint64(m.BaseReadTs))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -9085,30 +10616,127 @@ func (m *ExportRequest) Size() (n int) {
 	if m.UnixTs != 0 {
 		n += 1 + sovPb(uint64(m.UnixTs))
 	}
+	l = len(m.Format)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.Destination)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.AccessKey)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.SecretKey)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.SessionToken)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Anonymous {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func sovPb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Request != nil {
+		l = m.Request.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Explain {
+		n += 2
+	}
+	if m.Profile {
+		n += 2
+	}
+	l = len(m.Timeout)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.ReadAt)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
-func sozPb(x uint64) (n int) {
-	return sovPb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *List) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
+
+func (m *Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Response != nil {
+		l = m.Response.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.Plan)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Mutation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Mutation != nil {
+		l = m.Mutation.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.Cond)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovPb(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPb(x uint64) (n int) {
+	return sovPb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *List) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
@@ -10819,6 +12447,26 @@ func (m *RaftContext) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsLearner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsLearner = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -11013,6 +12661,26 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ClusterInfoOnly = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Learner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Learner = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -11731,6 +13399,42 @@ func (m *ZeroProposal) Unmarshal(dAtA []byte) error {
 			}
 			m.Cid = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TsAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TsAt == nil {
+				m.TsAt = &TimestampAt{}
+			}
+			if err := m.TsAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -12157,6 +13861,132 @@ func (m *MembershipState) Unmarshal(dAtA []byte) error {
 			}
 			m.Cid = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TsLog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TsLog = append(m.TsLog, &TimestampAt{})
+			if err := m.TsLog[len(m.TsLog)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimestampAt) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimestampAt: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimestampAt: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field At", wireType)
+			}
+			m.At = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.At |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
+			}
+			m.Ts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ts |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -13490,24 +15320,60 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingest", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ingest == nil {
+				m.Ingest = &IngestPredicate{}
+			}
+			if err := m.Ingest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
 
 	if iNdEx > l {
@@ -13598,6 +15464,42 @@ func (m *KVS) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Done = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ingest == nil {
+				m.Ingest = &IngestPredicate{}
+			}
+			if err := m.Ingest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -15717,6 +17619,26 @@ func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strict", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Strict = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -16019,7 +17941,7 @@ func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TxnStatus) Unmarshal(dAtA []byte) error {
+func (m *IngestPredicate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16042,17 +17964,17 @@ func (m *TxnStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TxnStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: IngestPredicate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TxnStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IngestPredicate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
 			}
-			m.StartTs = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16062,16 +17984,29 @@ func (m *TxnStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTs |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Predicate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
 			}
-			m.CommitTs = 0
+			m.Ts = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16081,7 +18016,26 @@ func (m *TxnStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CommitTs |= uint64(b&0x7F) << shift
+				m.Ts |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			}
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= IngestPredicate_Op(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -16111,7 +18065,7 @@ func (m *TxnStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OracleDelta) Unmarshal(dAtA []byte) error {
+func (m *IngestRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16134,17 +18088,17 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OracleDelta: wiretype end group for non-group")
+			return fmt.Errorf("proto: IngestRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OracleDelta: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IngestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Txns", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicates", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16154,31 +18108,29 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Txns = append(m.Txns, &TxnStatus{})
-			if err := m.Txns[len(m.Txns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Predicates = append(m.Predicates, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxAssigned", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
 			}
-			m.MaxAssigned = 0
+			m.Ts = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16188,16 +18140,16 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxAssigned |= uint64(b&0x7F) << shift
+				m.Ts |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupChecksums", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Abort", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16207,91 +18159,12 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.GroupChecksums == nil {
-				m.GroupChecksums = make(map[uint32]uint64)
-			}
-			var mapkey uint32
-			var mapvalue uint64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapkey |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipPb(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthPb
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.GroupChecksums[mapkey] = mapvalue
-			iNdEx = postIndex
+			m.Abort = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -16317,7 +18190,7 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
+func (m *IngestState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16340,15 +18213,68 @@ func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TxnTimestamps: wiretype end group for non-group")
+			return fmt.Errorf("proto: IngestState: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TxnTimestamps: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IngestState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
+			}
+			m.Ts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ts |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tablets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tablets == nil {
+				m.Tablets = make(map[string]uint32)
+			}
+			var mapkey string
+			var mapvalue uint32
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
 				for shift := uint(0); ; shift += 7 {
 					if shift >= 64 {
 						return ErrIntOverflowPb
@@ -16358,14 +18284,110 @@ func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					v |= uint64(b&0x7F) << shift
+					wire |= uint64(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
 				}
-				m.Ts = append(m.Ts, v)
-			} else if wireType == 2 {
-				var packedLen int
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Tablets[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leaders == nil {
+				m.Leaders = make(map[uint32]string)
+			}
+			var mapkey uint32
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
 				for shift := uint(0); ; shift += 7 {
 					if shift >= 64 {
 						return ErrIntOverflowPb
@@ -16375,34 +18397,29 @@ func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					packedLen |= int(b&0x7F) << shift
+					wire |= uint64(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
 					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Ts) == 0 {
-					m.Ts = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowPb
@@ -16412,16 +18429,41 @@ func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						v |= uint64(b&0x7F) << shift
+						stringLenmapvalue |= uint64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-					m.Ts = append(m.Ts, v)
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthPb
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
 			}
+			m.Leaders[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -16447,7 +18489,7 @@ func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PeerResponse) Unmarshal(dAtA []byte) error {
+func (m *TxnStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16470,17 +18512,17 @@ func (m *PeerResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PeerResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxnStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PeerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxnStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
 			}
-			var v int
+			m.StartTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16490,12 +18532,30 @@ func (m *PeerResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.StartTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+			}
+			m.CommitTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Status = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -16521,7 +18581,7 @@ func (m *PeerResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftBatch) Unmarshal(dAtA []byte) error {
+func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16544,15 +18604,15 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftBatch: wiretype end group for non-group")
+			return fmt.Errorf("proto: OracleDelta: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OracleDelta: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txns", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16579,16 +18639,33 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Context == nil {
-				m.Context = &RaftContext{}
-			}
-			if err := m.Context.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txns = append(m.Txns, &TxnStatus{})
+			if err := m.Txns[len(m.Txns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAssigned", wireType)
+			}
+			m.MaxAssigned = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAssigned |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupChecksums", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16615,12 +18692,75 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Payload == nil {
-				m.Payload = &api.Payload{}
+			if m.GroupChecksums == nil {
+				m.GroupChecksums = make(map[uint32]uint64)
 			}
-			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var mapkey uint32
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
 			}
+			m.GroupChecksums[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -16647,7 +18787,7 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Num) Unmarshal(dAtA []byte) error {
+func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16670,17 +18810,147 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Num: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxnTimestamps: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Num: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxnTimestamps: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Ts = append(m.Ts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Ts) == 0 {
+					m.Ts = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Ts = append(m.Ts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Val", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			m.Val = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16690,16 +18960,874 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Val |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			m.Status = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Context == nil {
+				m.Context = &RaftContext{}
+			}
+			if err := m.Context.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Payload == nil {
+				m.Payload = &api.Payload{}
+			}
+			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Num) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Num: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Num: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Val", wireType)
+			}
+			m.Val = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Val |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Forwarded", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Forwarded = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AssignedIds) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AssignedIds: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AssignedIds: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartId", wireType)
+			}
+			m.StartId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndId", wireType)
+			}
+			m.EndId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			m.ReadOnly = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadOnly |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientTs", wireType)
+			}
+			m.ClientTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClientTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			}
+			m.GroupId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Status) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BackupRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BackupRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BackupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+			}
+			m.ReadTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			}
+			m.GroupId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnixTs = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Location = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Since |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotTs", wireType)
+			}
+			m.SnapshotTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SessionToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Anonymous", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			var v int
+			m.Anonymous = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16709,17 +19837,29 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ReadOnly = bool(v != 0)
-		case 3:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Forwarded", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseReadTs", wireType)
 			}
-			var v int
+			m.BaseReadTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16729,12 +19869,11 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.BaseReadTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Forwarded = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -16760,7 +19899,7 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AssignedIds) Unmarshal(dAtA []byte) error {
+func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16783,17 +19922,17 @@ func (m *AssignedIds) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AssignedIds: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AssignedIds: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
-			m.StartId = 0
+			m.GroupId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16803,16 +19942,16 @@ func (m *AssignedIds) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartId |= uint64(b&0x7F) << shift
+				m.GroupId |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
 			}
-			m.EndId = 0
+			m.ReadTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16822,16 +19961,16 @@ func (m *AssignedIds) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndId |= uint64(b&0x7F) << shift
+				m.ReadTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
 			}
-			m.ReadOnly = 0
+			m.UnixTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16841,70 +19980,48 @@ func (m *AssignedIds) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReadOnly |= uint64(b&0x7F) << shift
+				m.UnixTs |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPb
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotMeta: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotMeta: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientTs", wireType)
+			m.Format = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
 			}
-			m.ClientTs = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16914,16 +20031,29 @@ func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ClientTs |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.GroupId = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Destination = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -16933,70 +20063,29 @@ func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Status) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Status: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			m.AccessKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
 			}
-			m.Code = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17006,14 +20095,27 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -17041,8 +20143,28 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Msg = string(dAtA[iNdEx:postIndex])
+			m.SessionToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Anonymous", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Anonymous = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -17068,7 +20190,7 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BackupRequest) Unmarshal(dAtA []byte) error {
+func (m *Request) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17091,17 +20213,17 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BackupRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BackupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
 			}
-			m.ReadTs = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17111,16 +20233,33 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReadTs |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Request == nil {
+				m.Request = &api.Request{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Explain", wireType)
 			}
-			m.GroupId = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17130,16 +20269,17 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Explain = bool(v != 0)
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17149,27 +20289,15 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.UnixTs = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Profile = bool(v != 0)
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -17197,49 +20325,11 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Location = string(dAtA[iNdEx:postIndex])
+			m.Timeout = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
-			}
-			m.Since = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Since |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotTs", wireType)
-			}
-			m.SnapshotTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SnapshotTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadAt", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -17267,13 +20357,67 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AccessKey = string(dAtA[iNdEx:postIndex])
+			m.ReadAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17283,29 +20427,33 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SecretKey = string(dAtA[iNdEx:postIndex])
+			if m.Response == nil {
+				m.Response = &api.Response{}
+			}
+			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 9:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SessionToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Plan", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17315,44 +20463,26 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SessionToken = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Anonymous", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Plan = append(m.Plan[:0], dAtA[iNdEx:postIndex]...)
+			if m.Plan == nil {
+				m.Plan = []byte{}
 			}
-			m.Anonymous = bool(v != 0)
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -17378,7 +20508,7 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExportRequest) Unmarshal(dAtA []byte) error {
+func (m *Mutation) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17401,17 +20531,17 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Mutation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Mutation: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mutation", wireType)
 			}
-			m.GroupId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17421,16 +20551,33 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Mutation == nil {
+				m.Mutation = &api.Mutation{}
+			}
+			if err := m.Mutation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cond", wireType)
 			}
-			m.ReadTs = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17440,16 +20587,29 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReadTs |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cond = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
-			m.UnixTs = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -17459,11 +20619,24 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.UnixTs |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -215,6 +215,45 @@ type CarModel {
 	previous_model: CarModel
 }
 
+type User {
+	name: string
+	_xid_: string
+	address: string
+	age: int
+	alive: bool
+	bin_data: string
+	dob: datetime
+	dob_day: datetime
+	follow: [uid]
+	friend: [uid]
+	full_name: string
+	gender: string
+	graduation: [datetime]
+	loc: geo
+	noindex_name: string
+	password: password
+	path: [uid]
+	power: float
+	school: [uid]
+	son: [uid]
+	survival_rate: float
+	sword_present: string
+}
+
+type Place {
+	name: string
+	abbr: string
+	school: [uid]
+	district: [uid]
+	county: [uid]
+	state: [uid]
+}
+
+type Node {
+	name: string
+	node: [uid]
+}
+
 name                           : string @index(term, exact, trigram) @count @lang .
 alias                          : string @index(exact, term, fulltext) .
 dob                            : dateTime @index(year) .
@@ -491,6 +530,7 @@ func populateCluster() {
 		<23> <shadow_deep> "4" .
 		<24> <shadow_deep> "14" .
 
+		<1> <dgraph.type> "User" .
 		<2> <dgraph.type> "Person" .
 		<3> <dgraph.type> "Person" .
 		<4> <dgraph.type> "Person" .
@@ -511,6 +551,13 @@ func populateCluster() {
 		<11000> <director.film> <11003> .
 
 		<11100> <node> <11100> .
+		<11100> <dgraph.type> "Node" .
+
+		<32> <dgraph.type> "Place" .
+		<33> <dgraph.type> "Place" .
+		<34> <dgraph.type> "Place" .
+		<35> <dgraph.type> "Place" .
+		<36> <dgraph.type> "Place" .
 
 		<200> <make> "Ford" .
 		<200> <model> "Focus" .
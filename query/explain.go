@@ -0,0 +1,295 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+// The number of nodes at each level that the fan-out of the next level is measured on.
+const explainSampleSize = 100
+
+// PlanNode describes how a node of the query SubGraph tree is processed.
+type PlanNode struct {
+	Attr  string `json:"attr,omitempty"`
+	Alias string `json:"alias,omitempty"`
+	// Func is the function run at this node, like eq(name, "Alice").
+	Func string `json:"func,omitempty"`
+	// FilterOp is and, or or not for the filters combining the filters below them.
+	FilterOp string `json:"filter_op,omitempty"`
+	// Index is true if Func looks up the index built by the tokenizers of the predicate.
+	Index      bool     `json:"index,omitempty"`
+	Tokenizers []string `json:"tokenizers,omitempty"`
+	// Group is the group serving the predicate.
+	Group uint32 `json:"group,omitempty"`
+
+	Estimate *PlanEstimate `json:"estimate,omitempty"`
+	Profile  *PlanProfile  `json:"profile,omitempty"`
+
+	Filters  []*PlanNode `json:"filters,omitempty"`
+	Children []*PlanNode `json:"children,omitempty"`
+}
+
+// PlanEstimate is the number of uids a node is expected to reach, set by Explain.
+type PlanEstimate struct {
+	// FanOut is the average number of uids reached from each uid of the parent node, measured on
+	// a sample of them. It's not set at the root.
+	FanOut float64 `json:"fan_out,omitempty"`
+	// Uids is the estimated number of uids reached, before filters and pagination.
+	Uids uint64 `json:"uids"`
+}
+
+// PlanProfile is how a node was processed, set by Profile.
+type PlanProfile struct {
+	// LatencyNs is the time spent processing the node, including its filters and children.
+	LatencyNs uint64 `json:"latency_ns"`
+	// Uids is the number of uids the node reached, after filters and pagination.
+	Uids int `json:"uids"`
+}
+
+type planner struct {
+	ctx    context.Context
+	schema map[string]*api.SchemaNode
+}
+
+func newPlanner(ctx context.Context, sgs []*SubGraph) (*planner, error) {
+	var preds []string
+	for _, pred := range GetAllPredicates(sgs) {
+		preds = append(preds, strings.TrimPrefix(pred, "~"))
+	}
+	p := &planner{ctx: ctx, schema: make(map[string]*api.SchemaNode)}
+	if len(preds) == 0 {
+		return p, nil
+	}
+	nodes, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{Predicates: preds})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		p.schema[node.Predicate] = node
+	}
+	return p, nil
+}
+
+// Explain returns the plan of the query, without running it. The estimates are computed from
+// the length of the posting lists: the root functions that look up an index are run to find the
+// uids the query starts from, and the number of uids reached at every level is measured on a
+// sample of the uids at the level above.
+func (req *QueryRequest) Explain(ctx context.Context) ([]*PlanNode, error) {
+	if err := req.buildSubGraphs(ctx); err != nil {
+		return nil, err
+	}
+	p, err := newPlanner(ctx, req.Subgraphs)
+	if err != nil {
+		return nil, err
+	}
+	var plan []*PlanNode
+	for _, sg := range req.Subgraphs {
+		node, err := p.node(sg)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.estimateRoot(sg, node); err != nil {
+			return nil, err
+		}
+		plan = append(plan, node)
+	}
+	return plan, nil
+}
+
+// Profile returns the plan of the query run by req.Process, with the time spent and the number of
+// uids reached at every node.
+func (req *QueryRequest) Profile(ctx context.Context) ([]*PlanNode, error) {
+	p, err := newPlanner(ctx, req.Subgraphs)
+	if err != nil {
+		return nil, err
+	}
+	var plan []*PlanNode
+	for _, sg := range req.Subgraphs {
+		node, err := p.node(sg)
+		if err != nil {
+			return nil, err
+		}
+		profile(sg, node)
+		plan = append(plan, node)
+	}
+	return plan, nil
+}
+
+// node returns the plan of sg, without estimates or profile.
+func (p *planner) node(sg *SubGraph) (*PlanNode, error) {
+	node := &PlanNode{Attr: sg.Attr, Alias: sg.Params.Alias, FilterOp: sg.FilterOp}
+	attr := strings.TrimPrefix(sg.Attr, "~")
+	if fn := sg.SrcFunc; fn != nil {
+		node.Func = funcString(sg)
+		srcFunc := &pb.SrcFunction{Name: fn.Name, IsCount: fn.IsCount}
+		if fn.Name != "uid" && !fn.IsValueVar && worker.FuncUsesIndex(srcFunc) {
+			node.Index = true
+			if sch, ok := p.schema[attr]; ok {
+				node.Tokenizers = sch.Tokenizer
+			}
+		}
+	}
+	if attr != "" && attr != "uid" && !sg.IsInternal() {
+		gid, err := worker.PredicateGroup(attr)
+		if err != nil {
+			return nil, err
+		}
+		node.Group = gid
+	}
+
+	for _, filter := range sg.Filters {
+		child, err := p.node(filter)
+		if err != nil {
+			return nil, err
+		}
+		node.Filters = append(node.Filters, child)
+	}
+	for _, sgChild := range sg.Children {
+		child, err := p.node(sgChild)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return node, nil
+}
+
+// funcString returns the function of sg as it was written in the query.
+func funcString(sg *SubGraph) string {
+	fn := sg.SrcFunc
+	if fn.Name == "uid" {
+		return "uid"
+	}
+	var args []string
+	switch {
+	case sg.Attr == "":
+	case fn.IsCount:
+		args = append(args, fmt.Sprintf("count(%s)", sg.Attr))
+	default:
+		args = append(args, sg.Attr)
+	}
+	for _, arg := range fn.Args {
+		if arg.IsValueVar {
+			args = append(args, fmt.Sprintf("val(%s)", arg.Value))
+		} else {
+			args = append(args, fmt.Sprintf("%q", arg.Value))
+		}
+	}
+	return fmt.Sprintf("%s(%s)", fn.Name, strings.Join(args, ", "))
+}
+
+// estimateRoot estimates the uids the query block sg starts from, and then the uids reached by
+// its children.
+func (p *planner) estimateRoot(sg *SubGraph, node *PlanNode) error {
+	var uids []uint64
+	switch {
+	case sg.SrcUIDs != nil && len(sg.SrcUIDs.Uids) > 0:
+		uids = sg.SrcUIDs.Uids
+	case node.Index && node.Attr != "":
+		q, err := createTaskQuery(sg)
+		if err != nil {
+			return err
+		}
+		res, err := worker.ProcessTaskOverNetwork(p.ctx, q)
+		if err != nil {
+			return err
+		}
+		uids = algo.MergeSorted(res.UidMatrix).Uids
+	default:
+		// The uids depend on variables or on functions reading the whole predicate.
+		return nil
+	}
+	node.Estimate = &PlanEstimate{Uids: uint64(len(uids))}
+	return p.estimateChildren(sg, node, sampleUids(uids), float64(len(uids)))
+}
+
+// estimateChildren estimates the uids reached by the children of sg from numSrc uids, by
+// measuring the fan-out of every uid predicate on the sample of those uids.
+func (p *planner) estimateChildren(sg *SubGraph, node *PlanNode, sample []uint64,
+	numSrc float64) error {
+
+	if len(sample) == 0 {
+		return nil
+	}
+	for i, child := range sg.Children {
+		attr := strings.TrimPrefix(child.Attr, "~")
+		if child.IsInternal() || attr == "" || attr == "uid" || child.Params.Expand != "" {
+			continue
+		}
+		if sch, ok := p.schema[attr]; !ok || sch.Type != "uid" {
+			// Only uid predicates lead to other nodes.
+			continue
+		}
+		res, err := worker.ProcessTaskOverNetwork(p.ctx, &pb.Query{
+			Attr:    attr,
+			Reverse: strings.HasPrefix(child.Attr, "~"),
+			ReadTs:  child.ReadTs,
+			UidList: &pb.List{Uids: sample},
+		})
+		if err != nil {
+			return err
+		}
+		var total int
+		for _, list := range res.UidMatrix {
+			total += len(list.Uids)
+		}
+		fanOut := float64(total) / float64(len(sample))
+		est := &PlanEstimate{FanOut: fanOut, Uids: uint64(fanOut*numSrc + 0.5)}
+		node.Children[i].Estimate = est
+
+		next := sampleUids(algo.MergeSorted(res.UidMatrix).Uids)
+		if err := p.estimateChildren(child, node.Children[i], next, float64(est.Uids)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sampleUids returns at most explainSampleSize uids, evenly spread over the sorted uids.
+func sampleUids(uids []uint64) []uint64 {
+	if len(uids) <= explainSampleSize {
+		return uids
+	}
+	sample := make([]uint64, 0, explainSampleSize)
+	for i := 0; i < explainSampleSize; i++ {
+		sample = append(sample, uids[i*len(uids)/explainSampleSize])
+	}
+	return sample
+}
+
+// profile sets the latency and the number of uids reached at every node of the processed sg.
+func profile(sg *SubGraph, node *PlanNode) {
+	node.Profile = &PlanProfile{LatencyNs: uint64(sg.latency.Nanoseconds())}
+	if sg.DestUIDs != nil {
+		node.Profile.Uids = len(sg.DestUIDs.Uids)
+	}
+	for i, filter := range sg.Filters {
+		profile(filter, node.Filters[i])
+	}
+	for i, child := range sg.Children {
+		profile(child, node.Children[i])
+	}
+}
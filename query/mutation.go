@@ -19,6 +19,9 @@ package query
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +30,7 @@ import (
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/types/facets"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
@@ -48,7 +52,16 @@ func ApplyMutations(ctx context.Context, m *pb.Mutations) (*api.TxnContext, erro
 			}
 		}
 	}
+	keys, err := worker.CheckStrictTypes(ctx, m)
+	if err != nil {
+		return nil, err
+	}
 	tctx, err := worker.MutateOverNetwork(ctx, m)
+	for _, key := range keys {
+		if !x.HasString(tctx.Keys, key) {
+			tctx.Keys = append(tctx.Keys, key)
+		}
+	}
 	if err != nil {
 		if span := otrace.FromContext(ctx); span != nil {
 			span.Annotatef(nil, "MutateOverNetwork Error: %v. Mutation: %v.", err, m)
@@ -232,3 +245,189 @@ func ToInternal(gmu *gql.Mutation,
 
 	return edges, nil
 }
+
+// varRef returns the name of the variable referred to by s through keyword(name), e.g.
+// uid(v) or val(v), along with whether s is such a reference at all.
+func varRef(s, keyword string) (string, bool) {
+	if !strings.HasPrefix(s, keyword+"(") || !strings.HasSuffix(s, ")") {
+		return "", false
+	}
+	return s[len(keyword)+1 : len(s)-1], true
+}
+
+// UpsertVars returns the names of the query variables that the N-Quads of the mutation refer
+// to through uid(v) or val(v).
+func UpsertVars(gmu *gql.Mutation) []string {
+	var vars []string
+	collect := func(nqs []*api.NQuad) {
+		for _, nq := range nqs {
+			if v, ok := varRef(nq.Subject, "uid"); ok {
+				vars = append(vars, v)
+			}
+			if v, ok := varRef(nq.ObjectId, "uid"); ok {
+				vars = append(vars, v)
+			}
+			if v, ok := varRef(nq.ObjectValue.GetDefaultVal(), "val"); ok {
+				vars = append(vars, v)
+			}
+		}
+	}
+	collect(gmu.Set)
+	collect(gmu.Del)
+	return x.RemoveDuplicates(vars)
+}
+
+// UpdateMutations replaces the variable references in the N-Quads of the mutation with the
+// results of the query of an upsert block. vars must hold the variables filled in by
+// processing that query.
+//
+// uid(v) is replaced by every uid in v, generating one N-Quad per uid. If v holds no uids, a
+// set N-Quad gets the blank node _:uid(v) instead so that a new node is created, while a
+// delete N-Quad is dropped. val(v) is replaced by the value of v for the subject of the
+// N-Quad; N-Quads whose subject has no value in v are dropped.
+func UpdateMutations(gmu *gql.Mutation, vars map[string]varValue) error {
+	var err error
+	if gmu.Set, err = updateNQuads(gmu.Set, vars, true); err != nil {
+		return err
+	}
+	gmu.Del, err = updateNQuads(gmu.Del, vars, false)
+	return err
+}
+
+func updateNQuads(nqs []*api.NQuad, vars map[string]varValue,
+	isSet bool) ([]*api.NQuad, error) {
+
+	uidsFor := func(s string) []string {
+		name, ok := varRef(s, "uid")
+		if !ok {
+			return []string{s}
+		}
+		v := vars[name]
+		if v.Uids == nil || len(v.Uids.Uids) == 0 {
+			if isSet {
+				return []string{"_:" + s}
+			}
+			return nil
+		}
+		uids := make([]string, 0, len(v.Uids.Uids))
+		for _, uid := range v.Uids.Uids {
+			uids = append(uids, fmt.Sprintf("%#x", uid))
+		}
+		return uids
+	}
+
+	var res []*api.NQuad
+	for _, nq := range nqs {
+		valVar, isValVar := varRef(nq.ObjectValue.GetDefaultVal(), "val")
+		objects := []string{nq.ObjectId}
+		if len(nq.ObjectId) > 0 {
+			objects = uidsFor(nq.ObjectId)
+		}
+
+		for _, subject := range uidsFor(nq.Subject) {
+			var objectValue *api.Value
+			if isValVar {
+				val, ok := valueFor(vars[valVar], subject)
+				if !ok {
+					continue
+				}
+				var err error
+				if objectValue, err = types.ObjectValue(val.Tid, val.Value); err != nil {
+					return nil, x.Wrapf(err, "while substituting val(%s)", valVar)
+				}
+			}
+
+			for _, object := range objects {
+				n := &api.NQuad{
+					Subject:     subject,
+					Predicate:   nq.Predicate,
+					ObjectId:    object,
+					ObjectValue: nq.ObjectValue,
+					Label:       nq.Label,
+					Lang:        nq.Lang,
+					Facets:      nq.Facets,
+				}
+				if isValVar {
+					n.ObjectValue = objectValue
+				}
+				res = append(res, n)
+			}
+		}
+	}
+	return res, nil
+}
+
+// valueFor returns the value held by a value variable for the given subject. Aggregated
+// values are not tied to a uid and apply to every subject.
+func valueFor(v varValue, subject string) (types.Val, bool) {
+	if val, ok := v.Vals[math.MaxUint64]; ok && len(v.Vals) == 1 {
+		return val, true
+	}
+	uid, err := gql.ParseUid(subject)
+	if err != nil {
+		return types.Val{}, false
+	}
+	val, ok := v.Vals[uid]
+	return val, ok
+}
+
+// EvalUpsertCond evaluates the condition of an upsert block, as parsed by
+// gql.ParseUpsertCond, against the variables filled in by processing the query of the block.
+// len(v) is the number of uids held by v, or the number of values for a value variable.
+func EvalUpsertCond(ft *gql.FilterTree, vars map[string]varValue) (bool, error) {
+	if ft.Func != nil {
+		v := vars[ft.Func.Attr]
+		n := int64(len(v.Vals))
+		if v.Uids != nil {
+			n = int64(len(v.Uids.Uids))
+		}
+		if len(ft.Func.Args) != 1 {
+			return false, x.Errorf("Invalid arguments to %s(len(%s))", ft.Func.Name, ft.Func.Attr)
+		}
+		want, err := strconv.ParseInt(ft.Func.Args[0].Value, 0, 64)
+		if err != nil {
+			return false, x.Wrapf(err, "while evaluating %s(len(%s))", ft.Func.Name, ft.Func.Attr)
+		}
+		switch ft.Func.Name {
+		case "eq":
+			return n == want, nil
+		case "le":
+			return n <= want, nil
+		case "lt":
+			return n < want, nil
+		case "ge":
+			return n >= want, nil
+		case "gt":
+			return n > want, nil
+		}
+		return false, x.Errorf("Invalid function %s in upsert condition", ft.Func.Name)
+	}
+
+	res := make([]bool, 0, len(ft.Child))
+	for _, ch := range ft.Child {
+		ok, err := EvalUpsertCond(ch, vars)
+		if err != nil {
+			return false, err
+		}
+		res = append(res, ok)
+	}
+	switch {
+	case ft.Op == "not" && len(res) == 1:
+		return !res[0], nil
+	case ft.Op == "and":
+		for _, ok := range res {
+			if !ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	case ft.Op == "or":
+		for _, ok := range res {
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, x.Errorf("Invalid operator %s in upsert condition", ft.Op)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"strings"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// AddNodeFilter restricts the nodes reached by the blocks to those matching the filter, at every
+// level: the filter is added to the root of the blocks and to the uid predicates of their children,
+// along with the filters already there. The predicates expanded by expand() are filtered too.
+func AddNodeFilter(gqs []*gql.GraphQuery, filter *gql.FilterTree) {
+	for _, gq := range gqs {
+		if gq == nil {
+			continue
+		}
+		// The blocks without a starting function only aggregate the variables of other blocks.
+		if !gq.IsEmpty {
+			gq.Filter = andFilter(gq.Filter, filter)
+		}
+		addChildrenNodeFilter(gq, filter)
+	}
+}
+
+func addChildrenNodeFilter(gq *gql.GraphQuery, filter *gql.FilterTree) {
+	for _, child := range gq.Children {
+		if isNodeChild(child) {
+			child.Filter = andFilter(child.Filter, filter)
+		}
+		addChildrenNodeFilter(child, filter)
+	}
+}
+
+// isNodeChild returns whether the child reaches other nodes, i.e. is an uid or reverse predicate,
+// its count, or expand().
+func isNodeChild(child *gql.GraphQuery) bool {
+	switch {
+	case child.Expand != "":
+		return true
+	case child.Func != nil || child.MathExp != nil || child.Attr == "uid":
+		return false
+	}
+	return isNodePredicate(child.Attr)
+}
+
+// isNodePredicate returns whether the predicate may reach other nodes. The schema of the
+// predicates served by other groups may be unknown, in which case the filter is added anyway: it
+// doesn't change the values of scalar predicates.
+func isNodePredicate(attr string) bool {
+	if strings.HasPrefix(attr, "~") {
+		return true
+	}
+	typ, err := schema.State().TypeOf(attr)
+	return err != nil || typ == types.UidID
+}
+
+func andFilter(ft, filter *gql.FilterTree) *gql.FilterTree {
+	if ft == nil {
+		return filter
+	}
+	return &gql.FilterTree{Op: "and", Child: []*gql.FilterTree{ft, filter}}
+}
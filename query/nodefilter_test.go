@@ -0,0 +1,66 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/schema"
+)
+
+func TestAddNodeFilter(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("name: string .\nfriend: [uid] ."), 1))
+	res, err := gql.Parse(gql.Request{Str: `{
+		q(func: has(name)) @filter(has(age)) {
+			uid
+			name
+			~friend @filter(has(age)) {
+				name
+			}
+			count(friend)
+			expand(_all_)
+		}
+		var(func: has(name)) {
+			a as age
+		}
+		sum() {
+			total: sum(val(a))
+		}
+	}`})
+	require.NoError(t, err)
+	filter := &gql.FilterTree{Func: &gql.Function{Name: "eq", Attr: "tenant",
+		Args: []gql.Arg{{Value: "acme"}}}}
+	AddNodeFilter(res.Query, filter)
+
+	q := res.Query[0]
+	require.Equal(t, "and", q.Filter.Op)
+	require.Equal(t, filter, q.Filter.Child[1])
+	require.Nil(t, q.Children[0].Filter, "uid is not filtered")
+	require.Nil(t, q.Children[1].Filter, "scalar predicates are not filtered")
+	require.Equal(t, "and", q.Children[2].Filter.Op)
+	require.Equal(t, filter, q.Children[2].Filter.Child[1])
+	require.Nil(t, q.Children[2].Children[0].Filter)
+	require.Equal(t, filter, q.Children[3].Filter, "counts of uid predicates are filtered")
+	require.Equal(t, filter, q.Children[4].Filter, "expand() is filtered")
+
+	require.Equal(t, filter, res.Query[1].Filter)
+	require.Nil(t, res.Query[2].Filter, "blocks without function are not filtered")
+	require.Nil(t, res.Query[2].Children[0].Filter, "aggregations are not filtered")
+}
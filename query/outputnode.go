@@ -493,6 +493,7 @@ func processNodeUids(fj *fastJsonNode, sg *SubGraph) error {
 type Extensions struct {
 	Latency *api.Latency    `json:"server_latency,omitempty"`
 	Txn     *api.TxnContext `json:"txn,omitempty"`
+	Plan    json.RawMessage `json:"plan,omitempty"`
 }
 
 func (sg *SubGraph) toFastJSON(l *Latency) ([]byte, error) {
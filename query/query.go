@@ -43,7 +43,7 @@ import (
 )
 
 const (
-	FacetDelimeter = "|"
+	FacetDelimeter = x.FacetDelimeter
 )
 
 /*
@@ -199,6 +199,8 @@ type SubGraph struct {
 	List     bool // whether predicate is of list type
 
 	pathMeta *pathMetadata
+	// latency is the time spent processing this node, including its filters and children.
+	latency time.Duration
 }
 
 func (sg *SubGraph) recurse(set func(sg *SubGraph)) {
@@ -634,6 +636,12 @@ func filterCopy(sg *SubGraph, ft *gql.FilterTree) error {
 		if !isValidFuncName(ft.Func.Name) {
 			return x.Errorf("Invalid function name: %s", ft.Func.Name)
 		}
+		if ft.Func.IsLenVar {
+			return x.Errorf("len() is only allowed in the condition of an upsert block")
+		}
+		if ft.Func.Name == "between" && ft.Func.IsValueVar {
+			return x.Errorf("between is not supported with val()")
+		}
 
 		if isUidFnWithoutVar(ft.Func) {
 			sg.SrcFunc = &Function{Name: ft.Func.Name}
@@ -695,6 +703,31 @@ func uniqueKey(gchild *gql.GraphQuery) string {
 	return key
 }
 
+// checkTypeFields checks that every predicate asked for inside a block with the @type
+// directive is declared as a field of that type. Reverse predicates, expand(), uid and
+// internal nodes like val() and math() are not checked.
+func checkTypeFields(gq *gql.GraphQuery) error {
+	typ, ok := schema.State().GetType(gq.Type)
+	if !ok {
+		return x.Errorf("Type %s used in @type directive is not defined", gq.Type)
+	}
+	fields := make(map[string]struct{}, len(typ.Fields))
+	for _, field := range typ.Fields {
+		fields[field.Predicate] = struct{}{}
+	}
+
+	for _, child := range gq.Children {
+		if child.IsInternal || child.Expand != "" || child.Attr == "uid" ||
+			child.Attr == "dgraph.type" || strings.HasPrefix(child.Attr, "~") {
+			continue
+		}
+		if _, ok := fields[child.Attr]; !ok {
+			return x.Errorf("Predicate %s is not a field of type %s", child.Attr, gq.Type)
+		}
+	}
+	return nil
+}
+
 func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 	// Typically you act on the current node, and leave recursion to deal with
 	// children. But, in this case, we don't want to muck with the current
@@ -702,6 +735,12 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 	// So, we work on the children, and then recurse for grand children.
 	attrsSeen := make(map[string]struct{})
 
+	if len(gq.Type) > 0 {
+		if err := checkTypeFields(gq); err != nil {
+			return err
+		}
+	}
+
 	for _, gchild := range gq.Children {
 		if sg.Params.Alias == "shortest" && gchild.Expand != "" {
 			return x.Errorf("expand() not allowed inside shortest")
@@ -989,6 +1028,12 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 		if !isValidFuncName(gq.Func.Name) {
 			return nil, x.Errorf("Invalid function name: %s", gq.Func.Name)
 		}
+		if gq.Func.IsLenVar {
+			return nil, x.Errorf("len() is only allowed in the condition of an upsert block")
+		}
+		if gq.Func.Name == "between" && gq.Func.IsValueVar {
+			return nil, x.Errorf("between is not supported with val()")
+		}
 
 		sg.createSrcFunction(gq.Func)
 	}
@@ -1406,7 +1451,9 @@ func (sg *SubGraph) updateFacetMatrix() {
 func (sg *SubGraph) updateUidMatrix() {
 	sg.updateFacetMatrix()
 	for _, l := range sg.uidMatrix {
-		if len(sg.Params.Order) > 0 || len(sg.Params.FacetOrder) > 0 {
+		// The root using a variable keeps its order, like the order of a shortest path.
+		sorted := sort.SliceIsSorted(l.Uids, func(i, j int) bool { return l.Uids[i] < l.Uids[j] })
+		if len(sg.Params.Order) > 0 || len(sg.Params.FacetOrder) > 0 || !sorted {
 			// We can't do intersection directly as the list is not sorted by UIDs.
 			// So do filter.
 			algo.ApplyFilter(l, func(uid uint64, idx int) bool {
@@ -1487,7 +1534,7 @@ AssignStep:
 
 // Updates the doneVars map by picking up uid/values from the current Subgraph
 func (sg *SubGraph) updateVars(doneVars map[string]varValue, sgPath []*SubGraph) error {
-	// NOTE: although we initialize doneVars (req.vars) in ProcessQuery, this nil check is for
+	// NOTE: although we initialize doneVars (req.Vars) in ProcessQuery, this nil check is for
 	// non-root lookups that happen to other nodes. Don't use len(doneVars) == 0 !
 	if doneVars == nil || (sg.Params.Var == "" && sg.Params.FacetVar == nil) {
 		return nil
@@ -1878,74 +1925,27 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 			continue
 		}
 
-		if !x.WorkerConfig.ExpandEdge {
-			return out,
-				x.Errorf("Cannot run expand() query when ExpandEdge(--expand_edge) is false.")
-		}
-
 		var preds []string
-		types, err := getNodeTypes(ctx, sg)
-		if err != nil {
-			return out, err
-		}
-
 		switch child.Params.Expand {
 		// It could be expand(_all_), expand(_forward_), expand(_reverse_) or expand(val(x)).
-		case "_all_":
-			span.Annotate(nil, "expand(_all_)")
-			if len(types) > 0 {
-				preds = getPredicatesFromTypes(types)
-
-				rpreds, err := getReversePredicatesFromType(ctx, preds)
-				if err != nil {
-					return out, err
-				}
-				preds = append(preds, rpreds...)
-				break
-			}
-
-			// Get the predicate list for expansion.
-			child.ExpandPreds, err = getNodePredicates(ctx, sg)
+		// The first three expand the fields declared on the types of the nodes.
+		case "_all_", "_forward_", "_reverse_":
+			span.Annotate(nil, "expand("+child.Params.Expand+")")
+			nodeTypes, err := getNodeTypes(ctx, sg)
 			if err != nil {
 				return out, err
 			}
-			preds = uniqueValues(child.ExpandPreds)
-
-			rpreds, err := getReversePredicates(ctx)
-			if err != nil {
-				return out, err
-			}
-			preds = append(preds, rpreds...)
-		case "_forward_":
-			span.Annotate(nil, "expand(_forward_)")
-			if len(types) > 0 {
-				preds = getPredicatesFromTypes(types)
-				break
+			typePreds := getPredicatesFromTypes(nodeTypes)
+			if child.Params.Expand != "_reverse_" {
+				preds = typePreds
 			}
-
-			child.ExpandPreds, err = getNodePredicates(ctx, sg)
-			if err != nil {
-				return out, err
-			}
-			preds = uniqueValues(child.ExpandPreds)
-		case "_reverse_":
-			span.Annotate(nil, "expand(_reverse_)")
-			if len(types) > 0 {
-				typePreds := getPredicatesFromTypes(types)
-
+			if child.Params.Expand != "_forward_" {
 				rpreds, err := getReversePredicatesFromType(ctx, typePreds)
 				if err != nil {
 					return out, err
 				}
 				preds = append(preds, rpreds...)
-				break
 			}
-
-			rpreds, err := getReversePredicates(ctx)
-			if err != nil {
-				return out, err
-			}
-			preds = rpreds
 		default:
 			span.Annotate(nil, "expand default")
 			// We already have the predicates populated from the var.
@@ -1974,6 +1974,16 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 				temp.Children = append(temp.Children, s)
 			}
 
+			// The filters of expand() can only be node filters of the ACLs, which restrict the
+			// nodes reached by the expanded predicates.
+			if isNodePredicate(pred) {
+				for _, f := range child.Filters {
+					fc := &SubGraph{}
+					fc.copyFiltersRecurse(f)
+					temp.Filters = append(temp.Filters, fc)
+				}
+			}
+
 			for _, ch := range sg.Children {
 				if ch.isSimilar(temp) {
 					return out, x.Errorf("Repeated subgraph: [%s] while using expand()", ch.Attr)
@@ -1988,6 +1998,15 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 // ProcessGraph processes the SubGraph instance accumulating result for the query
 // from different instances. Note: taskQuery is nil for root node.
 func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
+	// Record the latency before sending the result, after which sg is read by the parent.
+	start := time.Now()
+	ch := make(chan error, 1)
+	processGraph(ctx, sg, parent, ch)
+	sg.latency += time.Since(start)
+	rch <- <-ch
+}
+
+func processGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 	var suffix string
 	if len(sg.Params.Alias) > 0 {
 		suffix += "." + sg.Params.Alias
@@ -1999,6 +2018,11 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 	stop := x.SpanTimer(span, "query.ProcessGraph"+suffix)
 	defer stop()
 
+	if ctx.Err() != nil {
+		// The query timed out or was cancelled.
+		rch <- ctx.Err()
+		return
+	}
 	if sg.Attr == "uid" {
 		// We dont need to call ProcessGraph for uid, as we already have uids
 		// populated from parent and there is nothing to process but uidMatrix
@@ -2223,6 +2247,11 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 	}
 
+	// Don't go down to the next level if the query timed out or was cancelled.
+	if ctx.Err() != nil {
+		rch <- ctx.Err()
+		return
+	}
 	childChan := make(chan error, len(sg.Children))
 	for i := 0; i < len(sg.Children); i++ {
 		child := sg.Children[i]
@@ -2488,7 +2517,7 @@ func isValidArg(a string) bool {
 func isValidFuncName(f string) bool {
 	switch f {
 	case "anyofterms", "allofterms", "val", "regexp", "anyoftext", "alloftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "anyof", "allof", "type", "match", "between":
 		return true
 	}
 	return isInequalityFn(f) || types.IsGeoFunc(f)
@@ -2531,21 +2560,6 @@ func getNodePredicates(ctx context.Context, sg *SubGraph) ([]*pb.ValueList, erro
 	return result.ValueMatrix, nil
 }
 
-func getReversePredicates(ctx context.Context) ([]string, error) {
-	schs, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{})
-	if err != nil {
-		return nil, err
-	}
-	preds := make([]string, 0, len(schs))
-	for _, sch := range schs {
-		if !sch.Reverse {
-			continue
-		}
-		preds = append(preds, "~"+sch.Predicate)
-	}
-	return preds, nil
-}
-
 func getNodeTypes(ctx context.Context, sg *SubGraph) ([]string, error) {
 	temp := &SubGraph{
 		Attr:    "dgraph.type",
@@ -2577,7 +2591,8 @@ func getPredicatesFromTypes(types []string) []string {
 			preds = append(preds, field.Predicate)
 		}
 	}
-	return preds
+	// Types may share fields.
+	return x.RemoveDuplicates(preds)
 }
 
 // getReversePredicatesFromType queries the schema and returns a list of the
@@ -2656,19 +2671,12 @@ type QueryRequest struct {
 
 	Subgraphs []*SubGraph
 
-	vars map[string]varValue
+	Vars map[string]varValue
 }
 
-// ProcessQuery processes query part of the request (without mutations).
-// Fills Subgraphs and Vars.
-// It optionally also returns a map of the allocated uids in case of an upsert request.
-func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
+// buildSubGraphs converts the query blocks of the request to req.Subgraphs.
+func (req *QueryRequest) buildSubGraphs(ctx context.Context) error {
 	span := otrace.FromContext(ctx)
-	stop := x.SpanTimer(span, "query.ProcessQuery")
-	defer stop()
-
-	// doneVars stores the processed variables.
-	req.vars = make(map[string]varValue)
 	loopStart := time.Now()
 	queries := req.GqlQuery.Query
 	for i := 0; i < len(queries); i++ {
@@ -2691,6 +2699,22 @@ func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
 		req.Subgraphs = append(req.Subgraphs, sg)
 	}
 	req.Latency.Parsing += time.Since(loopStart)
+	return nil
+}
+
+// ProcessQuery processes query part of the request (without mutations).
+// Fills Subgraphs and Vars.
+// It optionally also returns a map of the allocated uids in case of an upsert request.
+func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
+	span := otrace.FromContext(ctx)
+	stop := x.SpanTimer(span, "query.ProcessQuery")
+	defer stop()
+
+	// doneVars stores the processed variables.
+	req.Vars = make(map[string]varValue)
+	if err := req.buildSubGraphs(ctx); err != nil {
+		return err
+	}
 
 	execStart := time.Now()
 	hasExecuted := make([]bool, len(req.Subgraphs))
@@ -2710,7 +2734,7 @@ func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
 			}
 			// The variable should be defined in this block or should have already been
 			// populated by some other block, otherwise we are not ready to execute yet.
-			_, ok := req.vars[v]
+			_, ok := req.Vars[v]
 			if !ok && !selfDep {
 				return false
 			}
@@ -2734,7 +2758,7 @@ func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
 				continue
 			}
 
-			err = sg.recursiveFillVars(req.vars)
+			err = sg.recursiveFillVars(req.Vars)
 			if err != nil {
 				return err
 			}
@@ -2779,10 +2803,10 @@ func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
 			sg := req.Subgraphs[idx]
 
 			var sgPath []*SubGraph
-			if err := sg.populateVarMap(req.vars, sgPath); err != nil {
+			if err := sg.populateVarMap(req.Vars, sgPath); err != nil {
 				return err
 			}
-			if err := sg.populatePostAggregation(req.vars, []*SubGraph{}, nil); err != nil {
+			if err := sg.populatePostAggregation(req.Vars, []*SubGraph{}, nil); err != nil {
 				return err
 			}
 		}
@@ -517,6 +517,25 @@ func TestShortestPathRev(t *testing.T) {
 		js)
 }
 
+func TestShortestPathRevFilter(t *testing.T) {
+
+	// The filter keeps the order of the path.
+	query := `
+		{
+			A as shortest(from:23, to:1) {
+				friend
+			}
+
+			me(func: uid( A)) @filter(has(name)) {
+				name
+			}
+		}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"_path_":[{"uid":"0x17","_weight_":1, "friend":{"uid":"0x1"}}],"me":[{"name":"Rick Grimes"},{"name":"Michonne"}]}}`,
+		js)
+}
+
 func TestFacetVarRetrieval(t *testing.T) {
 
 	query := `
@@ -2000,6 +2019,50 @@ func TestMultipleTypeDirectivesInPredicate(t *testing.T) {
 	require.JSONEq(t, `{"data": {"me":[{"enemy":[{"name":"Margaret", "pet":[{"name":"Bear"}]}, {"name":"Leonard"}]}]}}`, js)
 }
 
+func TestTypeDirectiveUndeclaredPredicate(t *testing.T) {
+	query := `
+		{
+			me(func: uid(0x2)) {
+				enemy @type(Person) {
+					name
+					alive
+				}
+			}
+		}
+	`
+	_, err := processQuery(t, context.Background(), query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate alive is not a field of type Person")
+}
+
+func TestTypeDirectiveUnknownType(t *testing.T) {
+	query := `
+		{
+			me(func: uid(0x2)) {
+				enemy @type(UnknownType) {
+					name
+				}
+			}
+		}
+	`
+	_, err := processQuery(t, context.Background(), query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Type UnknownType used in @type directive is not defined")
+}
+
+func TestExpandAllUntypedNode(t *testing.T) {
+	// Node 0x17 has no type, so there is nothing to expand.
+	query := `
+		{
+			me(func: uid(0x17)) {
+				expand(_all_)
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[]}}`, js)
+}
+
 func TestMaxPredicateSize(t *testing.T) {
 	// Create a string that has more than than 2^16 chars.
 	var b strings.Builder
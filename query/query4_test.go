@@ -328,3 +328,61 @@ func TestTypeExpandReverse(t *testing.T) {
 		{"~previous_model": [{"uid":"0xc9"}]}
 	]}}`, js)
 }
+
+func TestBetweenAtRoot(t *testing.T) {
+	query := `{
+		q(func: between(age, 17, 25)) {
+			uid
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q":[
+		{"uid":"0x19"}, {"uid":"0x1f"}, {"uid":"0x2710"},
+		{"uid":"0x2715"}, {"uid":"0x2716"}, {"uid":"0x2717"}
+	]}}`, js)
+}
+
+func TestBetweenLossyIndex(t *testing.T) {
+	query := `{
+		q(func: between(dob, "1909-01-01", "1910-01-01")) {
+			name
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q":[
+		{"name":"Michonne"}, {"name":"Glenn Rhee"}, {"name":"Daryl Dixon"}
+	]}}`, js)
+}
+
+func TestBetweenFilterBoundaryBuckets(t *testing.T) {
+	query := `{
+		q(func: uid(1)) {
+			friend @filter(between(dob, "1909-03-01", "1910-01-01")) {
+				name
+			}
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q":[{"friend":[{"name":"Glenn Rhee"}]}]}}`, js)
+}
+
+func TestBetweenEmptyRange(t *testing.T) {
+	query := `{
+		q(func: between(age, 25, 17)) {
+			uid
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q":[]}}`, js)
+}
+
+func TestBetweenWrongArgs(t *testing.T) {
+	query := `{
+		q(func: between(age, 17)) {
+			uid
+		}
+	}`
+	_, err := processQuery(t, context.Background(), query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Function 'between' requires 2 arguments")
+}
@@ -179,6 +179,10 @@ func (sg *SubGraph) expandOut(ctx context.Context,
 				if subgraph.UnknownAttr {
 					continue
 				}
+				// The filters only apply to the destination uids so far.
+				if len(subgraph.Filters) > 0 {
+					subgraph.updateUidMatrix()
+				}
 
 				// Send the destuids in res chan.
 				for mIdx, fromUID := range subgraph.SrcUIDs.Uids {
@@ -261,6 +265,34 @@ func (sg *SubGraph) copyFiltersRecurse(otherSubgraph *SubGraph) {
 	}
 }
 
+// endsMatchFilters returns whether the source and the destination of the path match the filters
+// at the root of the block, such as the node filters of the ACLs. The other nodes of the path are
+// filtered by the filters of the predicates.
+func (sg *SubGraph) endsMatchFilters(ctx context.Context) (bool, error) {
+	if len(sg.Filters) == 0 {
+		return true, nil
+	}
+	ends := []uint64{sg.Params.From}
+	switch {
+	case sg.Params.To < sg.Params.From:
+		ends = []uint64{sg.Params.To, sg.Params.From}
+	case sg.Params.To > sg.Params.From:
+		ends = append(ends, sg.Params.To)
+	}
+	temp := &SubGraph{ReadTs: sg.ReadTs, Cache: sg.Cache, SrcUIDs: &pb.List{Uids: ends}}
+	for _, f := range sg.Filters {
+		fc := &SubGraph{}
+		fc.copyFiltersRecurse(f)
+		temp.Filters = append(temp.Filters, fc)
+	}
+	rch := make(chan error, 1)
+	ProcessGraph(ctx, temp, &SubGraph{}, rch)
+	if err := <-rch; err != nil {
+		return false, err
+	}
+	return len(temp.DestUIDs.Uids) == len(ends), nil
+}
+
 func KShortestPath(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 	var err error
 	if sg.Params.Alias != "shortest" {
@@ -430,6 +462,9 @@ func ShortestPath(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 		numPaths = 1
 	}
 
+	if ok, err := sg.endsMatchFilters(ctx); err != nil || !ok {
+		return nil, err
+	}
 	if numPaths > 1 {
 		return KShortestPath(ctx, sg)
 	}
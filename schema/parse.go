@@ -308,6 +308,15 @@ func parseTypeDeclaration(it *lex.ItemIterator) (*pb.TypeUpdate, error) {
 	typeUpdate := &pb.TypeUpdate{TypeName: it.Item().Val}
 
 	it.Next()
+	for it.Item().Typ == itemAt {
+		it.Next()
+		if it.Item().Typ != itemText || it.Item().Val != "strict" {
+			return nil, it.Item().Errorf("Invalid type directive: @%v", it.Item().Val)
+		}
+		typeUpdate.Strict = true
+		it.Next()
+	}
+
 	if it.Item().Typ != itemLeftCurl {
 		return nil, it.Item().Errorf("Expected {. Got %v", it.Item().Val)
 	}
@@ -419,7 +428,7 @@ func isTypeDeclaration(item lex.Item, it *lex.ItemIterator) bool {
 	case nextItems[0].Typ != itemText:
 		return false
 
-	case nextItems[1].Typ != itemLeftCurl:
+	case nextItems[1].Typ != itemLeftCurl && nextItems[1].Typ != itemAt:
 		return false
 	}
 
@@ -409,6 +409,46 @@ func TestParseSingleType(t *testing.T) {
 	}, result.Types[0])
 }
 
+func TestParseStrictType(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		type Person @strict {
+			Name: string!
+			Friend: [Person]
+		}
+	`)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.Types))
+	require.Equal(t, &pb.TypeUpdate{
+		TypeName: "Person",
+		Strict:   true,
+		Fields: []*pb.SchemaUpdate{
+			{
+				Predicate:   "Name",
+				ValueType:   pb.Posting_STRING,
+				NonNullable: true,
+			},
+			{
+				Predicate:      "Friend",
+				ValueType:      pb.Posting_OBJECT,
+				ObjectTypeName: "Person",
+				List:           true,
+			},
+		},
+	}, result.Types[0])
+}
+
+func TestParseTypeErrInvalidDirective(t *testing.T) {
+	reset()
+	_, err := Parse(`
+		type Person @loose {
+			Name: string
+		}
+	`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid type directive: @loose")
+}
+
 func TestParseBaseTypesCaseInsensitive(t *testing.T) {
 	reset()
 	result, err := Parse(`
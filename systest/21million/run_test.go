@@ -57,7 +57,8 @@ func TestQueries(t *testing.T) {
 	for _, file := range files {
 		filename := path.Join(queryDir, file.Name())
 
-		reader, cleanup := chunker.FileReader(filename)
+		reader, cleanup, err := chunker.FileReader(filename, nil)
+		x.CheckfNoTrace(err)
 		bytes, err := ioutil.ReadAll(reader)
 		x.CheckfNoTrace(err)
 		contents := string(bytes[:])
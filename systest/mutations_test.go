@@ -28,6 +28,7 @@ import (
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgo/y"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/dgraph/z"
 	"github.com/stretchr/testify/require"
@@ -82,6 +83,7 @@ func TestSystem(t *testing.T) {
 	t.Run("drop data and drop all", wrap(DropDataAndDropAll))
 	t.Run("drop type", wrap(DropType))
 	t.Run("drop type without specified type", wrap(DropTypeNoValue))
+	t.Run("strict type", wrap(StrictType))
 }
 
 func FacetJsonInputSupportsAnyOfTerms(t *testing.T, c *dgo.Dgraph) {
@@ -153,6 +155,11 @@ func ExpandAllLangTest(t *testing.T, c *dgo.Dgraph) {
 		Schema: `
 			list: [string] .
 			name: string @lang .
+			type Node {
+				name: string
+				number: int
+				list: [string]
+			}
 		`,
 	})))
 
@@ -171,6 +178,9 @@ func ExpandAllLangTest(t *testing.T, c *dgo.Dgraph) {
 			<0x1> <number> "99"^^<xs:int> .
 			<0x1> <list> "first" .
 			<0x1> <list> "second" .
+			<0x1> <dgraph.type> "Node" .
+			<0x2> <dgraph.type> "Node" .
+			<0x3> <dgraph.type> "Node" .
 		`),
 	})
 	check(t, err)
@@ -348,7 +358,12 @@ func ExpandAllReversePredicatesTest(t *testing.T, c *dgo.Dgraph) {
 	ctx := context.Background()
 
 	require.NoError(t, c.Alter(ctx, &api.Operation{
-		Schema: `link: [uid] @reverse .`,
+		Schema: `
+			link: [uid] @reverse .
+			type Link {
+				link: [uid]
+			}
+		`,
 	}))
 
 	_, err := c.NewTxn().Mutate(ctx, &api.Mutation{
@@ -363,6 +378,11 @@ func ExpandAllReversePredicatesTest(t *testing.T, c *dgo.Dgraph) {
 
 			# S**
 			<0x6> <link> <0x7> .
+
+			<0x2> <dgraph.type> "Link" .
+			<0x4> <dgraph.type> "Link" .
+			<0x5> <dgraph.type> "Link" .
+			<0x7> <dgraph.type> "Link" .
 		`),
 	})
 	require.NoError(t, err)
@@ -1140,7 +1160,12 @@ func FacetExpandAll(t *testing.T, c *dgo.Dgraph) {
 
 	check(t, (c.Alter(ctx, &api.Operation{
 		Schema: `name: string @index(hash) .
-				friend: [uid] @reverse .`,
+				friend: [uid] @reverse .
+				type Person {
+					name: string
+					friend: [uid]
+				}
+				`,
 	})))
 
 	txn := c.NewTxn()
@@ -1151,6 +1176,9 @@ func FacetExpandAll(t *testing.T, c *dgo.Dgraph) {
 			_:b <name> "Bob" (from="Toronto",to="Vancouver").
 			_:a <friend> _:c (age=15,car="Tesla") .
 			_:c <name> "Charlie" .
+			_:a <dgraph.type> "Person" .
+			_:b <dgraph.type> "Person" .
+			_:c <dgraph.type> "Person" .
 		`),
 	})
 	require.NoError(t, err)
@@ -1824,3 +1852,117 @@ func DropTypeNoValue(t *testing.T, c *dgo.Dgraph) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "DropValue must not be empty")
 }
+
+func StrictType(t *testing.T, c *dgo.Dgraph) {
+	ctx := context.Background()
+	require.NoError(t, c.Alter(ctx, &api.Operation{
+		Schema: `
+			name: string .
+			owner: uid @reverse .
+			member: [uid] @reverse .
+			type Person @strict {
+				name: string!
+			}
+			type Pet @strict {
+				name: string
+				owner: Person
+			}
+			type Team @strict {
+				member: [Person]!
+			}
+		`,
+	}))
+
+	// A Person without a name breaks the type.
+	_, err := c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		SetNquads: []byte(`_:a <dgraph.type> "Person" .`),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing non-nullable field name")
+
+	assigned, err := c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		SetNquads: []byte(`
+			_:a <dgraph.type> "Person" .
+			_:a <name> "Alice" .
+			_:b <name> "Bob" .
+		`),
+	})
+	require.NoError(t, err)
+	alice, bob := assigned.Uids["a"], assigned.Uids["b"]
+
+	// The owner of a Pet must be a Person.
+	_, err = c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		SetNquads: []byte(`
+			_:p <dgraph.type> "Pet" .
+			_:p <owner> <` + bob + `> .
+		`),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "which is not of type Person")
+
+	_, err = c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		SetNquads: []byte(`
+			_:p <dgraph.type> "Pet" .
+			_:p <owner> <` + alice + `> .
+		`),
+	})
+	require.NoError(t, err)
+
+	// Deleting the name of a Person breaks the type too.
+	_, err = c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		DelNquads: []byte(`<` + alice + `> <name> * .`),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing non-nullable field name")
+
+	// Deleting a value the node doesn't have leaves its name.
+	_, err = c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		DelNquads: []byte(`<` + alice + `> <name> "Nobody" .`),
+	})
+	require.NoError(t, err)
+
+	// Removing the type of a Person breaks the Pets it owns.
+	_, err = c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		DelNquads: []byte(`<` + alice + `> <dgraph.type> "Person" .`),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "which is not of type Person")
+
+	// Concurrent transactions each removing a different member of a Team would leave it
+	// without members together, so only one of them commits.
+	assigned, err = c.NewTxn().Mutate(ctx, &api.Mutation{
+		CommitNow: true,
+		SetNquads: []byte(`
+			_:c <dgraph.type> "Person" .
+			_:c <name> "Carol" .
+			_:t <dgraph.type> "Team" .
+			_:t <member> <` + alice + `> .
+			_:t <member> _:c .
+		`),
+	})
+	require.NoError(t, err)
+	carol, team := assigned.Uids["c"], assigned.Uids["t"]
+
+	txn1, txn2 := c.NewTxn(), c.NewTxn()
+	_, err = txn1.Mutate(ctx, &api.Mutation{
+		DelNquads: []byte(`<` + team + `> <member> <` + alice + `> .`),
+	})
+	require.NoError(t, err)
+	_, err = txn2.Mutate(ctx, &api.Mutation{
+		DelNquads: []byte(`<` + team + `> <member> <` + carol + `> .`),
+	})
+	require.NoError(t, err)
+	require.NoError(t, txn1.Commit(ctx))
+	require.Equal(t, y.ErrAborted, txn2.Commit(ctx))
+
+	resp, err := c.NewReadOnlyTxn().Query(ctx, `{ q(func: type(Person)) { name } }`)
+	require.NoError(t, err)
+	CompareJSON(t, `{"q":[{"name":"Alice"},{"name":"Carol"}]}`, string(resp.Json))
+}
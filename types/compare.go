@@ -44,3 +44,8 @@ func CompareVals(op string, arg1, arg2 Val) bool {
 	}
 	return false
 }
+
+// CompareBetween returns true if val lies within the closed range [lo, hi].
+func CompareBetween(val, lo, hi Val) bool {
+	return CompareVals("ge", val, lo) && CompareVals("le", val, hi)
+}
@@ -19,6 +19,8 @@
 package worker
 
 import (
+	"io"
+
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
@@ -35,3 +37,8 @@ func (w *grpcWorker) Backup(ctx context.Context, req *pb.BackupRequest) (*pb.Num
 func BackupOverNetwork(ctx context.Context, destination string) error {
 	return x.ErrNotSupported
 }
+
+// exportWriter is not supported in the oss version, exports are only written locally.
+func exportWriter(in *pb.ExportRequest, path string) (io.WriteCloser, error) {
+	return nil, x.ErrNotSupported
+}
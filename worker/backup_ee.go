@@ -13,7 +13,9 @@
 package worker
 
 import (
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/dgraph-io/dgraph/ee/backup"
@@ -52,10 +54,15 @@ func backupProcess(ctx context.Context, req *pb.BackupRequest) error {
 	// Attach snapshot readTs to request to compare with any previous version.
 	req.SnapshotTs = snap.ReadTs
 	// create backup request and process it.
-	br := &backup.Request{DB: pstore, Backup: req}
+	br := &backup.Request{DB: pstore, Backup: req, Key: x.WorkerConfig.EncryptionKey}
 	return br.Process(ctx)
 }
 
+// exportWriter returns a writer for the export file at path under the destination of in.
+func exportWriter(in *pb.ExportRequest, path string) (io.WriteCloser, error) {
+	return backup.NewExportWriter(in, path)
+}
+
 // Backup handles a request coming from another node.
 func (w *grpcWorker) Backup(ctx context.Context, req *pb.BackupRequest) (*pb.Num, error) {
 	var num pb.Num
@@ -104,6 +111,23 @@ func BackupOverNetwork(ctx context.Context, r *http.Request) error {
 	sessionToken := r.FormValue("session_token")
 	anonymous := r.FormValue("anonymous") == "true"
 
+	// Backups are incremental against the latest backup at the destination, unless asked
+	// otherwise.
+	typ := r.FormValue("type")
+	if typ != "" && typ != "full" && typ != "incremental" {
+		return x.Errorf("Invalid backup type %q, must be one of: full, incremental", typ)
+	}
+	var baseReadTs uint64
+	if base := r.FormValue("base_read_ts"); base != "" {
+		if typ == "full" {
+			return x.Errorf("A full backup can't have a base_read_ts")
+		}
+		var err error
+		if baseReadTs, err = strconv.ParseUint(base, 0, 64); err != nil {
+			return x.Wrapf(err, "Invalid base_read_ts %q", base)
+		}
+	}
+
 	// Check that this node can accept requests.
 	if err := x.HealthCheck(); err != nil {
 		glog.Errorf("Backup canceled, not ready to accept requests: %s", err)
@@ -125,6 +149,8 @@ func BackupOverNetwork(ctx context.Context, r *http.Request) error {
 		SecretKey:    secretKey,
 		SessionToken: sessionToken,
 		Anonymous:    anonymous,
+		Type:         typ,
+		BaseReadTs:   baseReadTs,
 	}
 	m := backup.Manifest{Groups: groups().KnownGroups()}
 	glog.Infof("Created backup request: %s. Groups=%v\n", &req, m.Groups)
@@ -161,6 +187,6 @@ func BackupOverNetwork(ctx context.Context, r *http.Request) error {
 	}
 
 	// The manifest completes the backup.
-	br := &backup.Request{Backup: &req, Manifest: &m}
+	br := &backup.Request{Backup: &req, Manifest: &m, Key: x.WorkerConfig.EncryptionKey}
 	return br.Complete(ctx)
 }
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// CDCEvent is a transaction committed in the group of this server, as written to the change
+// data capture sink. Set and Delete hold the N-Quads the transaction set and deleted in the
+// group.
+type CDCEvent struct {
+	CommitTs uint64   `json:"commit_ts"`
+	StartTs  uint64   `json:"start_ts"`
+	Group    uint32   `json:"group"`
+	Set      []string `json:"set,omitempty"`
+	Delete   []string `json:"delete,omitempty"`
+}
+
+// cdcSink appends the transactions committed in the group of this server to a file, one JSON
+// encoded CDCEvent per line, in commit order. Raft replays mutations and commits on restart, so
+// commits at or below the last one in the file are skipped.
+type cdcSink struct {
+	sync.Mutex
+	gid          uint32
+	fd           *os.File
+	lastCommitTs uint64
+	// Edges of the pending transactions, by start ts.
+	pending map[uint64][]*pb.DirectedEdge
+	// Closed and replaced on every write, to wake up the readers following the file.
+	written chan struct{}
+}
+
+var cdc *cdcSink
+
+// openCDCSink opens the sink at path for the group gid, creating it if needed.
+func openCDCSink(path string, gid uint32) (*cdcSink, error) {
+	fd, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, x.Wrapf(err, "While opening CDC sink %q", path)
+	}
+	s := &cdcSink{
+		gid:     gid,
+		fd:      fd,
+		pending: make(map[uint64][]*pb.DirectedEdge),
+		written: make(chan struct{}),
+	}
+	if err := s.recover(); err != nil {
+		fd.Close()
+		return nil, x.Wrapf(err, "While reading CDC sink %q", path)
+	}
+	glog.Infof("Writing CDC events to %q after commit ts %d", path, s.lastCommitTs)
+	return s, nil
+}
+
+// recover finds the last event in the file, and truncates any partially written event after it.
+func (s *cdcSink) recover() error {
+	var last CDCEvent
+	var end int64
+	r := bufio.NewReader(s.fd)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(line, &last); err != nil {
+			return err
+		}
+		end += int64(len(line))
+	}
+	if err := s.fd.Truncate(end); err != nil {
+		return err
+	}
+	if _, err := s.fd.Seek(end, io.SeekStart); err != nil {
+		return err
+	}
+	s.lastCommitTs = last.CommitTs
+	return nil
+}
+
+// addEdges records the edges a pending transaction applies in this group.
+func (s *cdcSink) addEdges(startTs uint64, edges []*pb.DirectedEdge) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.pending[startTs] = append(s.pending[startTs], edges...)
+}
+
+// commit writes the transaction with the given start ts to the sink if it was committed, and
+// forgets about it if it was aborted.
+func (s *cdcSink) commit(startTs, commitTs uint64) error {
+	if s == nil {
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	edges, ok := s.pending[startTs]
+	delete(s.pending, startTs)
+	if !ok || commitTs == 0 || commitTs <= s.lastCommitTs {
+		return nil
+	}
+
+	e := CDCEvent{CommitTs: commitTs, StartTs: startTs, Group: s.gid}
+	for _, edge := range edges {
+		nq, err := edgeToRDF(edge)
+		if err != nil {
+			glog.Errorf("While converting %v to an N-Quad. Err=%v. Ignoring.\n", edge, err)
+			continue
+		}
+		if edge.Op == pb.DirectedEdge_DEL {
+			e.Delete = append(e.Delete, nq)
+		} else {
+			e.Set = append(e.Set, nq)
+		}
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := s.fd.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	s.lastCommitTs = commitTs
+	close(s.written)
+	s.written = make(chan struct{})
+	return nil
+}
+
+// reset forgets about the pending transactions, like Oracle().ResetTxns.
+func (s *cdcSink) reset() {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.pending = make(map[uint64][]*pb.DirectedEdge)
+}
+
+// waitCh returns a channel closed on the next write.
+func (s *cdcSink) waitCh() <-chan struct{} {
+	s.Lock()
+	defer s.Unlock()
+	return s.written
+}
+
+// edgeToRDF returns edge as an N-Quad, without the trailing newline.
+func edgeToRDF(edge *pb.DirectedEdge) (string, error) {
+	var buf bytes.Buffer
+	subject := fmt.Sprintf(uidFmtStr, edge.Entity)
+	switch {
+	case edge.Attr == x.Star:
+		return subject + " * * .", nil
+	case bytes.Equal(edge.Value, []byte(x.Star)):
+		return fmt.Sprintf("%s <%s> * .", subject, edge.Attr), nil
+	}
+	err := writeRDF(&buf, fmt.Sprintf("%s <%s> ", subject, edge.Attr), posting.NewPosting(edge))
+	return strings.TrimSuffix(buf.String(), "\n"), err
+}
+
+// StreamCDC writes the events of the CDC sink committed after sinceTs to w, one JSON encoded
+// CDCEvent per line. It then follows the sink, writing the events as they are committed, until
+// ctx is done. flush is called after each batch of events is written.
+func StreamCDC(ctx context.Context, sinceTs uint64, w io.Writer, flush func()) error {
+	if cdc == nil {
+		return x.Errorf("Change data capture is not enabled. Restart Alpha with --cdc_file.")
+	}
+	fd, err := os.Open(cdc.fd.Name())
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	r := bufio.NewReader(fd)
+	var partial []byte
+	for {
+		// Get the channel before reading, so no write is missed.
+		written := cdc.waitCh()
+		for {
+			line, err := r.ReadBytes('\n')
+			if err == io.EOF {
+				// Keep the partially read line, it's completed by the next write.
+				partial = append(partial, line...)
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if len(partial) > 0 {
+				line = append(partial, line...)
+				partial = nil
+			}
+			var e CDCEvent
+			if err := json.Unmarshal(line, &e); err != nil {
+				return err
+			}
+			if e.CommitTs <= sinceTs {
+				continue
+			}
+			if _, err := w.Write(line); err != nil {
+				return err
+			}
+		}
+		flush()
+
+		select {
+		case <-written:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
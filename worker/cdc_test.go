@@ -0,0 +1,161 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func readCDCEvents(t *testing.T, b []byte) []CDCEvent {
+	var events []CDCEvent
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		var e CDCEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, e)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestCDCSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cdc.json")
+
+	s, err := openCDCSink(path, 1)
+	require.NoError(t, err)
+	s.addEdges(10, []*pb.DirectedEdge{
+		{Entity: 1, Attr: "name", Value: []byte("alice"), ValueType: pb.Posting_STRING},
+		{Entity: 1, Attr: "friend", ValueId: 2},
+	})
+	s.addEdges(12, []*pb.DirectedEdge{
+		{Entity: 1, Attr: "name", Value: []byte(x.Star), Op: pb.DirectedEdge_DEL},
+	})
+	s.addEdges(14, []*pb.DirectedEdge{
+		{Entity: 2, Attr: "name", Value: []byte("bob"), Lang: "en"},
+	})
+	require.NoError(t, s.commit(10, 11))
+	require.NoError(t, s.commit(14, 0)) // Aborted.
+	require.NoError(t, s.commit(12, 13))
+	require.NoError(t, s.commit(16, 17)) // No mutations in this group.
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []CDCEvent{
+		{CommitTs: 11, StartTs: 10, Group: 1, Set: []string{
+			`<0x1> <name> "alice"^^<xs:string> .`,
+			`<0x1> <friend> <0x2> .`,
+		}},
+		{CommitTs: 13, StartTs: 12, Group: 1, Delete: []string{`<0x1> <name> * .`}},
+	}, readCDCEvents(t, b))
+	require.NoError(t, s.fd.Close())
+
+	// A partially written event is dropped, and replayed commits are skipped on restart.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte(`{"commit_ts":`))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s, err = openCDCSink(path, 1)
+	require.NoError(t, err)
+	defer s.fd.Close()
+	require.Equal(t, uint64(13), s.lastCommitTs)
+	s.addEdges(12, []*pb.DirectedEdge{
+		{Entity: 1, Attr: "name", Value: []byte(x.Star), Op: pb.DirectedEdge_DEL},
+	})
+	s.addEdges(18, []*pb.DirectedEdge{
+		// Invalid values are skipped.
+		{Entity: 3, Attr: "age", Value: []byte("3"), ValueType: pb.Posting_INT},
+		{Entity: 3, Attr: "name", Value: []byte("carol"), Lang: "en"},
+	})
+	require.NoError(t, s.commit(12, 13))
+	require.NoError(t, s.commit(18, 19))
+
+	b, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	events := readCDCEvents(t, b)
+	require.Equal(t, 3, len(events))
+	require.Equal(t, CDCEvent{CommitTs: 19, StartTs: 18, Group: 1,
+		Set: []string{`<0x3> <name> "carol"@en .`}}, events[2])
+}
+
+func TestStreamCDC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cdc, err = openCDCSink(filepath.Join(dir, "cdc.json"), 1)
+	require.NoError(t, err)
+	defer func() {
+		cdc.fd.Close()
+		cdc = nil
+	}()
+	val, err := types.ObjectValue(types.IntID, int64(1))
+	require.NoError(t, err)
+	edge := &pb.DirectedEdge{Entity: 1, Attr: "age", Value: val.GetBytesVal()}
+	for ts := uint64(1); ts < 6; ts += 2 {
+		cdc.addEdges(ts, []*pb.DirectedEdge{edge})
+		require.NoError(t, cdc.commit(ts, ts+1))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- StreamCDC(ctx, 2, pw, func() {})
+		pw.Close()
+	}()
+
+	// Events after since_ts are streamed, followed by the ones committed later.
+	dec := json.NewDecoder(pr)
+	var e CDCEvent
+	require.NoError(t, dec.Decode(&e))
+	require.Equal(t, uint64(4), e.CommitTs)
+	require.NoError(t, dec.Decode(&e))
+	require.Equal(t, uint64(6), e.CommitTs)
+
+	cdc.addEdges(7, []*pb.DirectedEdge{edge})
+	require.NoError(t, cdc.commit(7, 8))
+	require.NoError(t, dec.Decode(&e))
+	require.Equal(t, uint64(8), e.CommitTs)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("StreamCDC didn't return")
+	}
+}
@@ -77,9 +77,10 @@ func newNode(store *raftwal.DiskStorage, gid uint32, id uint64, myAddr string) *
 	glog.Infof("Node ID: %#x with GroupID: %d\n", id, gid)
 
 	rc := &pb.RaftContext{
-		Addr:  myAddr,
-		Group: gid,
-		Id:    id,
+		Addr:      myAddr,
+		Group:     gid,
+		Id:        id,
+		IsLearner: x.WorkerConfig.Learner,
 	}
 	m := conn.NewNode(rc, store)
 
@@ -169,12 +170,14 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 	if proposal.Mutations.DropOp == pb.Mutations_DATA {
 		// Ensures nothing get written to disk due to commit proposals.
 		posting.Oracle().ResetTxns()
+		cdc.reset()
 		return posting.DeleteData()
 	}
 
 	if proposal.Mutations.DropOp == pb.Mutations_ALL {
 		// Ensures nothing get written to disk due to commit proposals.
 		posting.Oracle().ResetTxns()
+		cdc.reset()
 		schema.State().DeleteAll()
 
 		if err := posting.DeleteAll(); err != nil {
@@ -288,6 +291,7 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 		}
 		return ei.GetEntity() < ej.GetEntity()
 	})
+	cdc.addEdges(m.StartTs, m.Edges)
 
 	process := func(edges []*pb.DirectedEdge) error {
 		var retries int
@@ -351,6 +355,10 @@ func (n *node) applyCommitted(proposal *pb.Proposal) error {
 	}
 
 	switch {
+	case proposal.Ingest != nil:
+		n.elog.Printf("Applying ingestion of predicate: %s", proposal.Ingest.Predicate)
+		return applyIngest(ctx, proposal)
+
 	case len(proposal.Kv) > 0:
 		return populateKeyValues(ctx, proposal.Kv)
 
@@ -367,7 +375,7 @@ func (n *node) applyCommitted(proposal *pb.Proposal) error {
 
 	case proposal.Delta != nil:
 		n.elog.Printf("Applying Oracle Delta for key: %s", proposal.Key)
-		return n.commitOrAbort(proposal.Key, proposal.Delta)
+		return n.commitOrAbort(proposal.Key, proposal.Index, proposal.Delta)
 
 	case proposal.Snapshot != nil:
 		existing, err := n.Store.Snapshot()
@@ -415,16 +423,19 @@ func (n *node) processRollups() {
 			return
 		case readTs = <-n.rollupCh:
 		case <-tick.C:
-			if readTs <= last {
+			// Keep the versions read while the predicates are ingested.
+			rollupTs := ingestRollupTs(readTs)
+			if rollupTs <= last {
 				break // Break out of the select case.
 			}
-			if err := n.rollupLists(readTs); err != nil {
+			if err := n.rollupLists(rollupTs); err != nil {
 				// If we encounter error here, we don't need to do anything about
 				// it. Just let the user know.
-				glog.Errorf("Error while rolling up lists at %d: %v\n", readTs, err)
+				glog.Errorf("Error while rolling up lists at %d: %v\n", rollupTs, err)
 			} else {
-				last = readTs // Update last only if we succeeded.
-				glog.Infof("List rollup at Ts %d: OK.\n", readTs)
+				last = rollupTs // Update last only if we succeeded.
+				dropIngests(rollupTs)
+				glog.Infof("List rollup at Ts %d: OK.\n", rollupTs)
 			}
 		}
 	}
@@ -508,7 +519,7 @@ func (n *node) processApplyCh() {
 	}
 }
 
-func (n *node) commitOrAbort(pkey string, delta *pb.OracleDelta) error {
+func (n *node) commitOrAbort(pkey string, index uint64, delta *pb.OracleDelta) error {
 	// First let's commit all mutations to disk.
 	writer := posting.NewTxnWriter(pstore)
 	toDisk := func(start, commit uint64) {
@@ -537,6 +548,18 @@ func (n *node) commitOrAbort(pkey string, delta *pb.OracleDelta) error {
 	if err := writer.Flush(); err != nil {
 		return x.Errorf("Error while flushing to disk: %v", err)
 	}
+	for _, status := range delta.Txns {
+		if err := cdc.commit(status.StartTs, status.CommitTs); err != nil {
+			glog.Errorf("Error while writing txn (%d -> %d) to CDC sink: %v",
+				status.StartTs, status.CommitTs, err)
+		}
+	}
+
+	for _, status := range delta.Txns {
+		if err := applyIngestStatus(status, index); err != nil {
+			return err
+		}
+	}
 
 	g := groups()
 	atomic.StoreUint64(&g.deltaChecksum, delta.GroupChecksums[g.gid])
@@ -619,6 +642,8 @@ func (n *node) retrieveSnapshot(snap pb.Snapshot) error {
 	if _, err := n.populateSnapshot(snap, pool); err != nil {
 		return fmt.Errorf("Cannot retrieve snapshot from peer, error: %v", err)
 	}
+	// The snapshot only has the data as of its ReadTs.
+	posting.Oracle().SetMinReadTs(snap.ReadTs)
 	// Populate shard stores the streamed data directly into db, so we need to refresh
 	// schema for current group id
 	if err := schema.LoadFromDb(); err != nil {
@@ -715,7 +740,11 @@ func (n *node) Run() {
 
 			if rd.SoftState != nil {
 				groups().triggerMembershipSync()
+				wasLeader := leader
 				leader = rd.RaftState == raft.StateLeader
+				if leader && !wasLeader {
+					go n.watchIngests()
+				}
 			}
 			if leader {
 				// Leader can send messages in parallel with writing to disk.
@@ -937,6 +966,7 @@ func (n *node) rollupLists(readTs uint64) error {
 
 	// We can now discard all invalid versions of keys below this ts.
 	pstore.SetDiscardTs(readTs)
+	posting.Oracle().SetMinReadTs(readTs)
 
 	if amLeader {
 		// Only leader sends the tablet size updates to Zero. No one else does.
@@ -1063,7 +1093,6 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 		span.Annotate(nil, "Skipping calculateSnapshot due to streaming")
 		return nil, nil
 	}
-
 	first, err := n.Store.FirstIndex()
 	if err != nil {
 		span.Annotatef(nil, "Error: %v", err)
@@ -1144,6 +1173,13 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 		span.Annotatef(nil, "snapshotIdx is zero. Using last entry's index: %d", snapshotIdx)
 	}
 
+	// The Raft log must be replayed from the start of the ongoing ingestions, to restore their
+	// fences, unless the snapshot includes their end.
+	if snapshotIdx = ingestSnapshotIndex(snapshotIdx); snapshotIdx < first {
+		span.Annotate(nil, "Skipping calculateSnapshot due to ingestion")
+		return nil, nil
+	}
+
 	numDiscarding := snapshotIdx - first + 1
 	span.Annotatef(nil,
 		"Got snapshotIdx: %d. MaxCommitTs: %d. Discarding: %d. MinPendingStartTs: %d",
@@ -1240,8 +1276,15 @@ func (n *node) InitAndStartNode() {
 			// zero-member Raft group.
 			n.SetConfState(&sp.Metadata.ConfState)
 
+			// Versions below the snapshot might have been discarded before the restart.
+			var snap pb.Snapshot
+			x.Check(snap.Unmarshal(sp.Data))
+			posting.Oracle().SetMinReadTs(snap.ReadTs)
+
 			members := groups().members(n.gid)
-			for _, id := range sp.Metadata.ConfState.Nodes {
+			cs := sp.Metadata.ConfState
+			ids := append(append([]uint64{}, cs.Nodes...), cs.Learners...)
+			for _, id := range ids {
 				m, ok := members[id]
 				if ok {
 					n.Connect(id, m.Addr)
@@ -1263,6 +1306,8 @@ func (n *node) InitAndStartNode() {
 			n.retryUntilSuccess(n.joinPeers, time.Second)
 			n.SetRaft(raft.StartNode(n.Cfg, nil))
 		} else {
+			x.AssertTruef(!x.WorkerConfig.Learner,
+				"Learner can't start group %d without any voters.", n.gid)
 			peers := []raft.Peer{{ID: n.Id}}
 			n.SetRaft(raft.StartNode(n.Cfg, peers))
 			// Trigger election, so this node can become the leader of this single-node cluster.
@@ -20,7 +20,10 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"container/heap"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -34,6 +37,8 @@ import (
 
 	"github.com/dgraph-io/badger"
 	bpb "github.com/dgraph-io/badger/pb"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/types"
@@ -76,85 +81,353 @@ func toRDF(pl *posting.List, prefix string, readTs uint64) (*bpb.KVList, error)
 	var buf bytes.Buffer
 
 	err := pl.Iterate(readTs, 0, func(p *pb.Posting) error {
-		buf.WriteString(prefix)
+		if err := writeRDF(&buf, prefix, p); err != nil {
+			glog.Errorf("While converting %v to string. Err=%v. Ignoring.\n", p, err)
+		}
+		return nil
+	})
+	kv := &bpb.KV{
+		Value:   buf.Bytes(), // Don't think we need to copy these, because buf is not being reused.
+		Version: 1,           // Data value.
+	}
+	return listWrap(kv), err
+}
+
+// writeRDF writes p as an N-Quad with the given subject and predicate prefix to buf. Nothing
+// is written if the value of p can't be converted to a string.
+func writeRDF(buf *bytes.Buffer, prefix string, p *pb.Posting) error {
+	// Value posting
+	// Convert to appropriate type
+	vID := types.TypeID(p.ValType)
+	var str types.Val
+	if p.PostingType != pb.Posting_REF {
+		src := types.ValueForType(vID)
+		src.Value = p.Value
+		var err error
+		if str, err = types.Convert(src, types.StringID); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString(prefix)
+	if p.PostingType == pb.Posting_REF {
+		buf.WriteString(fmt.Sprintf(uidFmtStr, p.Uid))
+
+	} else {
+		// trim null character at end
+		trimmed := strings.TrimRight(str.Value.(string), "\x00")
+		buf.WriteString(strconv.Quote(trimmed))
+		if p.PostingType == pb.Posting_VALUE_LANG {
+			buf.WriteByte('@')
+			buf.WriteString(string(p.LangTag))
+
+		} else if vID != types.DefaultID {
+			rdfType, ok := rdfTypeMap[vID]
+			x.AssertTruef(ok, "Didn't find RDF type for dgraph type: %+v", vID.Name())
+			buf.WriteString("^^<")
+			buf.WriteString(rdfType)
+			buf.WriteByte('>')
+		}
+	}
+	// Let's skip labels. Dgraph doesn't support them for any functionality.
+
+	// Facets.
+	fcs := p.Facets
+	if len(fcs) != 0 {
+		buf.WriteString(" (")
+		for i, f := range fcs {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(f.Key)
+			buf.WriteByte('=')
+
+			fVal, err := facets.ValFor(f)
+			if err != nil {
+				glog.Errorf("Error getting value from facet %#v:%v", f, err)
+				continue
+			}
+
+			fStringVal := &types.Val{Tid: types.StringID}
+			if err = types.Marshal(fVal, fStringVal); err != nil {
+				glog.Errorf("Error while marshaling facet value %v to string: %v",
+					fVal, err)
+				continue
+			}
+			facetTid, err := facets.TypeIDFor(f)
+			if err != nil {
+				glog.Errorf("Error getting type id from facet %#v:%v", f, err)
+				continue
+			}
+
+			if facetTid == types.StringID {
+				buf.WriteString(strconv.Quote(fStringVal.Value.(string)))
+			} else {
+				buf.WriteString(fStringVal.Value.(string))
+			}
+		}
+		buf.WriteByte(')')
+	}
+	// End dot.
+	buf.WriteString(" .\n")
+	return nil
+}
+
+// addJSON adds the postings of pl to obj, the JSON object of their node that chunker/json loads
+// back. It returns the objects of the values of list predicates that have facets, as chunker/json
+// only takes facets of single values.
+func addJSON(obj map[string]interface{}, pl *posting.List, attr string,
+	readTs uint64) ([]map[string]interface{}, error) {
+	var refs, vals []interface{}
+	var valFacets [][]*api.Facet
+
+	err := pl.Iterate(readTs, 0, func(p *pb.Posting) error {
 		if p.PostingType == pb.Posting_REF {
-			buf.WriteString(fmt.Sprintf(uidFmtStr, p.Uid))
+			ref := map[string]interface{}{"uid": fmt.Sprintf("0x%x", p.Uid)}
+			addJSONFacets(ref, attr, p.Facets)
+			refs = append(refs, ref)
+			return nil
+		}
 
+		val, ok := toJSONValue(p, attr)
+		if !ok {
+			return nil
+		}
+		if p.PostingType == pb.Posting_VALUE_LANG {
+			pred := attr + "@" + string(p.LangTag)
+			obj[pred] = val
+			addJSONFacets(obj, pred, p.Facets)
+			return nil
+		}
+		vals = append(vals, val)
+		valFacets = append(valFacets, p.Facets)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var objs []map[string]interface{}
+	switch {
+	case len(refs) > 0:
+		obj[attr] = refs
+	case len(vals) == 1:
+		obj[attr] = vals[0]
+		addJSONFacets(obj, attr, valFacets[0])
+	case len(vals) > 1:
+		var list []interface{}
+		for i, val := range vals {
+			if len(valFacets[i]) == 0 {
+				list = append(list, val)
+				continue
+			}
+			o := map[string]interface{}{"uid": obj["uid"], attr: val}
+			addJSONFacets(o, attr, valFacets[i])
+			objs = append(objs, o)
+		}
+		if len(list) > 0 {
+			obj[attr] = list
+		}
+	}
+	return objs, nil
+}
+
+// jsonIterator iterates over the data keys of a predicate, in the order of their uids.
+type jsonIterator struct {
+	attr string
+	itr  *badger.Iterator
+	key  []byte
+	uid  uint64
+}
+
+// next moves the iterator to the next node of the predicate, skipping the other versions of
+// the current one and the parts of the split posting lists. It returns false at the end.
+func (ji *jsonIterator) next() bool {
+	for ; ji.itr.Valid(); ji.itr.Next() {
+		item := ji.itr.Item()
+		if bytes.Equal(item.Key(), ji.key) {
+			continue
+		}
+		pk := x.Parse(item.Key())
+		if pk == nil || !pk.IsData() || pk.StartUid != 0 {
+			continue
+		}
+		ji.key = item.KeyCopy(nil)
+		ji.uid = pk.Uid
+		return true
+	}
+	return false
+}
+
+type jsonHeap []*jsonIterator
+
+func (h jsonHeap) Len() int            { return len(h) }
+func (h jsonHeap) Less(i, j int) bool  { return h[i].uid < h[j].uid }
+func (h jsonHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *jsonHeap) Push(x interface{}) { *h = append(*h, x.(*jsonIterator)) }
+func (h *jsonHeap) Pop() interface{} {
+	old := *h
+	ji := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return ji
+}
+
+// exportJSON writes the data of the predicates served by this group to w as a JSON array, with
+// an object per node holding all its predicates. The data keys are sorted by predicate first, so
+// the nodes are gathered by merging an iterator per predicate on their uids.
+func exportJSON(ctx context.Context, w io.Writer, readTs uint64) error {
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	var h jsonHeap
+	defer func() {
+		for _, ji := range h {
+			ji.itr.Close()
+		}
+	}()
+	// Find the predicates with data by jumping from one to the next.
+	keys := txn.NewIterator(badger.IteratorOptions{Prefix: []byte{x.DefaultPrefix}})
+	for keys.Rewind(); keys.Valid(); {
+		pk := x.Parse(keys.Item().Key())
+		if pk == nil {
+			keys.Next()
+			continue
+		}
+		keys.Seek(pk.SkipPredicate())
+		if !pk.IsData() || pk.Attr == x.PredicateListAttr {
+			continue
+		}
+		if servesTablet, err := groups().ServesTablet(pk.Attr); err != nil || !servesTablet {
+			continue
+		}
+		iterOpts := badger.DefaultIteratorOptions
+		iterOpts.AllVersions = true
+		iterOpts.PrefetchValues = false
+		iterOpts.Prefix = pk.DataPrefix()
+		ji := &jsonIterator{attr: pk.Attr, itr: txn.NewIterator(iterOpts)}
+		ji.itr.Rewind()
+		if ji.next() {
+			h = append(h, ji)
 		} else {
-			// Value posting
-			// Convert to appropriate type
-			vID := types.TypeID(p.ValType)
-			src := types.ValueForType(vID)
-			src.Value = p.Value
-			str, err := types.Convert(src, types.StringID)
+			ji.itr.Close()
+		}
+	}
+	keys.Close()
+	heap.Init(&h)
+
+	if _, err := w.Write([]byte("[\n")); err != nil {
+		return err
+	}
+	first := true
+	for len(h) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		uid := h[0].uid
+		obj := map[string]interface{}{"uid": fmt.Sprintf("0x%x", uid)}
+		var objs []map[string]interface{}
+		for len(h) > 0 && h[0].uid == uid {
+			ji := h[0]
+			pl, err := posting.ReadPostingList(ji.key, ji.itr)
 			if err != nil {
-				glog.Errorf("While converting %v to string. Err=%v. Ignoring.\n", src, err)
-				return nil
+				return err
+			}
+			more, err := addJSON(obj, pl, ji.attr, readTs)
+			if err != nil {
+				return err
 			}
+			objs = append(objs, more...)
+			if ji.next() {
+				heap.Fix(&h, 0)
+			} else {
+				ji.itr.Close()
+				heap.Pop(&h)
+			}
+		}
+		if len(obj) > 1 {
+			objs = append([]map[string]interface{}{obj}, objs...)
+		}
 
-			// trim null character at end
-			trimmed := strings.TrimRight(str.Value.(string), "\x00")
-			buf.WriteString(strconv.Quote(trimmed))
-			if p.PostingType == pb.Posting_VALUE_LANG {
-				buf.WriteByte('@')
-				buf.WriteString(string(p.LangTag))
-
-			} else if vID != types.DefaultID {
-				rdfType, ok := rdfTypeMap[vID]
-				x.AssertTruef(ok, "Didn't find RDF type for dgraph type: %+v", vID.Name())
-				buf.WriteString("^^<")
-				buf.WriteString(rdfType)
-				buf.WriteByte('>')
+		for _, o := range objs {
+			b, err := json.Marshal(o)
+			if err != nil {
+				return err
 			}
+			if !first {
+				b = append([]byte(",\n"), b...)
+			}
+			first = false
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := w.Write([]byte("\n]\n"))
+	return err
+}
+
+// toJSONValue converts the value of p to the JSON value chunker/json parses back to the same
+// value. Passwords are skipped, as chunker/json would hash their stored hash again.
+func toJSONValue(p *pb.Posting, attr string) (interface{}, bool) {
+	vID := types.TypeID(p.ValType)
+	if vID == types.PasswordID {
+		glog.Warningf("Skipping password value of predicate %q in JSON export.", attr)
+		return nil, false
+	}
+	src := types.ValueForType(vID)
+	src.Value = p.Value
+	switch vID {
+	case types.IntID, types.FloatID, types.BoolID:
+		val, err := types.Convert(src, vID)
+		if err != nil {
+			glog.Errorf("While converting %v to %s. Err=%v. Ignoring.\n", src, vID.Name(), err)
+			return nil, false
+		}
+		return val.Value, true
+	}
+
+	str, err := types.Convert(src, types.StringID)
+	if err != nil {
+		glog.Errorf("While converting %v to string. Err=%v. Ignoring.\n", src, err)
+		return nil, false
+	}
+	// trim null character at end
+	trimmed := strings.TrimRight(str.Value.(string), "\x00")
+	if vID == types.GeoID {
+		return json.RawMessage(trimmed), true
+	}
+	return trimmed, true
+}
+
+// addJSONFacets adds fcs to obj as facets of pred, with the keys chunker/json expects.
+func addJSONFacets(obj map[string]interface{}, pred string, fcs []*api.Facet) {
+	for _, f := range fcs {
+		fVal, err := facets.ValFor(f)
+		if err != nil {
+			glog.Errorf("Error getting value from facet %#v:%v", f, err)
+			continue
 		}
-		// Let's skip labels. Dgraph doesn't support them for any functionality.
-
-		// Facets.
-		fcs := p.Facets
-		if len(fcs) != 0 {
-			buf.WriteString(" (")
-			for i, f := range fcs {
-				if i != 0 {
-					buf.WriteByte(',')
-				}
-				buf.WriteString(f.Key)
-				buf.WriteByte('=')
-
-				fVal, err := facets.ValFor(f)
-				if err != nil {
-					glog.Errorf("Error getting value from facet %#v:%v", f, err)
-					continue
-				}
-
-				fStringVal := &types.Val{Tid: types.StringID}
-				if err = types.Marshal(fVal, fStringVal); err != nil {
-					glog.Errorf("Error while marshaling facet value %v to string: %v",
-						fVal, err)
-					continue
-				}
-				facetTid, err := facets.TypeIDFor(f)
-				if err != nil {
-					glog.Errorf("Error getting type id from facet %#v:%v", f, err)
-					continue
-				}
-
-				if facetTid == types.StringID {
-					buf.WriteString(strconv.Quote(fStringVal.Value.(string)))
-				} else {
-					buf.WriteString(fStringVal.Value.(string))
-				}
+		key := pred + x.FacetDelimeter + f.Key
+		switch fVal.Tid {
+		case types.IntID, types.BoolID, types.StringID:
+			obj[key] = fVal.Value
+		case types.FloatID:
+			// chunker/json only parses numbers with a decimal point as floats.
+			str := strconv.FormatFloat(fVal.Value.(float64), 'f', -1, 64)
+			if !strings.Contains(str, ".") {
+				str += ".0"
+			}
+			obj[key] = json.Number(str)
+		default:
+			fStringVal := &types.Val{Tid: types.StringID}
+			if err = types.Marshal(fVal, fStringVal); err != nil {
+				glog.Errorf("Error while marshaling facet value %v to string: %v",
+					fVal, err)
+				continue
 			}
-			buf.WriteByte(')')
+			obj[key] = fStringVal.Value
 		}
-		// End dot.
-		buf.WriteString(" .\n")
-		return nil
-	})
-	kv := &bpb.KV{
-		Value:   buf.Bytes(), // Don't think we need to copy these, because buf is not being reused.
-		Version: 1,           // Data value.
 	}
-	return listWrap(kv), err
 }
 
 func toSchema(attr string, update pb.SchemaUpdate) (*bpb.KVList, error) {
@@ -203,45 +476,104 @@ func toSchema(attr string, update pb.SchemaUpdate) (*bpb.KVList, error) {
 }
 
 type fileWriter struct {
-	fd *os.File
+	fd io.WriteCloser
 	bw *bufio.Writer
+	ew io.WriteCloser
 	gw *gzip.Writer
+
+	closed bool
 }
 
-func (writer *fileWriter) open(fpath string) error {
+// open sets up the writer to write to fd, which is closed with the writer. The data is
+// compressed and, if key isn't nil, encrypted before it's written to fd.
+func (writer *fileWriter) open(fd io.WriteCloser, key []byte) error {
 	var err error
-	writer.fd, err = os.Create(fpath)
-	if err != nil {
-		return err
-	}
-
+	writer.fd = fd
 	writer.bw = bufio.NewWriterSize(writer.fd, 1e6)
-	writer.gw, err = gzip.NewWriterLevel(writer.bw, gzip.BestCompression)
+	var w io.Writer = writer.bw
+	if key != nil {
+		if writer.ew, err = enc.NewWriter(key, writer.bw); err != nil {
+			return err
+		}
+		w = writer.ew
+	}
+	writer.gw, err = gzip.NewWriterLevel(w, gzip.BestCompression)
 	return err
 }
 
-func (writer *fileWriter) Close() error {
+// flush writes the buffered data to fd.
+func (writer *fileWriter) flush() error {
 	if err := writer.gw.Flush(); err != nil {
 		return err
 	}
 	if err := writer.gw.Close(); err != nil {
 		return err
 	}
+	if writer.ew != nil {
+		if err := writer.ew.Close(); err != nil {
+			return err
+		}
+	}
 	if err := writer.bw.Flush(); err != nil {
 		return err
 	}
-	if err := writer.fd.Sync(); err != nil {
+	if f, ok := writer.fd.(*os.File); ok {
+		return f.Sync()
+	}
+	return nil
+}
+
+// Close flushes the data and closes fd. The writer is aborted if the data can't be flushed.
+func (writer *fileWriter) Close() error {
+	if err := writer.flush(); err != nil {
+		x.Ignore(writer.CloseWithError(err))
 		return err
 	}
+	writer.closed = true
+	return writer.fd.Close()
+}
+
+// CloseWithError aborts the writer after err. The writers of destinations are closed with err,
+// so their uploads fail instead of completing with partial data. A closed writer is left as is.
+func (writer *fileWriter) CloseWithError(err error) error {
+	if writer.closed {
+		return nil
+	}
+	writer.closed = true
+	if fd, ok := writer.fd.(interface{ CloseWithError(error) error }); ok {
+		return fd.CloseWithError(err)
+	}
 	return writer.fd.Close()
 }
 
-// export creates a export of data by exporting it as an RDF gzip.
-func export(ctx context.Context, in *pb.ExportRequest) error {
+// Export formats.
+const (
+	rdfFormat  = "rdf"
+	jsonFormat = "json"
+)
+
+func checkExportFormat(format string) error {
+	switch format {
+	case "", rdfFormat, jsonFormat:
+		return nil
+	default:
+		return x.Errorf("Invalid export format: %q. Valid formats are rdf and json.", format)
+	}
+}
+
+// export creates a export of data by exporting it as an RDF or JSON gzip.
+func export(ctx context.Context, in *pb.ExportRequest) (rerr error) {
 	if in.GroupId != groups().groupId() {
 		return x.Errorf("Export request group mismatch. Mine: %d. Requested: %d\n",
 			groups().groupId(), in.GroupId)
 	}
+	if err := checkExportFormat(in.Format); err != nil {
+		return err
+	}
+	format := in.Format
+	if format == "" {
+		format = rdfFormat
+	}
 	glog.Infof("Export requested at %d.", in.ReadTs)
 
 	// Let's wait for this server to catch up to all the updates until this ts.
@@ -251,37 +583,66 @@ func export(ctx context.Context, in *pb.ExportRequest) error {
 	glog.Infof("Running export for group %d at timestamp %d.", in.GroupId, in.ReadTs)
 
 	uts := time.Unix(in.UnixTs, 0)
-	bdir := path.Join(x.WorkerConfig.ExportPath, fmt.Sprintf(
-		"dgraph.r%d.u%s", in.ReadTs, uts.UTC().Format("0102.1504")))
-
-	if err := os.MkdirAll(bdir, 0700); err != nil {
-		return err
+	bdir := fmt.Sprintf("dgraph.r%d.u%s", in.ReadTs, uts.UTC().Format("0102.1504"))
+	if in.Destination == "" {
+		bdir = path.Join(x.WorkerConfig.ExportPath, bdir)
+		if err := os.MkdirAll(bdir, 0700); err != nil {
+			return err
+		}
 	}
-	path := func(suffix string) (string, error) {
-		return filepath.Abs(path.Join(bdir, fmt.Sprintf("g%02d.%s", in.GroupId, suffix)))
+	// The writers left open are aborted if the export fails, so that the uploads to the
+	// destination don't leak.
+	var writers []*fileWriter
+	defer func() {
+		if rerr == nil {
+			return
+		}
+		for _, writer := range writers {
+			x.Ignore(writer.CloseWithError(rerr))
+		}
+	}()
+	// create opens the export file with the given suffix, locally or at the destination.
+	create := func(suffix string) (*fileWriter, error) {
+		fpath := path.Join(bdir, fmt.Sprintf("g%02d.%s", in.GroupId, suffix))
+		var fd io.WriteCloser
+		if in.Destination != "" {
+			glog.Infof("Exporting to %s at %s\n", in.Destination, fpath)
+			var err error
+			if fd, err = exportWriter(in, fpath); err != nil {
+				return nil, err
+			}
+		} else {
+			var err error
+			if fpath, err = filepath.Abs(fpath); err != nil {
+				return nil, err
+			}
+			glog.Infof("Exporting to %s\n", fpath)
+			if fd, err = os.Create(fpath); err != nil {
+				return nil, err
+			}
+		}
+		writer := &fileWriter{}
+		if err := writer.open(fd, x.WorkerConfig.EncryptionKey); err != nil {
+			x.Ignore(writer.CloseWithError(err))
+			return nil, err
+		}
+		writers = append(writers, writer)
+		return writer, nil
 	}
 
 	// Open data file now.
-	dataPath, err := path("rdf.gz")
+	glog.Infof("Exporting data for group: %d\n", in.GroupId)
+	dataWriter, err := create(format + ".gz")
 	if err != nil {
 		return err
 	}
-	glog.Infof("Exporting data for group: %d at %s\n", in.GroupId, dataPath)
-	dataWriter := &fileWriter{}
-	if err := dataWriter.open(dataPath); err != nil {
-		return err
-	}
 
 	// Open schema file now.
-	schemaPath, err := path("schema.gz")
+	glog.Infof("Exporting schema for group: %d\n", in.GroupId)
+	schemaWriter, err := create("schema.gz")
 	if err != nil {
 		return err
 	}
-	glog.Infof("Exporting schema for group: %d at %s\n", in.GroupId, schemaPath)
-	schemaWriter := &fileWriter{}
-	if err := schemaWriter.open(schemaPath); err != nil {
-		return err
-	}
 
 	stream := pstore.NewStreamAt(in.ReadTs)
 	stream.LogPrefix = "Export"
@@ -294,8 +655,8 @@ func export(ctx context.Context, in *pb.ExportRequest) error {
 			return false
 		}
 		// We need to ensure that schema keys are separately identifiable, so they can be
-		// written to a different file.
-		return pk.IsData() || pk.IsSchema()
+		// written to a different file. The data is exported as JSON by exportJSON.
+		return (pk.IsData() && format == rdfFormat) || pk.IsSchema()
 	}
 	stream.KeyToList = func(key []byte, itr *badger.Iterator) (*bpb.KVList, error) {
 		item := itr.Item()
@@ -316,11 +677,11 @@ func export(ctx context.Context, in *pb.ExportRequest) error {
 			return toSchema(pk.Attr, update)
 
 		case pk.IsData():
-			prefix := fmt.Sprintf(uidFmtStr+" <%s> ", pk.Uid, pk.Attr)
 			pl, err := posting.ReadPostingList(key, itr)
 			if err != nil {
 				return nil, err
 			}
+			prefix := fmt.Sprintf(uidFmtStr+" <%s> ", pk.Uid, pk.Attr)
 			return toRDF(pl, prefix, in.ReadTs)
 
 		default:
@@ -351,6 +712,11 @@ func export(ctx context.Context, in *pb.ExportRequest) error {
 	if err := stream.Orchestrate(ctx); err != nil {
 		return err
 	}
+	if format == jsonFormat {
+		if err := exportJSON(ctx, dataWriter.gw, in.ReadTs); err != nil {
+			return err
+		}
+	}
 	if err := dataWriter.Close(); err != nil {
 		return err
 	}
@@ -365,7 +731,8 @@ func export(ctx context.Context, in *pb.ExportRequest) error {
 // If a server receives request to export a group that it doesn't handle, it would
 // automatically relay that request to the server that it thinks should handle the request.
 func (w *grpcWorker) Export(ctx context.Context, req *pb.ExportRequest) (*pb.Status, error) {
-	glog.Infof("Received export request via Grpc: %+v\n", req)
+	// Don't log the request, it may hold the credentials to access the destination.
+	glog.Infof("Received export request via Grpc: group %d at %d\n", req.GroupId, req.ReadTs)
 	if ctx.Err() != nil {
 		glog.Errorf("Context error during export: %v\n", ctx.Err())
 		return nil, ctx.Err()
@@ -373,10 +740,10 @@ func (w *grpcWorker) Export(ctx context.Context, req *pb.ExportRequest) (*pb.Sta
 
 	glog.Infof("Issuing export request...")
 	if err := export(ctx, req); err != nil {
-		glog.Errorf("While running export. Request: %+v. Error=%v\n", req, err)
+		glog.Errorf("While running export of group %d. Error=%v\n", req.GroupId, err)
 		return nil, err
 	}
-	glog.Infof("Export request: %+v OK.\n", req)
+	glog.Infof("Export request of group %d at %d OK.\n", req.GroupId, req.ReadTs)
 	return &pb.Status{Msg: "SUCCESS"}, nil
 }
 
@@ -399,7 +766,12 @@ func handleExportOverNetwork(ctx context.Context, in *pb.ExportRequest) error {
 	return err
 }
 
-func ExportOverNetwork(ctx context.Context) error {
+// ExportOverNetwork exports the data of all the groups. The format, destination and
+// credentials to access the destination are taken from input.
+func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) error {
+	if err := checkExportFormat(input.Format); err != nil {
+		return err
+	}
 	// If we haven't even had a single membership update, don't run export.
 	if err := x.HealthCheck(); err != nil {
 		glog.Errorf("Rejecting export request due to health check error: %v\n", err)
@@ -422,9 +794,15 @@ func ExportOverNetwork(ctx context.Context) error {
 	for _, gid := range gids {
 		go func(group uint32) {
 			req := &pb.ExportRequest{
-				GroupId: group,
-				ReadTs:  readTs,
-				UnixTs:  time.Now().Unix(),
+				GroupId:      group,
+				ReadTs:       readTs,
+				UnixTs:       time.Now().Unix(),
+				Format:       input.Format,
+				Destination:  input.Destination,
+				AccessKey:    input.AccessKey,
+				SecretKey:    input.SecretKey,
+				SessionToken: input.SessionToken,
+				Anonymous:    input.Anonymous,
 			}
 			ch <- handleExportOverNetwork(ctx, req)
 		}(gid)
@@ -20,6 +20,8 @@ import (
 	"bufio"
 	"compress/gzip"
 	"context"
+	"encoding/json"
+	"io"
 	"io/ioutil"
 	"math"
 	"os"
@@ -38,6 +40,7 @@ import (
 	"github.com/dgraph-io/dgraph/types/facets"
 
 	"github.com/dgraph-io/dgraph/chunker/rdf"
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/x"
 )
@@ -234,6 +237,137 @@ func TestExport(t *testing.T) {
 	require.Equal(t, 1, count)
 }
 
+func TestExportJSON(t *testing.T) {
+	initTestExport(t, "name:string @index .")
+	bdir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(bdir)
+
+	x.WorkerConfig.ExportPath = bdir
+	readTs := timestamp()
+	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+	err = export(context.Background(),
+		&pb.ExportRequest{ReadTs: readTs, GroupId: 1, Format: "json"})
+	require.NoError(t, err)
+
+	files := x.WalkPathFunc(bdir, func(path string, isdir bool) bool {
+		return !isdir && strings.HasSuffix(path, ".json.gz")
+	})
+	require.Equal(t, 1, len(files))
+
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var objs []map[string]interface{}
+	require.NoError(t, json.NewDecoder(r).Decode(&objs))
+	friends := []interface{}{map[string]interface{}{"uid": "0x5"}}
+	expected := []map[string]interface{}{
+		{"uid": "0x1", "name": "pho\ton", "friend": friends},
+		{"uid": "0x2", "name@en": "pho\ton", "friend": friends},
+		{"uid": "0x3", "name": "First Line\nSecondLine", "friend": friends},
+		{"uid": "0x4", "friend": []interface{}{map[string]interface{}{
+			"uid":          "0x5",
+			"friend|age":   float64(33),
+			"friend|close": true,
+			"friend|game":  "football",
+			"friend|poem":  "roses are red\nviolets are blue",
+			"friend|since": "2005-05-02T15:04:05Z",
+		}}},
+		{"uid": "0x5", "name": ""},
+	}
+	require.Equal(t, expected, objs)
+}
+
+func TestExportDestination(t *testing.T) {
+	initTestExport(t, "name:string @index .")
+	bdir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(bdir)
+	dest, err := ioutil.TempDir("", "export_dest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dest)
+
+	// Nothing is written to the export directory when there's a destination.
+	x.WorkerConfig.ExportPath = bdir
+	readTs := timestamp()
+	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+	err = export(context.Background(),
+		&pb.ExportRequest{ReadTs: readTs, GroupId: 1, Destination: "file://" + dest})
+	require.NoError(t, err)
+
+	files := x.WalkPathFunc(bdir, func(path string, isdir bool) bool { return !isdir })
+	require.Equal(t, 0, len(files))
+	files = x.WalkPathFunc(dest, func(path string, isdir bool) bool { return !isdir })
+	require.Equal(t, 2, len(files))
+
+	var data string
+	for _, file := range files {
+		if strings.HasSuffix(file, ".rdf.gz") {
+			data = file
+		}
+	}
+	require.NotEmpty(t, data)
+	f, err := os.Open(data)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	scanner := bufio.NewScanner(r)
+	count := 0
+	for scanner.Scan() {
+		count++
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, 8, count)
+}
+
+func TestExportEncrypted(t *testing.T) {
+	initTestExport(t, "name:string @index .")
+	bdir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(bdir)
+
+	key := []byte("0123456789abcdef")
+	x.WorkerConfig.ExportPath = bdir
+	x.WorkerConfig.EncryptionKey = key
+	defer func() { x.WorkerConfig.EncryptionKey = nil }()
+	readTs := timestamp()
+	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+	err = export(context.Background(), &pb.ExportRequest{ReadTs: readTs, GroupId: 1})
+	require.NoError(t, err)
+
+	files := x.WalkPathFunc(bdir, func(path string, isdir bool) bool {
+		return !isdir && strings.HasSuffix(path, ".rdf.gz")
+	})
+	require.Equal(t, 1, len(files))
+
+	// The file can't be read without the key.
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = gzip.NewReader(f)
+	require.Error(t, err)
+
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	er, err := enc.NewReader(key, f)
+	require.NoError(t, err)
+	r, err := gzip.NewReader(er)
+	require.NoError(t, err)
+	scanner := bufio.NewScanner(r)
+	count := 0
+	for scanner.Scan() {
+		_, err := rdf.Parse(scanner.Text())
+		require.NoError(t, err)
+		count++
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, 8, count)
+}
+
 type skv struct {
 	attr   string
 	schema pb.SchemaUpdate
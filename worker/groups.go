@@ -97,7 +97,11 @@ func StartRaftNodes(walStore *badger.DB, bindall bool) {
 	// Successfully connect with dgraphzero, before doing anything else.
 
 	// Connect with Zero leader and figure out what group we should belong to.
-	m := &pb.Member{Id: x.WorkerConfig.RaftId, Addr: x.WorkerConfig.MyAddr}
+	m := &pb.Member{
+		Id:      x.WorkerConfig.RaftId,
+		Addr:    x.WorkerConfig.MyAddr,
+		Learner: x.WorkerConfig.Learner,
+	}
 	var connState *pb.ConnectionState
 	var err error
 	for { // Keep on retrying. See: https://github.com/dgraph-io/dgraph/issues/2289
@@ -132,6 +136,11 @@ func StartRaftNodes(walStore *badger.DB, bindall bool) {
 	gr.Node = newNode(store, gid, x.WorkerConfig.RaftId, x.WorkerConfig.MyAddr)
 
 	x.Checkf(schema.LoadFromDb(), "Error while initializing schema")
+	if x.WorkerConfig.CDCFile != "" {
+		// Open the sink before raft replays the mutations.
+		cdc, err = openCDCSink(x.WorkerConfig.CDCFile, gid)
+		x.Check(err)
+	}
 	raftServer.Node = gr.Node.Node
 	gr.Node.InitAndStartNode()
 	x.UpdateHealthStatus(true)
@@ -214,6 +223,23 @@ func MaxLeaseId() uint64 {
 	return g.state.MaxLeaseId
 }
 
+// IsMember returns true if one of the hosts is the host of a member of the cluster, as known to
+// this Alpha.
+func IsMember(hosts []string) bool {
+	g := groups()
+	if g == nil {
+		return false
+	}
+	g.RLock()
+	defer g.RUnlock()
+	return conn.IsMember(g.state, hosts)
+}
+
+// PredicateGroup returns the group serving the predicate attr, or zero if no group serves it.
+func PredicateGroup(attr string) (uint32, error) {
+	return groups().BelongsToReadOnly(attr)
+}
+
 func UpdateMembershipState(ctx context.Context) error {
 	g := groups()
 	p := g.Leader(0)
@@ -426,8 +452,9 @@ func (g *groupi) HasMeInState() bool {
 	return has
 }
 
-// Returns 0, 1, or 2 valid server addrs.
-func (g *groupi) AnyTwoServers(gid uint32) []string {
+// Returns 0, 1, or 2 valid server addrs. Learners are only returned if learners is true, in which
+// case they come first so that they take the load off the voters.
+func (g *groupi) AnyTwoServers(gid uint32, learners bool) []string {
 	g.RLock()
 	defer g.RUnlock()
 
@@ -438,14 +465,19 @@ func (g *groupi) AnyTwoServers(gid uint32) []string {
 	if !has {
 		return []string{}
 	}
-	var res []string
+	var res, voters []string
 	for _, m := range group.Members {
 		// map iteration gives us members in no particular order.
-		res = append(res, m.Addr)
-		if len(res) >= 2 {
-			break
+		if !m.Learner {
+			voters = append(voters, m.Addr)
+		} else if learners && len(res) < 2 {
+			res = append(res, m.Addr)
 		}
 	}
+	res = append(res, voters...)
+	if len(res) > 2 {
+		res = res[:2]
+	}
 	return res
 }
 
@@ -588,6 +620,7 @@ func (g *groupi) doSendMembership(tablets map[string]*pb.Tablet) error {
 		Addr:       x.WorkerConfig.MyAddr,
 		Leader:     leader,
 		LastUpdate: uint64(time.Now().Unix()),
+		Learner:    x.WorkerConfig.Learner,
 	}
 	group := &pb.Group{
 		Members: make(map[uint64]*pb.Member),
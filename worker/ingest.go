@@ -0,0 +1,559 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/badger"
+	bpb "github.com/dgraph-io/badger/pb"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+/*
+The bulk loader can ingest its output into the groups of a running cluster, in replace mode: the
+data of the predicates it loaded is replaced, not merged. The ingestion is fenced like a predicate
+move, and committed like a transaction:
+
+• Zero blocks the commits on the predicates, and leases the timestamps ts, ts+1 and ts+2.
+• The bulk loader streams each predicate to the leader of the group serving it, which proposes
+  the start of the ingestion. Every replica then deletes the existing keys of the predicate at ts,
+  and serves the reads above it at ts-1, the version before the ingestion.
+• The leader proposes the streamed keys, which are written at ts+1, and then that they are done.
+• Once all the predicates are streamed, Zero checks that every group is done, and commits the
+  ingestion as the transaction starting at ts, or aborts it. Every group gets the decision through
+  the Oracle delta stream, at the same point of the stream. If it is committed at commitTs, the new
+  data shows up for the reads at or above commitTs in all the groups. If it is aborted, the old
+  keys are written back at ts+2, and show up for the reads at or above it. The reads below keep
+  being served at ts-1.
+
+The versions written by the ingestion hide the ones below them, so the lists of the predicate are
+read at the timestamp of the reads until its fence is dropped, instead of at their latest version.
+The lists are rolled up below ts, or at or above the end of the fence once the ingestion is
+committed or aborted, and the fence is dropped once they are rolled up past its end, as no read
+can be served below it anymore. Snapshots are taken before the start of the ingestion, or after
+its end, so that its fence is restored when the Raft log is replayed.
+*/
+
+// ingestTimeout is how long the leader waits for Zero to commit or abort the ingestion of a
+// predicate while it receives no more data to ingest, before asking Zero to abort it.
+const ingestTimeout = 20 * time.Minute
+
+type ingestFence struct {
+	ts uint64
+	// The Raft index of the proposal starting the ingestion.
+	index uint64
+	// Whether all the keys of the predicate have been written.
+	done bool
+	// Once the ingestion is committed or aborted, the timestamp the reads are served at as usual
+	// from, and the Raft index of the proposal ending it.
+	endTs    uint64
+	endIndex uint64
+	// The schema of the predicate before the ingestion, restored if it is aborted.
+	schema []byte
+	// Asks Zero to abort the ingestion if it isn't finished in time. Only set on the leader.
+	timer *time.Timer
+}
+
+// fences returns whether the fence serves the reads at readTs at ts-1.
+func (f *ingestFence) fences(readTs uint64) bool {
+	return readTs >= f.ts && (f.endTs == 0 || readTs < f.endTs)
+}
+
+var ingests = struct {
+	sync.Mutex
+	// The fences of each predicate, in the order of their ingestions. Only the last one can be
+	// ongoing.
+	m map[string][]*ingestFence
+}{m: make(map[string][]*ingestFence)}
+
+// getIngest returns the fence of the last ingestion of the predicate.
+func getIngest(attr string) *ingestFence {
+	ingests.Lock()
+	defer ingests.Unlock()
+	fs := ingests.m[attr]
+	if len(fs) == 0 {
+		return nil
+	}
+	return fs[len(fs)-1]
+}
+
+// ingestRollupTs returns the highest timestamp up to readTs the lists can be rolled up at, while
+// keeping the versions read during the ingestions.
+func ingestRollupTs(readTs uint64) uint64 {
+	ingests.Lock()
+	defer ingests.Unlock()
+	for _, fs := range ingests.m {
+		for _, f := range fs {
+			if f.fences(readTs) {
+				readTs = f.ts - 1
+			}
+		}
+	}
+	return readTs
+}
+
+// dropIngests drops the fences ending at or below readTs, once the lists have been rolled up at
+// readTs.
+func dropIngests(readTs uint64) {
+	ingests.Lock()
+	defer ingests.Unlock()
+	for attr, fs := range ingests.m {
+		var kept []*ingestFence
+		for _, f := range fs {
+			if f.endTs > 0 && f.endTs <= readTs {
+				glog.Infof("Dropping fence of predicate %q ingested at %d", attr, f.ts)
+				continue
+			}
+			kept = append(kept, f)
+		}
+		if len(kept) == 0 {
+			delete(ingests.m, attr)
+		} else {
+			ingests.m[attr] = kept
+		}
+	}
+}
+
+// ingestSnapshotIndex returns the highest Raft index up to index a snapshot can be taken at, so
+// that the log is replayed from the start of the ingestions, unless it includes their end.
+func ingestSnapshotIndex(index uint64) uint64 {
+	ingests.Lock()
+	defer ingests.Unlock()
+	for _, fs := range ingests.m {
+		for _, f := range fs {
+			if index >= f.index && (f.endIndex == 0 || index < f.endIndex) {
+				index = f.index - 1
+			}
+		}
+	}
+	return index
+}
+
+// resetIngestTimers pushes back the abort of the ongoing ingestions, while data is received.
+func resetIngestTimers() {
+	ingests.Lock()
+	defer ingests.Unlock()
+	for _, fs := range ingests.m {
+		for _, f := range fs {
+			if f.timer != nil && f.endTs == 0 {
+				f.timer.Reset(ingestTimeout)
+			}
+		}
+	}
+}
+
+// ingestReadTs returns the timestamp to read the predicate at. While it's being ingested, the
+// reads at or above the ingestion timestamp are served at the version before it, until the
+// ingestion is committed or aborted.
+func ingestReadTs(attr string, readTs uint64) uint64 {
+	ingests.Lock()
+	defer ingests.Unlock()
+	for _, f := range ingests.m[attr] {
+		if f.fences(readTs) {
+			return f.ts - 1
+		}
+	}
+	return readTs
+}
+
+// ingestListTs returns the timestamp to read the lists of the predicate at, given the timestamp
+// returned by ingestReadTs. That's readTs while the predicate has ingestion fences, as the
+// versions written by the ingestions hide the ones below them, and the latest version otherwise.
+func ingestListTs(attr string, readTs uint64) uint64 {
+	ingests.Lock()
+	defer ingests.Unlock()
+	if len(ingests.m[attr]) > 0 {
+		return readTs
+	}
+	return math.MaxUint64
+}
+
+func applyIngest(ctx context.Context, proposal *pb.Proposal) error {
+	in := proposal.Ingest
+	switch in.Op {
+	case pb.IngestPredicate_START:
+		return startIngest(in, proposal.Index)
+	case pb.IngestPredicate_DATA:
+		if f := getIngest(in.Predicate); f == nil || f.ts != in.Ts || f.endTs > 0 {
+			return x.Errorf("Ingestion of predicate %q at %d isn't ongoing", in.Predicate, in.Ts)
+		}
+		writer := posting.NewTxnWriter(pstore)
+		if err := writer.Send(&pb.KVS{Kv: proposal.Kv}); err != nil {
+			return err
+		}
+		return writer.Flush()
+	case pb.IngestPredicate_DONE:
+		if f := getIngest(in.Predicate); f != nil && f.ts == in.Ts {
+			ingests.Lock()
+			f.done = true
+			ingests.Unlock()
+		}
+		return nil
+	case pb.IngestPredicate_ABORT:
+		f := getIngest(in.Predicate)
+		if f == nil || f.ts != in.Ts || f.done || f.endTs > 0 {
+			// Already finished, like when the proposal is replayed, or left for Zero to finish.
+			return nil
+		}
+		return abortIngest(in.Predicate, f, proposal.Index)
+	}
+	return x.Errorf("Unknown ingestion op: %v", in.Op)
+}
+
+func startIngest(in *pb.IngestPredicate, index uint64) error {
+	if f := getIngest(in.Predicate); f != nil {
+		if f.ts == in.Ts {
+			return nil
+		}
+		if f.endTs == 0 {
+			return x.Errorf("Predicate %q is already being ingested at %d", in.Predicate, f.ts)
+		}
+	}
+	glog.Infof("Starting ingestion of predicate %q at %d", in.Predicate, in.Ts)
+
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	f := &ingestFence{ts: in.Ts, index: index}
+	item, err := txn.Get(x.SchemaKey(in.Predicate))
+	switch {
+	case err == badger.ErrKeyNotFound:
+	case err != nil:
+		return err
+	default:
+		if f.schema, err = item.ValueCopy(nil); err != nil {
+			return err
+		}
+	}
+
+	// Delete all the existing keys of the predicate at ts.
+	writer := posting.NewTxnWriter(pstore)
+	err = forEachKey(txn, in.Predicate, func(key []byte) error {
+		return writer.SetAt(key, nil, posting.BitEmptyPosting, in.Ts)
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	ingests.Lock()
+	ingests.m[in.Predicate] = append(ingests.m[in.Predicate], f)
+	ingests.Unlock()
+	return nil
+}
+
+// forEachKey calls fn with a copy of each key of the predicate, except the schema key.
+func forEachKey(txn *badger.Txn, attr string, fn func(key []byte) error) error {
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.PrefetchValues = false
+	prefix := x.PredicatePrefix(attr)
+	itr := txn.NewIterator(iterOpts)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		if err := fn(itr.Item().KeyCopy(nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyIngestStatus commits or aborts the ingestion starting at the start timestamp of the
+// transaction status, as decided by Zero. It's called for each status of the Oracle deltas, before
+// the reads at their timestamps are served.
+func applyIngestStatus(status *pb.TxnStatus, index uint64) error {
+	ingests.Lock()
+	ongoing := make(map[string]*ingestFence)
+	for attr, fs := range ingests.m {
+		if f := fs[len(fs)-1]; f.ts == status.StartTs && f.endTs == 0 {
+			ongoing[attr] = f
+		}
+	}
+	ingests.Unlock()
+
+	for attr, f := range ongoing {
+		if status.CommitTs == 0 {
+			if err := abortIngest(attr, f, index); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.done {
+			glog.Errorf("Ingestion of predicate %q at %d committed before all its keys were "+
+				"written", attr, f.ts)
+		}
+		glog.Infof("Ingestion of predicate %q at %d committed at %d", attr, f.ts, status.CommitTs)
+		if err := schema.Load(attr); err != nil {
+			return err
+		}
+		f.end(status.CommitTs, index)
+	}
+	return nil
+}
+
+// abortIngest restores the data of the predicate as it was before the ingestion, which shows up
+// at ts+2.
+func abortIngest(attr string, f *ingestFence, index uint64) error {
+	glog.Warningf("Aborting ingestion of predicate %q at %d", attr, f.ts)
+	if err := rollbackIngest(attr, f); err != nil {
+		return err
+	}
+	f.end(f.ts+2, index)
+	return nil
+}
+
+// end ends the fence once the ingestion is committed or aborted.
+func (f *ingestFence) end(ts, index uint64) {
+	ingests.Lock()
+	defer ingests.Unlock()
+	f.endTs, f.endIndex = ts, index
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+}
+
+// rollbackIngest writes back the keys of the predicate as they were before the ingestion, at
+// ts+2, along with its schema.
+func rollbackIngest(attr string, f *ingestFence) error {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	oldTxn := pstore.NewTransactionAt(f.ts-1, false)
+	defer oldTxn.Discard()
+
+	writer := posting.NewTxnWriter(pstore)
+	err := forEachKey(txn, attr, func(key []byte) error {
+		if pk := x.Parse(key); pk == nil || pk.StartUid > 0 {
+			// The parts of split lists are written back along with their main key.
+			return nil
+		}
+		iterOpts := badger.DefaultIteratorOptions
+		iterOpts.AllVersions = true
+		iterOpts.PrefetchValues = false
+		itr := oldTxn.NewKeyIterator(key, iterOpts)
+		defer itr.Close()
+		itr.Seek(key)
+		l, err := posting.ReadPostingList(key, itr)
+		if err != nil {
+			return err
+		}
+		kvs, err := l.Rollup()
+		if err != nil {
+			return err
+		}
+		for _, kv := range kvs {
+			kv.Version = f.ts + 2
+		}
+		return writer.Send(&pb.KVS{Kv: kvs})
+	})
+	if err != nil {
+		return err
+	}
+
+	schemaKey := x.SchemaKey(attr)
+	if f.schema != nil {
+		err = writer.SetAt(schemaKey, f.schema, posting.BitSchemaPosting, 1)
+	} else {
+		err = writer.Delete(schemaKey, 1)
+	}
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if f.schema == nil {
+		return schema.State().Delete(attr)
+	}
+	return schema.Load(attr)
+}
+
+// proposeIngest proposes the given op of the ingestion of the predicate.
+func (n *node) proposeIngest(ctx context.Context, attr string, ts uint64,
+	op pb.IngestPredicate_Op) error {
+	return n.proposeAndWait(ctx, &pb.Proposal{
+		Ingest: &pb.IngestPredicate{Predicate: attr, Ts: ts, Op: op},
+	})
+}
+
+// watchIngests asks Zero to abort the ongoing ingestions if they aren't finished in time, when
+// this node becomes the leader.
+func (n *node) watchIngests() {
+	ingests.Lock()
+	defer ingests.Unlock()
+	for attr, fs := range ingests.m {
+		if f := fs[len(fs)-1]; f.endTs == 0 && f.timer == nil {
+			f.timer = n.ingestTimer(attr, f.ts)
+		}
+	}
+}
+
+// ingestTimer returns a timer asking Zero to abort the ingestion of the predicate once it fires.
+// Zero aborts it unless it has been committed, and the groups get the decision like any other.
+func (n *node) ingestTimer(attr string, ts uint64) *time.Timer {
+	return time.AfterFunc(ingestTimeout, func() {
+		glog.Warningf("Ingestion of predicate %q wasn't finished in %s", attr, ingestTimeout)
+		if err := n.blockingAbort(&pb.TxnTimestamps{Ts: []uint64{ts}}); err != nil {
+			glog.Errorf("While aborting ingestion of predicate %q: %v", attr, err)
+		}
+	})
+}
+
+// IngestPredicate receives the keys of a predicate from the bulk loader, and proposes them to
+// the group. The first message holds the predicate and the ingestion timestamp.
+func (w *grpcWorker) IngestPredicate(stream pb.Worker_IngestPredicateServer) error {
+	n := groups().Node
+	if !n.AmLeader() {
+		return errNotLeader
+	}
+	ctx := stream.Context()
+	kvs, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	in := kvs.GetIngest()
+	if in == nil || len(in.Predicate) == 0 || in.Ts == 0 {
+		return x.Errorf("The first message of the stream must set the predicate and timestamp")
+	}
+	attr, ts := in.Predicate, in.Ts
+	if gid, err := groups().BelongsTo(attr); err != nil {
+		return err
+	} else if gid != groups().groupId() {
+		return errUnservedTablet
+	}
+	// Commits below ts must be applied before the existing keys are deleted.
+	if err := posting.Oracle().WaitForTs(ctx, ts); err != nil {
+		return x.Errorf("While waiting for txn ts: %d. Error: %v", ts, err)
+	}
+
+	// No deletion would start while the predicate is streamed.
+	mu := groups().blockDeletes
+	mu.Lock()
+	defer mu.Unlock()
+
+	glog.Infof("Receiving predicate %q to ingest at %d", attr, ts)
+	if err := n.proposeIngest(ctx, attr, ts, pb.IngestPredicate_START); err != nil {
+		return err
+	}
+	count, err := n.proposeIngestedKeys(stream, kvs, attr, ts)
+	if err != nil {
+		glog.Errorf("While receiving predicate %q to ingest: %v", attr, err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if aerr := n.proposeIngest(ctx, attr, ts, pb.IngestPredicate_ABORT); aerr != nil {
+			glog.Errorf("While aborting ingestion of predicate %q: %v", attr, aerr)
+		}
+		return err
+	}
+	if err := n.proposeIngest(ctx, attr, ts, pb.IngestPredicate_DONE); err != nil {
+		return err
+	}
+	glog.Infof("Proposed %d keys of predicate %q to ingest", count, attr)
+
+	resetIngestTimers()
+	if f := getIngest(attr); f != nil && f.ts == ts {
+		ingests.Lock()
+		if f.timer == nil && f.endTs == 0 {
+			f.timer = n.ingestTimer(attr, ts)
+		}
+		ingests.Unlock()
+	}
+	return stream.SendAndClose(&api.Payload{Data: []byte(fmt.Sprintf("%d", count))})
+}
+
+// proposeIngestedKeys proposes the keys received in batches, and returns their count.
+func (n *node) proposeIngestedKeys(stream pb.Worker_IngestPredicateServer, kvs *pb.KVS,
+	attr string, ts uint64) (int, error) {
+	ctx := stream.Context()
+	var batch []*bpb.KV
+	var count, size int
+	propose := func() error {
+		p := &pb.Proposal{
+			Kv:     batch,
+			Ingest: &pb.IngestPredicate{Predicate: attr, Ts: ts, Op: pb.IngestPredicate_DATA},
+		}
+		batch, size = nil, 0
+		resetIngestTimers()
+		return n.proposeAndWait(ctx, p)
+	}
+
+	for {
+		for _, kv := range kvs.Kv {
+			pk := x.Parse(kv.Key)
+			if pk == nil || pk.Attr != attr {
+				return count, x.Errorf("Key %q doesn't belong to predicate %q", kv.Key, attr)
+			}
+			if pk.IsSchema() {
+				kv.Version = 1
+				kv.UserMeta = []byte{posting.BitSchemaPosting}
+			} else {
+				kv.Version = ts + 1
+			}
+			batch = append(batch, kv)
+			size += len(kv.Key) + len(kv.Value)
+			if size >= 32<<20 { // 32 MB
+				if err := propose(); err != nil {
+					return count, err
+				}
+			}
+		}
+		count += len(kvs.Kv)
+
+		var err error
+		kvs, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+	if size > 0 {
+		if err := propose(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// PrepareIngest checks that all the keys of the predicates have been written, before Zero commits
+// their ingestion.
+func (w *grpcWorker) PrepareIngest(ctx context.Context, in *pb.IngestRequest) (*api.Payload,
+	error) {
+	n := groups().Node
+	if !n.AmLeader() {
+		return &emptyPayload, errNotLeader
+	}
+	for _, attr := range in.Predicates {
+		if f := getIngest(attr); f == nil || f.ts != in.Ts || !f.done {
+			return &emptyPayload, x.Errorf("Ingestion of predicate %q at %d isn't done",
+				attr, in.Ts)
+		}
+	}
+	return &emptyPayload, nil
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2019 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	bpb "github.com/dgraph-io/badger/pb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func ingestProposal(attr string, ts uint64, op pb.IngestPredicate_Op,
+	kvs ...*bpb.KV) *pb.Proposal {
+	return &pb.Proposal{Kv: kvs, Ingest: &pb.IngestPredicate{Predicate: attr, Ts: ts, Op: op}}
+}
+
+// ingestedUids returns the uids in the posting list of the key at readTs.
+func ingestedUids(t *testing.T, key []byte, readTs uint64) []uint64 {
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.AllVersions = true
+	itr := txn.NewKeyIterator(key, iterOpts)
+	defer itr.Close()
+	itr.Seek(key)
+	l, err := posting.ReadPostingList(key, itr)
+	require.NoError(t, err)
+	uids, err := l.Uids(posting.ListOptions{ReadTs: readTs})
+	require.NoError(t, err)
+	return uids.Uids
+}
+
+func uidsKV(t *testing.T, key []byte, ts uint64, uids ...uint64) *bpb.KV {
+	pl := &pb.PostingList{}
+	for _, uid := range uids {
+		pl.Postings = append(pl.Postings, &pb.Posting{Uid: uid})
+	}
+	val, err := pl.Marshal()
+	require.NoError(t, err)
+	return &bpb.KV{Key: key, Value: val, UserMeta: []byte{posting.BitDeltaPosting}, Version: ts}
+}
+
+func setUids(t *testing.T, key []byte, ts uint64, uids ...uint64) {
+	writer := posting.NewTxnWriter(pstore)
+	require.NoError(t, writer.Send(&pb.KVS{Kv: []*bpb.KV{uidsKV(t, key, ts, uids...)}}))
+	require.NoError(t, writer.Flush())
+}
+
+func TestIngestAbort(t *testing.T) {
+	attr := "ingest_abort"
+	oldKey, newKey := x.DataKey(attr, 1), x.DataKey(attr, 2)
+	setUids(t, oldKey, 5, 10, 11)
+	ctx := context.Background()
+
+	start := ingestProposal(attr, 20, pb.IngestPredicate_START)
+	start.Index = 7
+	require.NoError(t, applyIngest(ctx, start))
+	require.Equal(t, uint64(6), ingestSnapshotIndex(10))
+	require.Equal(t, uint64(19), ingestRollupTs(25))
+	require.Empty(t, ingestedUids(t, oldKey, 20))
+
+	kv := uidsKV(t, newKey, 21, 12)
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 20, pb.IngestPredicate_DATA, kv)))
+	require.Equal(t, []uint64{12}, ingestedUids(t, newKey, 21))
+
+	// The leader aborts the ingestion itself if it fails before all the keys are written. The
+	// old keys show up at ts+2.
+	abort := ingestProposal(attr, 20, pb.IngestPredicate_ABORT)
+	abort.Index = 9
+	require.NoError(t, applyIngest(ctx, abort))
+	require.Equal(t, uint64(19), ingestReadTs(attr, 21))
+	require.Equal(t, uint64(22), ingestReadTs(attr, 22))
+	require.Equal(t, uint64(6), ingestSnapshotIndex(8))
+	require.Equal(t, uint64(10), ingestSnapshotIndex(10))
+	require.Equal(t, uint64(22), ingestRollupTs(22))
+	require.Equal(t, []uint64{10, 11}, ingestedUids(t, oldKey, 22))
+	require.Empty(t, ingestedUids(t, newKey, 22))
+
+	// Replayed proposals of a finished ingestion are ignored.
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 20, pb.IngestPredicate_DONE)))
+	require.Error(t, applyIngest(ctx, ingestProposal(attr, 20, pb.IngestPredicate_DATA, kv)))
+
+	// Once all the keys are written, only Zero can abort it.
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 24, pb.IngestPredicate_START)))
+	kv = uidsKV(t, newKey, 25, 13)
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 24, pb.IngestPredicate_DATA, kv)))
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 24, pb.IngestPredicate_DONE)))
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 24, pb.IngestPredicate_ABORT)))
+	require.Zero(t, getIngest(attr).endTs)
+
+	require.NoError(t, applyIngestStatus(&pb.TxnStatus{StartTs: 24}, 12))
+	require.Equal(t, uint64(26), getIngest(attr).endTs)
+	require.Equal(t, uint64(19), ingestReadTs(attr, 21))
+	require.Equal(t, uint64(23), ingestReadTs(attr, 25))
+	require.Equal(t, []uint64{10, 11}, ingestedUids(t, oldKey, 26))
+	require.Empty(t, ingestedUids(t, newKey, 26))
+
+	// The fences are dropped once the lists are rolled up past their end.
+	dropIngests(25)
+	require.Len(t, ingests.m[attr], 1)
+	dropIngests(26)
+	require.Nil(t, getIngest(attr))
+	require.Equal(t, uint64(math.MaxUint64), ingestListTs(attr, 26))
+}
+
+func TestIngestCommit(t *testing.T) {
+	attr := "ingest_commit"
+	oldKey, newKey := x.DataKey(attr, 1), x.DataKey(attr, 2)
+	setUids(t, oldKey, 5, 10)
+	ctx := context.Background()
+
+	start := ingestProposal(attr, 30, pb.IngestPredicate_START)
+	start.Index = 7
+	require.NoError(t, applyIngest(ctx, start))
+	sch := &pb.SchemaUpdate{ValueType: pb.Posting_UID, List: true}
+	val, err := sch.Marshal()
+	require.NoError(t, err)
+	kvs := []*bpb.KV{
+		{Key: x.SchemaKey(attr), Value: val, UserMeta: []byte{posting.BitSchemaPosting},
+			Version: 1},
+		uidsKV(t, newKey, 31, 12),
+	}
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 30, pb.IngestPredicate_DATA, kvs...)))
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 30, pb.IngestPredicate_DONE)))
+
+	// Reads above the ingestion timestamp are served at the version before it, until it's
+	// committed. The lists are read at the read timestamp meanwhile.
+	require.Equal(t, uint64(25), ingestReadTs(attr, 25))
+	require.Equal(t, uint64(29), ingestReadTs(attr, 30))
+	require.Equal(t, uint64(29), ingestReadTs(attr, 40))
+	require.Equal(t, uint64(40), ingestReadTs("other", 40))
+	require.Equal(t, uint64(29), ingestListTs(attr, 29))
+	require.Equal(t, uint64(math.MaxUint64), ingestListTs("other", 29))
+	require.Equal(t, []uint64{10}, ingestedUids(t, oldKey, ingestReadTs(attr, 40)))
+	require.Empty(t, ingestedUids(t, newKey, ingestReadTs(attr, 40)))
+	require.False(t, schema.State().IsList(attr))
+
+	// Then the new data shows up at the commit timestamp.
+	require.NoError(t, applyIngestStatus(&pb.TxnStatus{StartTs: 30, CommitTs: 35}, 9))
+	require.Equal(t, uint64(29), ingestReadTs(attr, 34))
+	require.Equal(t, uint64(35), ingestReadTs(attr, 35))
+	require.Empty(t, ingestedUids(t, oldKey, 35))
+	require.Equal(t, []uint64{12}, ingestedUids(t, newKey, 35))
+	require.True(t, schema.State().IsList(attr))
+
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	item, err := txn.Get(x.SchemaKey(attr))
+	require.NoError(t, err)
+	require.Equal(t, posting.BitSchemaPosting, item.UserMeta())
+
+	// A later abort of the same transaction is ignored.
+	require.NoError(t, applyIngestStatus(&pb.TxnStatus{StartTs: 30}, 10))
+	require.Equal(t, []uint64{12}, ingestedUids(t, newKey, 40))
+
+	// Snapshots and rollups happen before the ingestion, or after its commit.
+	require.Equal(t, uint64(6), ingestSnapshotIndex(8))
+	require.Equal(t, uint64(9), ingestSnapshotIndex(9))
+	require.Equal(t, uint64(29), ingestRollupTs(34))
+	require.Equal(t, uint64(35), ingestRollupTs(35))
+
+	// The next ingestion can start before the fence is dropped.
+	require.NoError(t, applyIngest(ctx, ingestProposal(attr, 40, pb.IngestPredicate_START)))
+	require.Error(t, applyIngest(ctx, ingestProposal(attr, 44, pb.IngestPredicate_START)))
+	require.Equal(t, uint64(29), ingestReadTs(attr, 34))
+	require.Equal(t, uint64(39), ingestReadTs(attr, 41))
+	require.NoError(t, applyIngestStatus(&pb.TxnStatus{StartTs: 40}, 11))
+
+	dropIngests(35)
+	require.Len(t, ingests.m[attr], 1)
+	dropIngests(42)
+	require.Nil(t, getIngest(attr))
+}
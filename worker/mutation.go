@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/dgraph-io/badger"
@@ -396,6 +397,22 @@ func Timestamps(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error) {
 	return c.Timestamps(ctx, num)
 }
 
+// TimestampAt returns the timestamp to read the data as it was at the given time, as logged by
+// Zero.
+func TimestampAt(ctx context.Context, at time.Time) (uint64, error) {
+	pl := groups().connToZeroLeader()
+	if pl == nil {
+		return 0, conn.ErrNoConnection
+	}
+
+	c := pb.NewZeroClient(pl.Get())
+	resp, err := c.TimestampAt(ctx, &pb.TimestampAt{At: at.UnixNano()})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Ts, nil
+}
+
 func fillTxnContext(tctx *api.TxnContext, startTs uint64) {
 	if txn := posting.Oracle().GetTxn(startTs); txn != nil {
 		txn.Fill(tctx, groups().groupId())
@@ -557,6 +574,374 @@ func MutateOverNetwork(ctx context.Context, m *pb.Mutations) (*api.TxnContext, e
 	return tctx, e
 }
 
+// CheckStrictTypes checks that the nodes of strict types still conform to them once the mutations
+// are applied. It runs before they are applied, so that a rejected mutation leaves nothing behind:
+// the nodes are read at the start ts of the transaction, which includes its earlier mutations, and
+// the edges of the mutations are laid over them. Each mutation must then leave the nodes valid on
+// its own. Besides the nodes touched by the mutations, it checks the nodes pointing to the ones
+// that lose a type, which are found through the reverse edges of the fields declared with it.
+//
+// It returns the conflict keys of the data it read, which must be added to the keys of the
+// transaction, so that concurrent transactions breaking a node together can't both commit.
+func CheckStrictTypes(ctx context.Context, m *pb.Mutations) ([]string, error) {
+	strict := make(map[string]pb.TypeUpdate)
+	var preds []string
+	for _, name := range schema.State().Types() {
+		if typ, ok := schema.State().GetType(name); ok && typ.Strict {
+			strict[name] = typ
+			for _, field := range typ.Fields {
+				preds = append(preds, field.Predicate)
+			}
+		}
+	}
+	if len(strict) == 0 {
+		return nil, nil
+	}
+
+	sc := &strictCheck{
+		edges:  make(map[string]map[uint64][]*pb.DirectedEdge),
+		schema: make(map[string]*api.SchemaNode),
+		keys:   make(map[string]struct{}),
+		readTs: m.StartTs,
+	}
+	var uids []uint64
+	for _, edge := range m.Edges {
+		if edge.Entity == 0 {
+			continue
+		}
+		if sc.edges[edge.Attr] == nil {
+			sc.edges[edge.Attr] = make(map[uint64][]*pb.DirectedEdge)
+		}
+		sc.edges[edge.Attr][edge.Entity] = append(sc.edges[edge.Attr][edge.Entity], edge)
+		uids = append(uids, edge.Entity)
+	}
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	uids = sortUnique(uids)
+
+	// The schema of the predicates is read from the groups serving them, since this Alpha only
+	// knows the schema of the predicates it serves.
+	nodes, err := GetSchemaOverNetwork(ctx, &pb.SchemaRequest{
+		Predicates: preds,
+		Fields:     []string{"type", "list", "reverse"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		sc.schema[node.Predicate] = node
+	}
+
+	before, after, err := sc.nodeTypes(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	lost := make(map[string][]uint64)
+	for _, uid := range uids {
+		for name := range before[uid] {
+			if _, ok := after[uid][name]; !ok {
+				lost[name] = append(lost[name], uid)
+			}
+		}
+	}
+	var sources []uint64
+	for name, typ := range strict {
+		for _, field := range typ.Fields {
+			targets, ok := lost[field.ObjectTypeName]
+			if !ok || field.ValueType != pb.Posting_OBJECT {
+				continue
+			}
+			pred := sc.schema[field.Predicate]
+			if pred == nil {
+				// The predicate isn't served by any group, so no node points to the targets.
+				continue
+			}
+			if !pred.Reverse {
+				return nil, x.Errorf("Field %s of strict type %s needs @reverse to find the "+
+					"nodes pointing to the ones losing type %s", field.Predicate, name,
+					field.ObjectTypeName)
+			}
+			res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+				Attr:    field.Predicate,
+				UidList: &pb.List{Uids: targets},
+				Reverse: true,
+				ReadTs:  sc.readTs,
+			})
+			if err != nil && err != errNonExistentTablet {
+				return nil, err
+			}
+			for _, list := range res.GetUidMatrix() {
+				sources = append(sources, list.Uids...)
+			}
+		}
+	}
+	if len(sources) > 0 {
+		uids = sortUnique(append(uids, sources...))
+		if _, after, err = sc.nodeTypes(ctx, uids); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, typ := range strict {
+		var nodes []uint64
+		for _, uid := range uids {
+			if _, ok := after[uid][name]; ok {
+				nodes = append(nodes, uid)
+			}
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+		for _, field := range typ.Fields {
+			if err := sc.checkField(ctx, name, field, nodes); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(sc.keys))
+	for key := range sc.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// strictCheck holds the edges of the mutations checked by CheckStrictTypes, by predicate and by
+// node, along with the schema of the predicates of the strict types and the conflict keys of the
+// data read.
+type strictCheck struct {
+	edges  map[string]map[uint64][]*pb.DirectedEdge
+	schema map[string]*api.SchemaNode
+	keys   map[string]struct{}
+	readTs uint64
+}
+
+// checkField checks that the given nodes of a strict type have a value for the field if it is
+// non-nullable, and that its uid edges point to nodes of the declared object type, once the
+// mutations are applied.
+func (sc *strictCheck) checkField(ctx context.Context, typeName string, field *pb.SchemaUpdate,
+	nodes []uint64) error {
+
+	required := (field.List && field.NonNullableList) || (!field.List && field.NonNullable)
+	isObject := field.ValueType == pb.Posting_OBJECT && len(field.ObjectTypeName) > 0
+	if !required && !isObject {
+		return nil
+	}
+
+	res, err := sc.read(ctx, field.Predicate, nodes)
+	if err != nil {
+		return err
+	}
+	isUid := field.ValueType == pb.Posting_OBJECT || field.ValueType == pb.Posting_UID
+	isList := sc.schema[field.Predicate].GetList()
+	typ, ok := types.TypeForName(sc.schema[field.Predicate].GetType())
+	if !ok {
+		typ = types.DefaultID
+	}
+	var targets []uint64
+	for i, uid := range nodes {
+		edges := sc.edges[field.Predicate][uid]
+		var numValues int
+		if isUid {
+			var pointsTo []uint64
+			if i < len(res.UidMatrix) {
+				pointsTo = res.UidMatrix[i].Uids
+			}
+			pointsTo = overlayUids(pointsTo, edges, isList)
+			numValues = len(pointsTo)
+			targets = append(targets, pointsTo...)
+		} else {
+			var vals []*pb.TaskValue
+			var langs []string
+			if i < len(res.ValueMatrix) {
+				vals = res.ValueMatrix[i].Values
+			}
+			if i < len(res.LangMatrix) {
+				langs = res.LangMatrix[i].Lang
+			}
+			numValues = overlayValues(vals, langs, edges, typ, isList)
+		}
+		if required && numValues == 0 {
+			return x.Errorf("Node %#x of strict type %s is missing non-nullable field %s",
+				uid, typeName, field.Predicate)
+		}
+	}
+	if !isObject || len(targets) == 0 {
+		return nil
+	}
+
+	targets = sortUnique(targets)
+	_, targetTypes, err := sc.nodeTypes(ctx, targets)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if _, ok := targetTypes[target][field.ObjectTypeName]; !ok {
+			return x.Errorf("Field %s of strict type %s points to node %#x, which is not of type %s",
+				field.Predicate, typeName, target, field.ObjectTypeName)
+		}
+	}
+	return nil
+}
+
+// nodeTypes returns the types of each of the given sorted nodes as of the read ts, and the ones
+// they have once the mutations are applied.
+func (sc *strictCheck) nodeTypes(ctx context.Context,
+	uids []uint64) (map[uint64]map[string]struct{}, map[uint64]map[string]struct{}, error) {
+
+	res, err := sc.read(ctx, "dgraph.type", uids)
+	if err != nil {
+		return nil, nil, err
+	}
+	before := make(map[uint64]map[string]struct{}, len(uids))
+	after := make(map[uint64]map[string]struct{}, len(uids))
+	for i, uid := range uids {
+		before[uid] = make(map[string]struct{})
+		types := make(map[string]struct{})
+		if i < len(res.ValueMatrix) {
+			for _, val := range res.ValueMatrix[i].Values {
+				if len(val.Val) > 0 {
+					before[uid][string(val.Val)] = struct{}{}
+					types[string(val.Val)] = struct{}{}
+				}
+			}
+		}
+		for _, edge := range sc.edges["dgraph.type"][uid] {
+			switch {
+			case edge.Op == pb.DirectedEdge_DEL && isStarAll(edge.Value):
+				types = make(map[string]struct{})
+			case edge.Op == pb.DirectedEdge_DEL:
+				delete(types, string(edge.Value))
+			default:
+				types[string(edge.Value)] = struct{}{}
+			}
+		}
+		after[uid] = types
+	}
+	return before, after, nil
+}
+
+// read reads all the values of the predicate, in every language, for the given sorted list of
+// nodes as of the read ts, and records the conflict keys of the read. A predicate that isn't
+// served by any group has no values.
+func (sc *strictCheck) read(ctx context.Context, attr string, uids []uint64) (*pb.Result, error) {
+	for _, uid := range uids {
+		sc.keys[posting.ReadConflictKey(x.DataKey(attr, uid))] = struct{}{}
+	}
+	res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:      attr,
+		UidList:   &pb.List{Uids: uids},
+		ExpandAll: true,
+		ReadTs:    sc.readTs,
+	})
+	if err == errNonExistentTablet {
+		return &pb.Result{}, nil
+	}
+	return res, err
+}
+
+// overlayUids returns the uids a node points to through a predicate once the edges are applied,
+// given the ones it points to before. A set edge replaces the uid unless the predicate is a list.
+func overlayUids(uids []uint64, edges []*pb.DirectedEdge, isList bool) []uint64 {
+	if len(edges) == 0 {
+		return uids
+	}
+	set := make(map[uint64]struct{}, len(uids))
+	for _, uid := range uids {
+		set[uid] = struct{}{}
+	}
+	for _, edge := range edges {
+		switch {
+		case edge.Op == pb.DirectedEdge_DEL && isStarAll(edge.Value):
+			set = make(map[uint64]struct{})
+		case edge.Op == pb.DirectedEdge_DEL:
+			delete(set, edge.ValueId)
+		case !isList:
+			set = map[uint64]struct{}{edge.ValueId: {}}
+		default:
+			set[edge.ValueId] = struct{}{}
+		}
+	}
+	out := make([]uint64, 0, len(set))
+	for uid := range set {
+		out = append(out, uid)
+	}
+	return out
+}
+
+// langValue is a value of a scalar predicate, in its stored form, along with its language.
+type langValue struct {
+	lang string
+	val  string
+}
+
+// overlayValues returns the number of values a node has for a scalar predicate of type typ once
+// the edges are applied, given the ones it has before and their languages. The values of the edges
+// are converted to the type of the predicate like they are when applied, so that they can be
+// compared with the stored ones. A set edge replaces the value of its language unless the
+// predicate is a list.
+func overlayValues(vals []*pb.TaskValue, langs []string, edges []*pb.DirectedEdge,
+	typ types.TypeID, isList bool) int {
+
+	set := make(map[langValue]struct{}, len(vals))
+	for i, val := range vals {
+		if len(val.Val) == 0 {
+			continue
+		}
+		var lang string
+		if i < len(langs) {
+			lang = langs[i]
+		}
+		set[langValue{lang: lang, val: string(val.Val)}] = struct{}{}
+	}
+	for _, edge := range edges {
+		if edge.Op == pb.DirectedEdge_DEL && isStarAll(edge.Value) {
+			set = make(map[langValue]struct{})
+			continue
+		}
+		key := langValue{lang: edge.Lang, val: string(storedValue(edge, typ))}
+		switch {
+		case edge.Op == pb.DirectedEdge_DEL:
+			delete(set, key)
+		case !isList:
+			for lv := range set {
+				if lv.lang == edge.Lang {
+					delete(set, lv)
+				}
+			}
+			set[key] = struct{}{}
+		default:
+			set[key] = struct{}{}
+		}
+	}
+	return len(set)
+}
+
+// storedValue returns the value of the edge as stored for a predicate of type typ. A value that
+// can't be converted is returned as is, since the mutation fails when applied anyway.
+func storedValue(edge *pb.DirectedEdge, typ types.TypeID) []byte {
+	converted := *edge
+	su := &pb.SchemaUpdate{ValueType: typ.Enum(), Lang: true}
+	if err := ValidateAndConvert(&converted, su); err != nil {
+		return edge.Value
+	}
+	return converted.Value
+}
+
+// sortUnique sorts the uids and removes the duplicates in place.
+func sortUnique(uids []uint64) []uint64 {
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	out := uids[:0]
+	for _, uid := range uids {
+		if len(out) > 0 && out[len(out)-1] == uid {
+			continue
+		}
+		out = append(out, uid)
+	}
+	return out
+}
+
 // CommitOverNetwork makes a proxy call to Zero to commit or abort a transaction.
 func CommitOverNetwork(ctx context.Context, tc *api.TxnContext) (uint64, error) {
 	ctx, span := otrace.StartSpan(ctx, "worker.CommitOverNetwork")
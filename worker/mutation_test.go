@@ -209,3 +209,36 @@ func TestCheckSchema(t *testing.T) {
 	err = checkSchema(result.Schemas[1])
 	require.NoError(t, err)
 }
+
+func TestOverlayValues(t *testing.T) {
+	stored := types.ValueForType(types.BinaryID)
+	require.NoError(t, types.Marshal(types.Val{Tid: types.IntID, Value: int64(5)}, &stored))
+	vals := []*pb.TaskValue{{Val: stored.Value.([]byte), ValType: types.IntID.Enum()}}
+	edge := func(op pb.DirectedEdge_Op, val string) *pb.DirectedEdge {
+		return &pb.DirectedEdge{Op: op, Value: []byte(val), ValueType: pb.Posting_DEFAULT}
+	}
+
+	// Deleting a value that isn't stored leaves the stored one.
+	require.Equal(t, 1, overlayValues(vals, []string{""},
+		[]*pb.DirectedEdge{edge(pb.DirectedEdge_DEL, "6")}, types.IntID, false))
+	// The deleted value is compared with the stored one once converted to the predicate's type.
+	require.Equal(t, 0, overlayValues(vals, []string{""},
+		[]*pb.DirectedEdge{edge(pb.DirectedEdge_DEL, "5")}, types.IntID, false))
+	require.Equal(t, 0, overlayValues(vals, []string{""},
+		[]*pb.DirectedEdge{edge(pb.DirectedEdge_DEL, "_STAR_ALL")}, types.IntID, false))
+	// A set replaces the value unless the predicate is a list.
+	sets := []*pb.DirectedEdge{edge(pb.DirectedEdge_SET, "6"), edge(pb.DirectedEdge_DEL, "5")}
+	require.Equal(t, 1, overlayValues(vals, []string{""}, sets, types.IntID, false))
+	require.Equal(t, 1, overlayValues(vals, []string{""}, sets, types.IntID, true))
+	require.Equal(t, 2, overlayValues(vals, []string{""}, sets[:1], types.IntID, true))
+	require.Equal(t, 1, overlayValues(vals, []string{""}, sets[:1], types.IntID, false))
+
+	// Values in other languages are kept apart.
+	strs := []*pb.TaskValue{{Val: []byte("Alice")}, {Val: []byte("Alicia")}}
+	del := &pb.DirectedEdge{Op: pb.DirectedEdge_DEL, Value: []byte("Alice"), Lang: "es"}
+	require.Equal(t, 2, overlayValues(strs, []string{"", "es"},
+		[]*pb.DirectedEdge{del}, types.StringID, false))
+	del.Value = []byte("Alicia")
+	require.Equal(t, 1, overlayValues(strs, []string{"", "es"},
+		[]*pb.DirectedEdge{del}, types.StringID, false))
+}
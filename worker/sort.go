@@ -390,11 +390,22 @@ func processSort(ctx context.Context, ts *pb.SortMessage) (*pb.SortResult, error
 	if err := posting.Oracle().WaitForTs(ctx, ts.ReadTs); err != nil {
 		return nil, err
 	}
+	if err := posting.Oracle().CheckReadTs(ts.ReadTs); err != nil {
+		return nil, err
+	}
 	span.Annotatef(nil, "Waiting for checksum match")
 	if err := groups().ChecksumsMatch(ctx); err != nil {
 		return nil, err
 	}
 	span.Annotate(nil, "Done waiting")
+	// The first order is read here, at the version before its ingestion if it's being ingested.
+	// The others are fetched by processTask, which does the same for each of them.
+	sorted := ts
+	if readTs := ingestReadTs(ts.Order[0].Attr, ts.ReadTs); readTs != ts.ReadTs {
+		sm := *ts
+		sm.ReadTs = readTs
+		sorted = &sm
+	}
 
 	if ts.Count < 0 {
 		return nil, x.Errorf("We do not yet support negative or infinite count with sorting: %s %d. "+
@@ -415,12 +426,12 @@ func processSort(ctx context.Context, ts *pb.SortMessage) (*pb.SortResult, error
 			resCh <- &sortresult{err: ctx.Err()}
 			return
 		}
-		r := sortWithoutIndex(cctx, ts)
+		r := sortWithoutIndex(cctx, sorted)
 		resCh <- r
 	}()
 
 	go func() {
-		sr := sortWithIndex(cctx, ts)
+		sr := sortWithIndex(cctx, sorted)
 		resCh <- sr
 	}()
 
@@ -497,7 +508,7 @@ func intersectBucket(ctx context.Context, ts *pb.SortMessage, token string,
 
 	key := x.IndexKey(order.Attr, token)
 	// Don't put the Index keys in memory.
-	pl, err := posting.GetNoStore(key)
+	pl, err := posting.GetNoStoreAt(key, ingestListTs(order.Attr, ts.ReadTs))
 	if err != nil {
 		return err
 	}
@@ -659,7 +670,7 @@ func sortByValue(ctx context.Context, ts *pb.SortMessage, ul *pb.List,
 func fetchValue(uid uint64, attr string, langs []string, scalar types.TypeID,
 	readTs uint64) (types.Val, error) {
 	// Don't put the values in memory
-	pl, err := posting.GetNoStore(x.DataKey(attr, uid))
+	pl, err := posting.GetNoStoreAt(x.DataKey(attr, uid), ingestListTs(attr, readTs))
 	if err != nil {
 		return types.Val{}, err
 	}
@@ -29,13 +29,14 @@ import (
 type matchFn func(types.Val, stringFilter) bool
 
 type stringFilter struct {
-	funcName  string
-	funcType  FuncType
-	lang      string
-	tokens    []string
-	match     matchFn
-	ineqValue types.Val
-	eqVals    []types.Val
+	funcName    string
+	funcType    FuncType
+	lang        string
+	tokens      []string
+	match       matchFn
+	ineqValue   types.Val
+	ineqValueHi types.Val
+	eqVals      []types.Val
 }
 
 func matchStrings(uids *pb.List, values [][]types.Val, filter stringFilter) *pb.List {
@@ -79,6 +80,9 @@ func defaultMatch(value types.Val, filter stringFilter) bool {
 }
 
 func ineqMatch(value types.Val, filter stringFilter) bool {
+	if filter.funcName == between {
+		return types.CompareBetween(value, filter.ineqValue, filter.ineqValueHi)
+	}
 	if len(filter.eqVals) == 0 {
 		return types.CompareVals(filter.funcName, value, filter.ineqValue)
 	}
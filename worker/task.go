@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -69,12 +70,25 @@ func invokeNetworkRequest(ctx context.Context, addr string,
 
 const backupRequestGracePeriod = time.Second
 
+type learnerReadsKey struct{}
+
+// WithLearnerReads returns a context under which the query tasks can be served by the learner
+// replicas of a group, which might lag behind the voters. Only read-only queries should use it.
+func WithLearnerReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, learnerReadsKey{}, true)
+}
+
+func learnerReads(ctx context.Context) bool {
+	ok, _ := ctx.Value(learnerReadsKey{}).(bool)
+	return ok
+}
+
 // TODO: Cross-server cancellation as described in Jeff Dean's talk.
 func processWithBackupRequest(
 	ctx context.Context,
 	gid uint32,
 	f func(context.Context, pb.WorkerClient) (interface{}, error)) (interface{}, error) {
-	addrs := groups().AnyTwoServers(gid)
+	addrs := groups().AnyTwoServers(gid, learnerReads(ctx))
 	if len(addrs) == 0 {
 		return nil, errors.New("No network connection")
 	}
@@ -136,6 +150,9 @@ func processWithBackupRequest(
 // the instance which stores posting list corresponding to the predicate in the
 // query.
 func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error) {
+	if ctx.Err() != nil {
+		return &emptyResult, ctx.Err()
+	}
 	attr := q.Attr
 	gid, err := groups().BelongsToReadOnly(attr)
 	if err != nil {
@@ -248,7 +265,7 @@ func parseFuncTypeHelper(name string) (FuncType, string) {
 	}
 	f := strings.ToLower(name)
 	switch f {
-	case "le", "ge", "lt", "gt", "eq":
+	case "le", "ge", "lt", "gt", "eq", "between":
 		return CompareAttrFn, f
 	case "min", "max", "sum", "avg":
 		return AggregatorFn, f
@@ -282,6 +299,17 @@ func needsIndex(fnType FuncType) bool {
 	return false
 }
 
+// FuncUsesIndex returns true if the function is evaluated by reading the index posting lists of
+// its predicate, like in handleUidPostings.
+func FuncUsesIndex(srcFunc *pb.SrcFunction) bool {
+	fnType, _ := parseFuncType(srcFunc)
+	switch fnType {
+	case GeoFn, RegexFn, FullTextSearchFn, StandardFn, CustomIndexFn, MatchFn, CompareAttrFn:
+		return true
+	}
+	return false
+}
+
 // needsIntersect checks if the function type needs algo.IntersectSorted() after the results
 // are collected. This is needed for functions that require all values to  match, like
 // "allofterms", "alloftext", and custom functions with "allof".
@@ -429,7 +457,7 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 					if val, err = types.Convert(val, srcFn.atype); err != nil {
 						return err
 					}
-					if types.CompareVals(srcFn.fname, val, srcFn.ineqValue) {
+					if srcFn.compareVal(val, srcFn.ineqValue) {
 						uidList.Uids = append(uidList.Uids, q.UidList.Uids[i])
 						break
 					}
@@ -720,6 +748,9 @@ func processTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, erro
 	if err := posting.Oracle().WaitForTs(ctx, q.ReadTs); err != nil {
 		return &emptyResult, err
 	}
+	if err := posting.Oracle().CheckReadTs(q.ReadTs); err != nil {
+		return &emptyResult, err
+	}
 	if span != nil {
 		maxAssigned := posting.Oracle().MaxAssigned()
 		span.Annotatef(nil, "Done waiting for maxAssigned. Attr: %q ReadTs: %d Max: %d",
@@ -743,9 +774,18 @@ func processTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, erro
 	} else if gid != groups().groupId() {
 		return &emptyResult, errUnservedTablet
 	}
+	if readTs := ingestReadTs(q.Attr, q.ReadTs); readTs != q.ReadTs {
+		// The predicate is being ingested. Copy the query, it's owned by the caller.
+		qc := *q
+		qc.ReadTs = readTs
+		q = &qc
+	}
 
 	var qs queryState
-	if q.Cache == UseTxnCache {
+	if listTs := ingestListTs(q.Attr, q.ReadTs); listTs != math.MaxUint64 {
+		// The lists of the txn cache are read at their latest version.
+		qs.cache = posting.NewLocalCacheAt(listTs)
+	} else if q.Cache == UseTxnCache {
 		qs.cache = posting.Oracle().CacheAt(q.ReadTs)
 	}
 	if qs.cache == nil {
@@ -1043,19 +1083,33 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 		}
 
 		x.AssertTrue(len(arg.out.UidMatrix) > 0)
-		rowsToFilter := 0
-		if arg.srcFn.fname == eq {
+		var rowsToFilter []int
+		switch {
+		case arg.srcFn.fname == eq:
 			// If fn is eq, we could have multiple arguments and hence multiple rows
 			// to filter.
-			rowsToFilter = len(arg.srcFn.tokens)
-		} else if arg.srcFn.tokens[0] == arg.srcFn.ineqValueToken {
+			for row := range arg.srcFn.tokens {
+				rowsToFilter = append(rowsToFilter, row)
+			}
+		case arg.srcFn.fname == between:
+			// For between, only the buckets holding the bounds can contain values
+			// outside of the range.
+			last := len(arg.srcFn.tokens) - 1
+			if arg.srcFn.tokens[0] == arg.srcFn.ineqValueToken {
+				rowsToFilter = append(rowsToFilter, 0)
+			}
+			if last > 0 && arg.srcFn.tokens[last] == arg.srcFn.ineqValueHiToken {
+				rowsToFilter = append(rowsToFilter, last)
+			}
+		case arg.srcFn.tokens[0] == arg.srcFn.ineqValueToken:
 			// If operation is not eq and ineqValueToken equals first token,
 			// then we need to filter first row..
-			rowsToFilter = 1
+			rowsToFilter = append(rowsToFilter, 0)
 		}
 		isList := schema.State().IsList(attr)
 		lang := langForFunc(arg.q.Langs)
-		for row := 0; row < rowsToFilter; row++ {
+		listTs := ingestListTs(attr, arg.q.ReadTs)
+		for _, row := range rowsToFilter {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -1066,7 +1120,7 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 				switch lang {
 				case "":
 					if isList {
-						pl, err := posting.GetNoStore(x.DataKey(attr, uid))
+						pl, err := posting.GetNoStoreAt(x.DataKey(attr, uid), listTs)
 						if err != nil {
 							filterErr = err
 							return false
@@ -1080,7 +1134,7 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 						}
 						for _, sv := range svs {
 							dst, err := types.Convert(sv, typ)
-							if err == nil && types.CompareVals(arg.q.SrcFunc.Name, dst, arg.srcFn.eqTokens[row]) {
+							if err == nil && arg.srcFn.compareRow(dst, row) {
 								return true
 							}
 						}
@@ -1088,7 +1142,7 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 						return false
 					}
 
-					pl, err := posting.GetNoStore(x.DataKey(attr, uid))
+					pl, err := posting.GetNoStoreAt(x.DataKey(attr, uid), listTs)
 					if err != nil {
 						filterErr = err
 						return false
@@ -1102,9 +1156,9 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 					}
 					dst, err := types.Convert(sv, typ)
 					return err == nil &&
-						types.CompareVals(arg.q.SrcFunc.Name, dst, arg.srcFn.eqTokens[row])
+						arg.srcFn.compareRow(dst, row)
 				case ".":
-					pl, err := posting.GetNoStore(x.DataKey(attr, uid))
+					pl, err := posting.GetNoStoreAt(x.DataKey(attr, uid), listTs)
 					if err != nil {
 						filterErr = err
 						return false
@@ -1117,7 +1171,7 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 					for _, sv := range values {
 						dst, err := types.Convert(sv, typ)
 						if err == nil &&
-							types.CompareVals(arg.q.SrcFunc.Name, dst, arg.srcFn.eqTokens[row]) {
+							arg.srcFn.compareRow(dst, row) {
 							return true
 						}
 					}
@@ -1133,7 +1187,7 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 					if sv.Value == nil {
 						return false
 					}
-					return types.CompareVals(arg.q.SrcFunc.Name, sv, arg.srcFn.eqTokens[row])
+					return arg.srcFn.compareRow(sv, row)
 				}
 			})
 			if filterErr != nil {
@@ -1358,6 +1412,7 @@ func (qs *queryState) filterStringFunction(arg funcArgs) error {
 		filtered = matchStrings(filtered, values, filter)
 	case CompareAttrFn:
 		filter.ineqValue = arg.srcFn.ineqValue
+		filter.ineqValueHi = arg.srcFn.ineqValueHi
 		filter.eqVals = arg.srcFn.eqTokens
 		filter.match = ineqMatch
 		filtered = matchStrings(filtered, values, filter)
@@ -1374,25 +1429,46 @@ func matchRegex(value types.Val, regex *cregexp.Regexp) bool {
 }
 
 type functionContext struct {
-	tokens         []string
-	geoQuery       *types.GeoQueryData
-	intersectDest  bool
-	ineqValue      types.Val
-	eqTokens       []types.Val
-	ineqValueToken string
-	n              int
-	threshold      int64
-	uidPresent     uint64
-	fname          string
-	fnType         FuncType
-	regex          *cregexp.Regexp
-	isFuncAtRoot   bool
-	isStringFn     bool
-	atype          types.TypeID
+	tokens           []string
+	geoQuery         *types.GeoQueryData
+	intersectDest    bool
+	ineqValue        types.Val
+	eqTokens         []types.Val
+	ineqValueToken   string
+	ineqValueHi      types.Val // Upper bound for between.
+	ineqValueHiToken string
+	n                int
+	threshold        int64
+	uidPresent       uint64
+	fname            string
+	fnType           FuncType
+	regex            *cregexp.Regexp
+	isFuncAtRoot     bool
+	isStringFn       bool
+	atype            types.TypeID
+}
+
+// compareVal checks val against the comparison function. For between, val is
+// checked against both bounds and arg is ignored.
+func (fc *functionContext) compareVal(val, arg types.Val) bool {
+	if fc.fname == between {
+		return types.CompareBetween(val, fc.ineqValue, fc.ineqValueHi)
+	}
+	return types.CompareVals(fc.fname, val, arg)
+}
+
+// compareRow checks val against the comparison function, for the row of the tokens of its
+// argument. between has a single argument per bound, and no row of eqTokens.
+func (fc *functionContext) compareRow(val types.Val, row int) bool {
+	if fc.fname == between {
+		return types.CompareBetween(val, fc.ineqValue, fc.ineqValueHi)
+	}
+	return types.CompareVals(fc.fname, val, fc.eqTokens[row])
 }
 
 const (
-	eq = "eq" // equal
+	eq      = "eq"      // equal
+	between = "between" // closed range
 )
 
 func ensureArgsCount(srcFunc *pb.SrcFunction, expected int) error {
@@ -1449,6 +1525,31 @@ func parseSrcFn(q *pb.Query) (*functionContext, error) {
 		fc.n = len(q.UidList.Uids)
 	case CompareAttrFn:
 		args := q.SrcFunc.Args
+		if fc.fname == between {
+			if err = ensureArgsCount(q.SrcFunc, 2); err != nil {
+				return nil, err
+			}
+			if fc.ineqValue, err = convertValue(attr, args[0]); err != nil {
+				return nil, x.Errorf("Got error: %v while running: %v", err,
+					q.SrcFunc)
+			}
+			if fc.ineqValueHi, err = convertValue(attr, args[1]); err != nil {
+				return nil, x.Errorf("Got error: %v while running: %v", err,
+					q.SrcFunc)
+			}
+			if fc.tokens, fc.ineqValueToken, fc.ineqValueHiToken, err = getRangeTokens(
+				q.ReadTs, attr, fc.ineqValue, fc.ineqValueHi); err != nil {
+				return nil, err
+			}
+			if q.UidList != nil && len(fc.tokens) > len(q.UidList.Uids) {
+				fc.tokens = fc.tokens[:0]
+				fc.n = len(q.UidList.Uids)
+			} else {
+				fc.n = len(fc.tokens)
+			}
+			break
+		}
+
 		// Only eq can have multiple args. It should have atleast one.
 		if fc.fname == eq {
 			if len(args) < 1 {
@@ -1492,6 +1593,9 @@ func parseSrcFn(q *pb.Query) (*functionContext, error) {
 			fc.n = len(fc.tokens)
 		}
 	case CompareScalarFn:
+		if fc.fname == between {
+			return nil, x.Errorf("between is not supported with count")
+		}
 		if err = ensureArgsCount(q.SrcFunc, 1); err != nil {
 			return nil, err
 		}
@@ -1804,6 +1908,9 @@ func preprocessFilter(tree *pb.FilterTree) (*facetsTree, error) {
 		ftree.function.args = tree.Func.Args
 
 		fnType, fname := parseFuncTypeHelper(ftree.function.name)
+		if fname == between {
+			return nil, x.Errorf("Fn %s not supported in preprocessFilter.", fname)
+		}
 		if len(tree.Func.Args) != 1 {
 			return nil, x.Errorf("One argument expected in %s, but got %d.",
 				fname, len(tree.Func.Args))
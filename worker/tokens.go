@@ -191,3 +191,63 @@ func getInequalityTokens(readTs uint64, attr, f string,
 	}
 	return out, ineqToken, nil
 }
+
+// getRangeTokens gets the tokens lying between the tokens of lo and hi, both inclusive, using
+// the first sortable index that is found for the predicate. Unlike evaluating ge and le
+// separately, the index is walked once, from the lower bound up to the upper one.
+func getRangeTokens(readTs uint64, attr string, lo, hi types.Val) ([]string, string, string,
+	error) {
+	tokenizer, err := pickTokenizer(attr, between)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	buildToken := func(v types.Val) (string, error) {
+		tokens, err := tok.BuildTokens(v.Value, tok.GetLangTokenizer(tokenizer, "en"))
+		if err != nil {
+			return "", err
+		}
+		if len(tokens) != 1 {
+			return "", x.Errorf("Attribute %s does not have a valid tokenizer.", attr)
+		}
+		return tokens[0], nil
+	}
+	loToken, err := buildToken(lo)
+	if err != nil {
+		return nil, "", "", err
+	}
+	hiToken, err := buildToken(hi)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if types.CompareVals("lt", hi, lo) {
+		// Empty range.
+		return nil, loToken, hiToken, nil
+	}
+
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	itOpt := badger.DefaultIteratorOptions
+	itOpt.PrefetchValues = false
+	itOpt.Prefix = x.IndexKey(attr, string(tokenizer.Identifier()))
+	itr := txn.NewIterator(itOpt)
+	defer itr.Close()
+
+	// Tokens of sortable tokenizers sort the same way as the values they were built from, so
+	// we can stop as soon as we go past the token of the upper bound. For lossy tokenizers the
+	// bucket of each bound is included and filtered later in handleCompareFunction.
+	hiTokenInBytes := []byte(hiToken)
+	var out []string
+	for itr.Seek(x.IndexKey(attr, loToken)); itr.Valid(); itr.Next() {
+		k := x.Parse(itr.Item().Key())
+		if k == nil {
+			continue
+		}
+		if bytes.Compare([]byte(k.Term), hiTokenInBytes) > 0 {
+			break
+		}
+		out = append(out, k.Term)
+	}
+	return out, loToken, hiToken, nil
+}
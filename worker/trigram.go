@@ -43,7 +43,7 @@ func uidsForRegex(attr string, arg funcArgs,
 
 	uidsForTrigram := func(trigram string) (*pb.List, error) {
 		key := x.IndexKey(attr, trigram)
-		pl, err := posting.GetNoStore(key)
+		pl, err := posting.GetNoStoreAt(key, ingestListTs(attr, opts.ReadTs))
 		if err != nil {
 			return nil, err
 		}
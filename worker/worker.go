@@ -54,11 +54,13 @@ func Init(ps *badger.DB) {
 	pstore = ps
 	// needs to be initialized after group config
 	pendingProposals = make(chan struct{}, x.WorkerConfig.NumPendingProposals)
-	workerServer = grpc.NewServer(
+	opts := append([]grpc.ServerOption{
 		grpc.MaxRecvMsgSize(x.GrpcMaxSize),
 		grpc.MaxSendMsgSize(x.GrpcMaxSize),
 		grpc.MaxConcurrentStreams(math.MaxInt32),
-		grpc.StatsHandler(&ocgrpc.ServerHandler{}))
+		grpc.StatsHandler(&ocgrpc.ServerHandler{})},
+		conn.ServerOptions()...)
+	workerServer = grpc.NewServer(opts...)
 }
 
 // grpcWorker struct implements the gRPC server interface.
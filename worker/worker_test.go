@@ -30,6 +30,7 @@ import (
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
 )
 
@@ -299,6 +300,49 @@ func TestProcessTaskIndex(t *testing.T) {
 	}, algo.ToUintsListForTest(r.UidMatrix))
 }
 
+// between on a lossy tokenizer filters the values of the buckets holding its bounds.
+func TestProcessTaskBetweenLossy(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`dob: datetime @index(hour) .`), 1))
+	dobs := map[uint64]string{
+		10: "2019-01-01T10:05:00Z",
+		11: "2019-01-01T10:40:00Z",
+		12: "2019-01-01T11:20:00Z",
+		13: "2019-01-01T11:50:00Z",
+		14: "2019-01-01T13:00:00Z",
+	}
+	for uid, dob := range dobs {
+		val, err := types.Convert(types.Val{Tid: types.StringID, Value: []byte(dob)}, types.DateTimeID)
+		require.NoError(t, err)
+		bin := types.ValueForType(types.BinaryID)
+		require.NoError(t, types.Marshal(val, &bin))
+		edge := &pb.DirectedEdge{
+			Value:     bin.Value.([]byte),
+			ValueType: pb.Posting_DATETIME,
+			Label:     "author0",
+			Attr:      "dob",
+			Entity:    uid,
+		}
+		addEdge(t, edge, getOrCreate(x.DataKey("dob", uid)))
+	}
+
+	for _, tc := range []struct {
+		lo, hi string
+		uids   []uint64
+	}{
+		{"2019-01-01T10:30:00Z", "2019-01-01T11:30:00Z", []uint64{11, 12}},
+		{"2019-01-01T10:30:00Z", "2019-01-01T10:50:00Z", []uint64{11}},
+		{"2019-01-01T10:10:00Z", "2019-01-01T10:20:00Z", []uint64{}},
+		{"2019-01-01T10:00:00Z", "2019-01-01T13:00:00Z", []uint64{10, 11, 12, 13, 14}},
+	} {
+		query := newQuery("dob", nil, []string{"between", "", tc.lo, tc.hi})
+		query.UidList = nil // at root
+		r, err := helpProcessTask(query, 1)
+		require.NoError(t, err)
+		require.Equal(t, tc.uids, algo.MergeSorted(r.UidMatrix).Uids,
+			"between(dob, %s, %s)", tc.lo, tc.hi)
+	}
+}
+
 /*
 func populateGraphForSort(t *testing.T, ps store.Store) {
 	edge := &pb.DirectedEdge{
@@ -610,6 +654,7 @@ func TestMain(m *testing.M) {
 	gr.tablets["name"] = &pb.Tablet{GroupId: 1}
 	gr.tablets["name2"] = &pb.Tablet{GroupId: 1}
 	gr.tablets["age"] = &pb.Tablet{GroupId: 1}
+	gr.tablets["dob"] = &pb.Tablet{GroupId: 1}
 	gr.tablets["friend"] = &pb.Tablet{GroupId: 1}
 	gr.tablets["http://www.w3.org/2000/01/rdf-schema#range"] = &pb.Tablet{GroupId: 1}
 	gr.tablets["friend_not_served"] = &pb.Tablet{GroupId: 2}
@@ -626,6 +671,7 @@ func TestMain(m *testing.M) {
 	x.Check(err)
 	pstore = ps
 	posting.Init(ps)
+	schema.Init(ps)
 	Init(ps)
 	os.Exit(m.Run())
 }
@@ -43,6 +43,12 @@ type WorkerOptions struct {
 	MaxRetries          int
 	StrictMutations     bool
 	AclEnabled          bool
+	// EncryptionKey is the key used to encrypt backups and exports, if any.
+	EncryptionKey []byte
+	// CDCFile is the file committed transactions are written to, if any.
+	CDCFile string
+	// Learner is true if this server joins its group as a non-voting learner replica.
+	Learner bool
 }
 
 var WorkerConfig WorkerOptions
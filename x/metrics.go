@@ -39,6 +39,8 @@ var (
 		"Total number of edges created", stats.UnitDimensionless)
 	LatencyMs = stats.Float64("latency",
 		"Latency of the various methods", stats.UnitMilliseconds)
+	RateLimitedRequests = stats.Int64("rate_limited_requests_total",
+		"Total number of requests rejected by a rate limit", stats.UnitDimensionless)
 
 	// value at particular point of time
 	PendingQueries = stats.Int64("pending_queries_total",
@@ -57,6 +59,8 @@ var (
 		"Number of active mutations", stats.UnitDimensionless)
 	AlphaHealth = stats.Int64("alpha_health_status",
 		"Status of the alphas", stats.UnitDimensionless)
+	RateLimitActiveRequests = stats.Int64("rate_limit_active_requests_total",
+		"Number of active requests charged to a rate limit", stats.UnitDimensionless)
 
 	// TODO: Request statistics, latencies, 500, timeouts
 	Conf *expvar.Map
@@ -67,6 +71,8 @@ var (
 	KeyStatus, _ = tag.NewKey("status")
 	KeyError, _  = tag.NewKey("error")
 	KeyMethod, _ = tag.NewKey("method")
+	// KeyLimit is the user or group of a rate limit, or ip for the limits of IP addresses.
+	KeyLimit, _ = tag.NewKey("limit")
 
 	// Tag values here
 	TagValueStatusOK    = "ok"
@@ -104,6 +110,13 @@ var allViews = []*view.View{
 		Aggregation: view.Count(),
 		TagKeys:     allTagKeys,
 	},
+	{
+		Name:        RateLimitedRequests.Name(),
+		Measure:     RateLimitedRequests,
+		Description: RateLimitedRequests.Description(),
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{KeyLimit},
+	},
 
 	// Last value aggregations
 	{
@@ -162,6 +175,13 @@ var allViews = []*view.View{
 		Aggregation: view.LastValue(),
 		TagKeys:     allTagKeys,
 	},
+	{
+		Name:        RateLimitActiveRequests.Name(),
+		Measure:     RateLimitActiveRequests,
+		Description: RateLimitActiveRequests.Description(),
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{KeyLimit},
+	},
 }
 
 func init() {
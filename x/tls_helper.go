@@ -37,6 +37,8 @@ const (
 
 const (
 	tlsRootCert = "ca.crt"
+	tlsNodeCert = "node.crt"
+	tlsNodeKey  = "node.key"
 )
 
 // TLSHelperConfig define params used to create a tls.Config
@@ -62,6 +64,61 @@ func RegisterClientTLSFlags(flag *pflag.FlagSet) {
 		"provided by the client to the server.")
 }
 
+// RegisterInternalTLSFlags registers the flags of the mutual TLS between the Zeros and Alphas.
+func RegisterInternalTLSFlags(flag *pflag.FlagSet) {
+	flag.String("tls_internal_dir", "",
+		"Path to the directory with the ca.crt, node.crt and node.key files created by "+
+			"'dgraph cert', used for mutual TLS between Zeros and Alphas. The certificate of "+
+			"each node must be valid for the host of its --my address. Internal traffic is "+
+			"plaintext if not set.")
+	flag.String("tls_internal_clients", "",
+		"Comma separated names of the client certificates created by 'dgraph cert -c' that "+
+			"the loaders can use to lease UIDs and timestamps, and to ingest the output of the "+
+			"bulk loader, when --tls_internal_dir is set. Other client certificates are refused.")
+}
+
+// InternalTLSClients returns the names of the client certificates set by
+// --tls_internal_clients.
+func InternalTLSClients(v *viper.Viper) []string {
+	var names []string
+	for _, name := range strings.Split(v.GetString("tls_internal_clients"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// LoadInternalTLSConfig returns the server and client configs of the mutual TLS between the
+// Zeros and Alphas, or nils if --tls_internal_dir isn't set. Both sides present the node
+// certificate, and only trust the certificates issued by the CA of the directory.
+func LoadInternalTLSConfig(v *viper.Viper) (*tls.Config, *tls.Config, error) {
+	dir := v.GetString("tls_internal_dir")
+	if dir == "" {
+		return nil, nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(path.Join(dir, tlsNodeCert), path.Join(dir, tlsNodeKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	pool, err := generateCertPool(path.Join(dir, tlsRootCert), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	server := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	client := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	return server, client, nil
+}
+
 func LoadServerTLSConfig(v *viper.Viper, tlsCertFile string, tlsKeyFile string) (*tls.Config,
 	error) {
 	conf := TLSHelperConfig{}
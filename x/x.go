@@ -53,6 +53,10 @@ const (
 	Error               = "Error"
 	ErrorNoData         = "ErrorNoData"
 	ValidHostnameRegex  = "^(([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\\-]*[a-zA-Z0-9])\\.)*([A-Za-z0-9]|[A-Za-z0-9][A-Za-z0-9\\-]*[A-Za-z0-9])$"
+
+	// ErrorResourceExhausted is returned for the requests over a rate limit.
+	ErrorResourceExhausted = "ErrorResourceExhausted"
+
 	// When changing this value also remember to change in in client/client.go:DeleteEdges.
 	Star = "_STAR_ALL"
 
@@ -64,6 +68,9 @@ const (
 	// The attr used to store list of predicates for a node.
 	PredicateListAttr = "_predicate_"
 
+	// FacetDelimeter separates a predicate from the key of its facet in JSON.
+	FacetDelimeter = "|"
+
 	PortZeroGrpc = 5080
 	PortZeroHTTP = 6080
 	PortInternal = 7080
@@ -43,7 +43,10 @@ type XidMap struct {
 	maxUidSeen uint64
 
 	// Optionally, these can be set to persist the mappings.
+	db     *badger.DB
 	writer *badger.WriteBatch
+	// Held for writing while Checkpoint replaces the writer.
+	writerMu sync.RWMutex
 }
 
 type shard struct {
@@ -85,6 +88,7 @@ func New(zero *grpc.ClientConn, db *badger.DB) *XidMap {
 	}
 	if db != nil {
 		// If DB is provided, let's load up all the xid -> uid mappings in memory.
+		xm.db = db
 		xm.writer = db.NewWriteBatch()
 
 		err := db.View(func(txn *badger.Txn) error {
@@ -171,7 +175,10 @@ func (m *XidMap) AssignUid(xid string) uint64 {
 	if m.writer != nil {
 		var uidBuf [8]byte
 		binary.BigEndian.PutUint64(uidBuf[:], newUid)
-		if err := m.writer.Set([]byte(xid), uidBuf[:], 0); err != nil {
+		m.writerMu.RLock()
+		err := m.writer.Set([]byte(xid), uidBuf[:], 0)
+		m.writerMu.RUnlock()
+		if err != nil {
 			panic(err)
 		}
 	}
@@ -232,3 +239,18 @@ func (m *XidMap) Flush() error {
 	}
 	return m.writer.Flush()
 }
+
+// Checkpoint persists the mappings assigned so far to the DB, if one was provided. Unlike Flush,
+// the XidMap can still be used afterwards.
+func (m *XidMap) Checkpoint() error {
+	if m.writer == nil {
+		return nil
+	}
+	m.writerMu.Lock()
+	defer m.writerMu.Unlock()
+	if err := m.writer.Flush(); err != nil {
+		return err
+	}
+	m.writer = m.db.NewWriteBatch()
+	return nil
+}
@@ -53,12 +53,16 @@ func TestXidmap(t *testing.T) {
 		uid := xidmap.AllocateUid() // Does not have to be above the bump.
 		t.Logf("bump up to: %d. allocated: %d", to, uid)
 
+		require.NoError(t, xidmap.Checkpoint())
+		uidc := xidmap.AssignUid("c")
+
 		require.NoError(t, xidmap.Flush())
 		xidmap = nil
 
 		xidmap2 := New(conn, db)
 		require.Equal(t, uida, xidmap2.AssignUid("a"))
 		require.Equal(t, uidb, xidmap2.AssignUid("b"))
+		require.Equal(t, uidc, xidmap2.AssignUid("c"))
 	})
 }
 